@@ -2,33 +2,67 @@ package adapters
 
 import (
 	"address-validator/config"
+	"address-validator/logging"
 	"address-validator/ports"
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	// Using standard log for simplicity, replace with zap if needed
 	"go.uber.org/zap" // Assuming you use zap for logging
 	addressvalidation "google.golang.org/api/addressvalidation/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 )
 
+// API_KEY_HEADER carries the API key of each call so keys can rotate on a single client
+const API_KEY_HEADER = "X-Goog-Api-Key"
+
 type GoogleAddressValidationAdapter struct {
 	client *addressvalidation.Service
+	keys   *apiKeyPool
 	logger *zap.Logger      // Using zap as in your example
 	config config.MapConfig // Keeping your config type for consistency
+
+	quotaErrors  atomic.Uint64
+	deniedErrors atomic.Uint64
 }
 
-// NewGoogleAddressValidationAdapter creates a new Google Address Validation adapter
-func NewGoogleAddressValidationAdapter(config config.MapConfig, logger *zap.Logger) (*GoogleAddressValidationAdapter, error) {
+// RETRY_BACKOFF is the wait before the first retry of a transient failure, doubled for each further one
+const RETRY_BACKOFF = 100 * time.Millisecond
+
+// NewGoogleAddressValidationAdapter creates a new Google Address Validation adapter.
+// Requests rotate across config.GoogleMapsAPIKeys, falling back to config.GoogleMapsAPIKey.
+// Calls use an HTTP client bounded by config.ProviderTimeout.
+// Extra client options are appended, e.g. to point at a test endpoint or inject another client.
+func NewGoogleAddressValidationAdapter(config config.MapConfig, logger *zap.Logger, opts ...option.ClientOption) (*GoogleAddressValidationAdapter, error) {
 	ctx := context.Background()
-	client, err := addressvalidation.NewService(ctx, option.WithAPIKey(config.GoogleMapsAPIKey)) // Using API Key as in your example
+	keys := config.GoogleMapsAPIKeys
+	if len(keys) == 0 {
+		keys = []string{config.GoogleMapsAPIKey}
+	}
+
+	// The key is sent per call instead of by the client transport
+	defaults := []option.ClientOption{option.WithoutAuthentication()}
+	if config.ProviderTimeout > 0 {
+		defaults = append(defaults, option.WithHTTPClient(&http.Client{Timeout: config.ProviderTimeout}))
+	}
+	opts = append(defaults, opts...)
+	client, err := addressvalidation.NewService(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Google Address Validation service: %w", err)
 	}
 
 	return &GoogleAddressValidationAdapter{
 		client: client,
+		keys:   newAPIKeyPool(keys, config.APIKeyCooldown),
 		logger: logger,
 		config: config,
 	}, nil
@@ -41,62 +75,544 @@ func (gava *GoogleAddressValidationAdapter) ValidateAddress(ctx context.Context,
 		IsValid: false,
 	}
 
+	// A region requested for the call replaces the configured country, the configured locality only applies
+	// to the latter. A requested locality applies to either, no locality at all is sent when neither is set
+	regionCode, locality := gava.config.Country, gava.config.Locality
+	if requested := ports.RegionCode(ctx); requested != "" && !strings.EqualFold(requested, regionCode) {
+		regionCode, locality = requested, ""
+	}
+	if requested := ports.Locality(ctx); requested != "" {
+		locality = requested
+	}
+
 	// Call Google Address Validation API
 	req := &addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressRequest{
 		Address: &addressvalidation.GoogleTypePostalAddress{
 			AddressLines: []string{address},
-			RegionCode:   gava.config.Country,
-			Locality:     gava.config.Locality,
+			RegionCode:   regionCode,
+			Locality:     locality,
 		},
+		// CASS standardization is only defined for US addresses
+		EnableUspsCass: gava.config.EnableUSPSCass && strings.EqualFold(regionCode, "us"),
 	}
 
-	gava.logger.Debug("calling Google Address Validation API", zap.Any("request", req))
-	resp, err := gava.client.V1.ValidateAddress(req).Do()
+	logging.FromContext(ctx, gava.logger).Debug("calling Google Address Validation API", zap.Any("request", req))
+	resp, err := gava.validate(ctx, req)
 	if err != nil {
 		gava.logger.Error("address validation error", zap.Error(err))
 		result.Error = "Failed to validate address: " + err.Error()
+
+		// Quota and billing problems are operational, surface them distinctly from bad input
+		switch classifyProviderError(err) {
+		case ports.ErrProviderQuota:
+			gava.quotaErrors.Add(1)
+			result.ErrorCode = ports.ERROR_CODE_PROVIDER_QUOTA
+			return result, fmt.Errorf("address validation error: %w: %w", ports.ErrProviderQuota, err)
+		case ports.ErrProviderDenied:
+			gava.deniedErrors.Add(1)
+			result.ErrorCode = ports.ERROR_CODE_PROVIDER_DENIED
+			return result, fmt.Errorf("address validation error: %w: %w", ports.ErrProviderDenied, err)
+		}
+		if isProviderTimeout(ctx, err) {
+			result.ErrorCode = ports.ERROR_CODE_PROVIDER_TIMEOUT
+			return result, fmt.Errorf("address validation error: %w: %w", ports.ErrProviderTimeout, err)
+		}
 		return result, fmt.Errorf("address validation error: %w", err)
 	}
 
-	// Check the validation results
-	if resp != nil && resp.Result != nil && resp.Result.Verdict != nil {
-		verdict := resp.Result.Verdict
+	// Hand the untouched payload to a debugging caller, it never carries the API key
+	if capture := ports.RawCaptureFrom(ctx); capture != nil && resp != nil {
+		if data, err := resp.MarshalJSON(); err == nil {
+			capture.Set(data)
+		}
+	}
+
+	// Nothing usable without an address or a location, a missing verdict alone is not fatal
+	if resp == nil || resp.Result == nil || !hasUsableResult(resp.Result) {
+		gava.logger.Warn("no validation result found for address")
+		result.Error = "No validation result found."
+		return result, ports.ErrAddressNotFound
+	}
+	validation := resp.Result
 
-		// Consider an address valid if it's at least Premises level and complete
-		if verdict.ValidationGranularity >= "PREMISE" && verdict.AddressComplete {
-			result.IsValid = true
+	if address := validation.Address; address != nil {
+		result.FormattedAddress = address.FormattedAddress
+		var adminArea string
+		if address.PostalAddress != nil {
+			result.RegionCode = strings.ToUpper(address.PostalAddress.RegionCode)
+			adminArea = address.PostalAddress.AdministrativeArea
 		}
+		if adminArea == "" {
+			adminArea = componentText(address.AddressComponents, "administrative_area_level_1")
+		}
+		result.CountryCode = result.RegionCode
+		result.AdminAreaCode = adminAreaCode(result.CountryCode, adminArea)
+		result.StreetLevel = hasStreetComponent(address.AddressComponents)
+		result.Components = addressComponents(address.AddressComponents)
+	}
 
-		if resp.Result.Address != nil && resp.Result.Address.FormattedAddress != "" {
-			result.FormattedAddress = resp.Result.Address.FormattedAddress
+	if geocode := validation.Geocode; geocode != nil {
+		if geocode.Location != nil {
+			result.Latitude = geocode.Location.Latitude
+			result.Longitude = geocode.Location.Longitude
 		}
+		if geocode.Bounds != nil {
+			result.Viewport = viewport(geocode.Bounds)
+		}
+		if geocode.PlusCode != nil {
+			result.PlusCode = geocode.PlusCode.GlobalCode
+		}
+		if len(geocode.PlaceTypes) > 0 {
+			result.Types = geocode.PlaceTypes
+		}
+	}
+
+	if validation.UspsData != nil {
+		result.USPS = uspsData(validation.UspsData)
+	}
+	result.AddressType = addressType(validation.Metadata, validation.UspsData)
+
+	verdict := validation.Verdict
+	if verdict == nil {
+		// The fields above are still returned for display, but the address cannot be confirmed
+		gava.logger.Warn("validation response has no verdict")
+		result.Error = "Address could not be verified."
+		return result, nil
+	}
+
+	// Consider an address valid if it's at least Premises level and complete
+	if verdict.ValidationGranularity >= "PREMISE" && verdict.AddressComplete {
+		result.IsValid = true
+	}
 
-		if resp.Result.Geocode != nil && resp.Result.Geocode.Location != nil {
-			result.Latitude = resp.Result.Geocode.Location.Latitude
-			result.Longitude = resp.Result.Geocode.Location.Longitude
+	result.Deliverable = deliverable(verdict, validation.UspsData)
+
+	// Google flags components it swapped for different ones, e.g. a corrected street name
+	result.ReplacedInput = verdict.HasReplacedComponents
+	result.LocationType = locationType(verdict.GeocodeGranularity)
+	result.InputGranularity = knownGranularity(verdict.InputGranularity)
+	result.Granularity = knownGranularity(verdict.ValidationGranularity)
+	result.InferenceGap = inferenceGap(verdict.InputGranularity, verdict.ValidationGranularity)
+	result.Confidence = granularityConfidence[verdict.ValidationGranularity]
+
+	// You might want to add more detailed error information based on the verdict
+	if !result.IsValid {
+		var errors []string
+		if verdict.InputGranularity == "OTHER" {
+			errors = append(errors, "Input address was not recognized.")
+		}
+		if !verdict.AddressComplete {
+			errors = append(errors, "Address is incomplete.")
 		}
+		// Add more checks based on your requirements
+		if len(errors) > 0 {
+			result.Error = strings.Join(errors, " ")
+		} else if result.Error == "" {
+			result.Error = "Address validation failed based on granularity."
+		}
+		result.Suggestions = suggestions(validation.Address, verdict)
+	}
 
-		// You might want to add more detailed error information based on the verdict
-		if !result.IsValid {
-			var errors []string
-			if verdict.InputGranularity == "OTHER" {
-				errors = append(errors, "Input address was not recognized.")
-			}
-			if !verdict.AddressComplete {
-				errors = append(errors, "Address is incomplete.")
-			}
-			// Add more checks based on your requirements
-			if len(errors) > 0 {
-				result.Error = strings.Join(errors, " ")
-			} else if result.Error == "" {
-				result.Error = "Address validation failed based on granularity."
-			}
+	return result, nil
+}
+
+// validate calls the API with the next available key, failing over to the other keys
+// while the provider rejects them for exceeding their quota
+func (gava *GoogleAddressValidationAdapter) validate(ctx context.Context, req *addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressRequest) (*addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressResponse, error) {
+	var resp *addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressResponse
+	var err error
+	for attempt := 0; attempt < len(gava.keys.keys); attempt++ {
+		index, available := gava.keys.pick()
+		// Once every key is cooling down only one call is spent on the one recovering first
+		if !available && attempt > 0 {
+			break
+		}
+
+		resp, err = gava.call(ctx, req, gava.keys.keys[index])
+		if err == nil || classifyProviderError(err) != ports.ErrProviderQuota {
+			return resp, err
+		}
+
+		gava.keys.rateLimited(index)
+		gava.logger.Warn("API key rate limited, skipping it during cooldown",
+			zap.Int("keyIndex", index),
+			zap.Duration("cooldown", gava.keys.cooldown),
+		)
+	}
+	return resp, err
+}
+
+// call sends the request with the given key, retrying timeouts and server errors up to
+// config.ProviderMaxRetries times with an exponential backoff.
+// Each attempt gets its own config.ProviderTimeout deadline within the caller's, so an attempt
+// running out of time is retried while the caller's deadline leaves room for it. A caller that
+// cancelled, e.g. a disconnected client, or whose own deadline passed is never retried.
+func (gava *GoogleAddressValidationAdapter) call(ctx context.Context, req *addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressRequest, key string) (*addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressResponse, error) {
+	backoff := RETRY_BACKOFF
+	for retry := 0; ; retry++ {
+		resp, err := gava.attempt(ctx, req, key)
+		if err == nil || retry >= gava.config.ProviderMaxRetries || !isRetryable(ctx, err) || !hasBudget(ctx, backoff) {
+			return resp, err
 		}
+
+		logging.FromContext(ctx, gava.logger).Warn("retrying provider call",
+			zap.Int("retry", retry+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// attempt sends the request once, bounded by config.ProviderTimeout within the caller's deadline
+func (gava *GoogleAddressValidationAdapter) attempt(ctx context.Context, req *addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressRequest, key string) (*addressvalidation.GoogleMapsAddressvalidationV1ValidateAddressResponse, error) {
+	if gava.config.ProviderTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, gava.config.ProviderTimeout)
+		defer cancel()
+	}
+
+	call := gava.client.V1.ValidateAddress(req).Context(ctx)
+	call.Header().Set(API_KEY_HEADER, key)
+	return call.Do()
+}
+
+// isRetryable reports whether a failed call may succeed when repeated, i.e. it timed out
+// or the provider answered with a server error
+func isRetryable(ctx context.Context, err error) bool {
+	// Nobody is waiting for the answer anymore
+	if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		return false
+	}
+	if isProviderTimeout(ctx, err) {
+		return true
+	}
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code >= http.StatusInternalServerError
+}
+
+// hasBudget reports whether the caller's deadline leaves time to wait out the backoff and try again
+func hasBudget(ctx context.Context, backoff time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > backoff
+}
+
+// isProviderTimeout reports whether an attempt ran out of time, by its own deadline or the HTTP client's.
+// A deadline or cancellation of the caller's own context is not a provider timeout.
+func isProviderTimeout(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// redactURL drops the query from the URL of a failed request, which carries the credentials of the
+// REST providers, so the error can be logged and wrapped without leaking them
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	if parsed, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		parsed.RawQuery = ""
+		urlErr.URL = parsed.String()
 	} else {
-		gava.logger.Warn("no validation result found for address")
-		result.Error = "No validation result found."
-		return result, fmt.Errorf("no validation result found")
+		urlErr.URL = ""
 	}
+	return err
+}
 
-	return result, nil
+// Hints for missing and unconfirmed component types, other types fall back to their name
+var (
+	missingHints = map[string]string{
+		"subpremise":                  "Add a unit number",
+		"street_number":               "Add a street number",
+		"route":                       "Add a street name",
+		"postal_code":                 "Add a postal code",
+		"locality":                    "Add a city",
+		"administrative_area_level_1": "Add a state",
+	}
+	unconfirmedHints = map[string]string{
+		"subpremise":    "Confirm the unit number",
+		"street_number": "Confirm the street number",
+		"route":         "Street not recognized, check its spelling",
+		"postal_code":   "Confirm postal code",
+		"locality":      "Confirm the city",
+	}
+)
+
+// suggestions lists what the user can fix in an invalid address, from the same verdict and
+// components that explain result.Error
+func suggestions(address *addressvalidation.GoogleMapsAddressvalidationV1Address, verdict *addressvalidation.GoogleMapsAddressvalidationV1Verdict) []string {
+	var hints []string
+	add := func(hint string) {
+		if !slices.Contains(hints, hint) {
+			hints = append(hints, hint)
+		}
+	}
+
+	if verdict.InputGranularity == "OTHER" {
+		add("Address not recognized, check it for typos")
+	}
+	if address == nil {
+		return hints
+	}
+
+	for _, componentType := range address.MissingComponentTypes {
+		if hint, ok := missingHints[componentType]; ok {
+			add(hint)
+		} else {
+			add("Add the " + strings.ReplaceAll(componentType, "_", " "))
+		}
+	}
+	for _, componentType := range address.UnconfirmedComponentTypes {
+		if hint, ok := unconfirmedHints[componentType]; ok {
+			add(hint)
+		} else {
+			add("Confirm the " + strings.ReplaceAll(componentType, "_", " "))
+		}
+	}
+	if len(address.UnresolvedTokens) > 0 {
+		add(fmt.Sprintf("Remove or correct %q", strings.Join(address.UnresolvedTokens, " ")))
+	}
+	return hints
+}
+
+// hasUsableResult reports whether a response carries a verdict, a formatted address or a location
+func hasUsableResult(validation *addressvalidation.GoogleMapsAddressvalidationV1ValidationResult) bool {
+	if validation.Verdict != nil {
+		return true
+	}
+	if validation.Address != nil && validation.Address.FormattedAddress != "" {
+		return true
+	}
+	return validation.Geocode != nil && validation.Geocode.Location != nil
+}
+
+// Provider reports the provider answering the calls
+func (gava *GoogleAddressValidationAdapter) Provider() string {
+	return ports.PROVIDER_GOOGLE
+}
+
+// CollectMetrics reports provider quota and denial errors for the metrics endpoint
+func (gava *GoogleAddressValidationAdapter) CollectMetrics() []ports.Metric {
+	return []ports.Metric{
+		{Name: "provider_quota_errors_total", Help: "Upstream calls rejected for exceeding the provider query limit.", Type: ports.METRIC_COUNTER, Value: float64(gava.quotaErrors.Load())},
+		{Name: "provider_denied_errors_total", Help: "Upstream calls denied by the provider, e.g. invalid key or billing disabled.", Type: ports.METRIC_COUNTER, Value: float64(gava.deniedErrors.Load())},
+	}
+}
+
+// classifyProviderError maps Google quota and denial responses to ports.ErrProviderQuota or
+// ports.ErrProviderDenied, and returns nil for any other error.
+// Both the legacy Maps statuses and the Cloud API statuses are recognized.
+func classifyProviderError(err error) error {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	detail := apiErr.Message + " " + apiErr.Body
+	switch {
+	case apiErr.Code == http.StatusTooManyRequests,
+		strings.Contains(detail, "OVER_QUERY_LIMIT"),
+		strings.Contains(detail, "RESOURCE_EXHAUSTED"):
+		return ports.ErrProviderQuota
+	case apiErr.Code == http.StatusForbidden,
+		strings.Contains(detail, "REQUEST_DENIED"),
+		strings.Contains(detail, "PERMISSION_DENIED"),
+		strings.Contains(detail, "BILLING_DISABLED"),
+		strings.Contains(detail, "BILLING_NOT_ENABLED"):
+		return ports.ErrProviderDenied
+	default:
+		return nil
+	}
+}
+
+// locationType maps the granularity of a geocode to the Geocoding API location type scale
+func locationType(granularity string) string {
+	switch granularity {
+	case "SUB_PREMISE", "PREMISE":
+		return ports.LOCATION_TYPE_ROOFTOP
+	case "PREMISE_PROXIMITY":
+		return ports.LOCATION_TYPE_RANGE_INTERPOLATED
+	case "BLOCK", "ROUTE":
+		return ports.LOCATION_TYPE_GEOMETRIC_CENTER
+	case "":
+		return ""
+	default:
+		return ports.LOCATION_TYPE_APPROXIMATE
+	}
+}
+
+// granularityConfidence scores the validation granularity from 1 for a unit down to 0.1 for
+// an unrecognized address. An unspecified granularity has no score.
+var granularityConfidence = map[string]float64{
+	ports.GRANULARITY_SUB_PREMISE:       1,
+	ports.GRANULARITY_PREMISE:           0.9,
+	ports.GRANULARITY_PREMISE_PROXIMITY: 0.7,
+	ports.GRANULARITY_BLOCK:             0.5,
+	ports.GRANULARITY_ROUTE:             0.3,
+	ports.GRANULARITY_OTHER:             0.1,
+}
+
+// knownGranularity returns granularity, or "" when it is unspecified
+func knownGranularity(granularity string) string {
+	if !slices.Contains(ports.GRANULARITIES, granularity) {
+		return ""
+	}
+	return granularity
+}
+
+// inferenceGap counts the levels the validated address is finer than the input, e.g. a street-only
+// input (ROUTE) validated to a house (PREMISE) is 3. A large gap means Google filled in a lot.
+func inferenceGap(input string, validation string) int {
+	inputRank := slices.Index(ports.GRANULARITIES, input)
+	validationRank := slices.Index(ports.GRANULARITIES, validation)
+	if inputRank < 0 || validationRank < 0 || validationRank >= inputRank {
+		return 0
+	}
+	return inputRank - validationRank
+}
+
+// streetComponentTypes are the component types that place an address on a street
+var streetComponentTypes = []string{"street_number", "route", "premise", "subpremise"}
+
+// addressType classifies the address from the metadata Google returns for supported regions,
+// falling back to the USPS record type, P for a PO box and F for a firm
+func addressType(metadata *addressvalidation.GoogleMapsAddressvalidationV1AddressMetadata, usps *addressvalidation.GoogleMapsAddressvalidationV1UspsData) string {
+	var recordType string
+	if usps != nil {
+		recordType = usps.AddressRecordType
+	}
+
+	switch {
+	case metadata != nil && metadata.PoBox, recordType == "P":
+		return ports.ADDRESS_TYPE_PO_BOX
+	case metadata != nil && metadata.Business, recordType == "F":
+		return ports.ADDRESS_TYPE_COMMERCIAL
+	case metadata != nil && metadata.Residential:
+		return ports.ADDRESS_TYPE_RESIDENTIAL
+	default:
+		return ""
+	}
+}
+
+// deliverable reports whether mail can be delivered to the address. The USPS DPV confirmation
+// decides when present: Y is confirmed, while N, or D and S for a missing or unconfirmed unit, are not.
+// Without it an address short of premise level or incomplete is not deliverable, and a complete
+// premise is only when Google confirmed every component. nil means the provider cannot tell.
+func deliverable(verdict *addressvalidation.GoogleMapsAddressvalidationV1Verdict, usps *addressvalidation.GoogleMapsAddressvalidationV1UspsData) *bool {
+	yes, no := true, false
+	if usps != nil {
+		switch usps.DpvConfirmation {
+		case "Y":
+			return &yes
+		case "N", "D", "S":
+			return &no
+		}
+	}
+
+	premise := slices.Index(ports.GRANULARITIES, verdict.ValidationGranularity)
+	switch {
+	case premise < 0:
+		return nil
+	case premise > slices.Index(ports.GRANULARITIES, ports.GRANULARITY_PREMISE), !verdict.AddressComplete:
+		return &no
+	case verdict.HasUnconfirmedComponents:
+		return nil
+	default:
+		return &yes
+	}
+}
+
+// hasStreetComponent reports whether any component locates the address at street level
+func hasStreetComponent(components []*addressvalidation.GoogleMapsAddressvalidationV1AddressComponent) bool {
+	for _, component := range components {
+		if slices.Contains(streetComponentTypes, component.ComponentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// componentText returns the text of the first component of the type, or "" without one
+func componentText(components []*addressvalidation.GoogleMapsAddressvalidationV1AddressComponent, componentType string) string {
+	for _, component := range components {
+		if component != nil && component.ComponentType == componentType && component.ComponentName != nil {
+			return component.ComponentName.Text
+		}
+	}
+	return ""
+}
+
+// adminAreaCode returns the ISO 3166-2 code of a state or province, the country code and the
+// subdivision part, e.g. US-NY or CA-ON. Providers abbreviate the area for countries with postal
+// abbreviations and name it in full elsewhere; a full name has no code and yields "".
+func adminAreaCode(countryCode string, adminArea string) string {
+	adminArea = strings.ToUpper(strings.TrimSpace(adminArea))
+	if len(countryCode) != 2 || adminArea == "" || len(adminArea) > 3 {
+		return ""
+	}
+	for _, r := range adminArea {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return countryCode + "-" + adminArea
+}
+
+// addressComponents maps the components of a validated address, skipping those without a type
+func addressComponents(components []*addressvalidation.GoogleMapsAddressvalidationV1AddressComponent) []ports.AddressComponent {
+	var mapped []ports.AddressComponent
+	for _, component := range components {
+		if component == nil || component.ComponentType == "" {
+			continue
+		}
+		var text string
+		if component.ComponentName != nil {
+			text = component.ComponentName.Text
+		}
+		mapped = append(mapped, ports.AddressComponent{Type: component.ComponentType, Text: text})
+	}
+	return mapped
+}
+
+// viewport maps the bounds of a geocode, nil when either corner is missing
+func viewport(bounds *addressvalidation.GoogleGeoTypeViewport) *ports.Viewport {
+	if bounds.High == nil || bounds.Low == nil {
+		return nil
+	}
+	return &ports.Viewport{
+		NE: ports.LatLng{Lat: bounds.High.Latitude, Lng: bounds.High.Longitude},
+		SW: ports.LatLng{Lat: bounds.Low.Latitude, Lng: bounds.Low.Longitude},
+	}
+}
+
+// uspsData maps the CASS fields of a USPS-enabled response
+func uspsData(data *addressvalidation.GoogleMapsAddressvalidationV1UspsData) *ports.USPSData {
+	usps := &ports.USPSData{
+		DPVConfirmation: data.DpvConfirmation,
+		CarrierRoute:    data.CarrierRoute,
+	}
+
+	if address := data.StandardizedAddress; address != nil {
+		var lines []string
+		for _, line := range []string{address.FirstAddressLine, address.SecondAddressLine, address.CityStateZipAddressLine} {
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+		usps.StandardizedAddress = strings.Join(lines, ", ")
+	}
+
+	return usps
 }
@@ -0,0 +1,1191 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+)
+
+func TestGoogleAddressValidationAdapter_USPS(t *testing.T) {
+	deliverable := true
+	// Fabricated USPS-enabled response
+	const uspsResponse = `{
+		"result": {
+			"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+			"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457-7406, USA", "postalAddress": {"regionCode": "US"}},
+			"geocode": {"location": {"latitude": 40.8399, "longitude": -73.9115}},
+			"uspsData": {
+				"standardizedAddress": {
+					"firstAddressLine": "1600 GRAND CONCOURSE",
+					"cityStateZipAddressLine": "BRONX NY 10457-7406",
+					"city": "BRONX",
+					"state": "NY",
+					"zipCode": "10457",
+					"zipCodeExtension": "7406"
+				},
+				"dpvConfirmation": "Y",
+				"carrierRoute": "C012"
+			}
+		}
+	}`
+	const plainResponse = `{
+		"result": {
+			"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+			"address": {"formattedAddress": "10 Downing St, London SW1A 2AA, UK"},
+			"geocode": {"location": {"latitude": 51.5034, "longitude": -0.1276}}
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		config   config.MapConfig
+		response string
+		wantCass bool
+		want     ports.AddressValidationResult
+	}{
+		{
+			name:     "Test US With CASS Enabled Maps USPS Data",
+			config:   config.MapConfig{Country: "us", EnableUSPSCass: true},
+			response: uspsResponse,
+			wantCass: true,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457-7406, USA",
+				RegionCode:       "US",
+				CountryCode:      "US",
+				Latitude:         40.8399,
+				Longitude:        -73.9115,
+				InputGranularity: "PREMISE",
+				Granularity:      "PREMISE",
+				Confidence:       0.9,
+				Deliverable:      &deliverable,
+				USPS: &ports.USPSData{
+					StandardizedAddress: "1600 GRAND CONCOURSE, BRONX NY 10457-7406",
+					DPVConfirmation:     "Y",
+					CarrierRoute:        "C012",
+				},
+			},
+		},
+		{
+			name:     "Test US With CASS Disabled Omits Flag",
+			config:   config.MapConfig{Country: "us"},
+			response: plainResponse,
+			wantCass: false,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "10 Downing St, London SW1A 2AA, UK",
+				Latitude:         51.5034,
+				Longitude:        -0.1276,
+				InputGranularity: "PREMISE",
+				Granularity:      "PREMISE",
+				Confidence:       0.9,
+				Deliverable:      &deliverable,
+			},
+		},
+		{
+			name:     "Test Non US With CASS Enabled Omits Flag",
+			config:   config.MapConfig{Country: "gb", EnableUSPSCass: true},
+			response: plainResponse,
+			wantCass: false,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "10 Downing St, London SW1A 2AA, UK",
+				Latitude:         51.5034,
+				Longitude:        -0.1276,
+				InputGranularity: "PREMISE",
+				Granularity:      "PREMISE",
+				Confidence:       0.9,
+				Deliverable:      &deliverable,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCass bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				var req struct {
+					EnableUspsCass bool `json:"enableUspsCass"`
+				}
+				if err := json.Unmarshal(body, &req); err != nil {
+					t.Errorf("invalid request body: %v", err)
+				}
+				gotCass = req.EnableUspsCass
+
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			tt.config.GoogleMapsAPIKey = "test-key"
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(tt.config, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if gotCass != tt.wantCass {
+				t.Errorf("request enableUspsCass = %v, want %v", gotCass, tt.wantCass)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_Viewport(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *ports.Viewport
+	}{
+		{
+			name: "Test Geocode Bounds Map To Viewport",
+			response: `{"result": {
+				"verdict": {"validationGranularity": "PREMISE", "addressComplete": true},
+				"geocode": {"location": {"latitude": 40.8399, "longitude": -73.9115},
+					"bounds": {"high": {"latitude": 40.8412, "longitude": -73.9102}, "low": {"latitude": 40.8386, "longitude": -73.9128}}}
+			}}`,
+			want: &ports.Viewport{
+				NE: ports.LatLng{Lat: 40.8412, Lng: -73.9102},
+				SW: ports.LatLng{Lat: 40.8386, Lng: -73.9128},
+			},
+		},
+		{
+			name: "Test Missing Bounds Omit Viewport",
+			response: `{"result": {
+				"verdict": {"validationGranularity": "PREMISE", "addressComplete": true},
+				"geocode": {"location": {"latitude": 40.8399, "longitude": -73.9115}}
+			}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Viewport, tt.want) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() Viewport = %+v, want %+v", got.Viewport, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_InferenceGap(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		validation      string
+		wantInput       string
+		wantGranularity string
+		wantGap         int
+	}{
+		{
+			name: "Test Matching Granularities Have No Gap", input: "PREMISE", validation: "PREMISE",
+			wantInput: "PREMISE", wantGranularity: "PREMISE",
+		},
+		{
+			name: "Test Street Input Validated To Premise Has Gap", input: "ROUTE", validation: "PREMISE",
+			wantInput: "ROUTE", wantGranularity: "PREMISE", wantGap: 3,
+		},
+		{
+			name: "Test Unrecognized Input Validated To Sub Premise Has Gap", input: "OTHER", validation: "SUB_PREMISE",
+			wantInput: "OTHER", wantGranularity: "SUB_PREMISE", wantGap: 5,
+		},
+		{
+			name: "Test Coarser Validation Has No Gap", input: "SUB_PREMISE", validation: "ROUTE",
+			wantInput: "SUB_PREMISE", wantGranularity: "ROUTE",
+		},
+		{
+			name: "Test Unspecified Input Has No Gap", input: "GRANULARITY_UNSPECIFIED", validation: "PREMISE",
+			wantGranularity: "PREMISE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"result": {
+					"verdict": {"inputGranularity": %q, "validationGranularity": %q, "addressComplete": true},
+					"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"}
+				}}`, tt.input, tt.validation)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if got.InputGranularity != tt.wantInput || got.Granularity != tt.wantGranularity || got.InferenceGap != tt.wantGap {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() granularity = %q -> %q gap %d, want %q -> %q gap %d",
+					got.InputGranularity, got.Granularity, got.InferenceGap, tt.wantInput, tt.wantGranularity, tt.wantGap)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_Confidence(t *testing.T) {
+	tests := []struct {
+		name       string
+		validation string
+		want       float64
+	}{
+		{name: "Test Sub Premise Scores Highest", validation: "SUB_PREMISE", want: 1},
+		{name: "Test Premise Scores High", validation: "PREMISE", want: 0.9},
+		{name: "Test Premise Proximity Scores Above Medium", validation: "PREMISE_PROXIMITY", want: 0.7},
+		{name: "Test Block Scores Medium", validation: "BLOCK", want: 0.5},
+		{name: "Test Route Scores Low", validation: "ROUTE", want: 0.3},
+		{name: "Test Unrecognized Address Scores Lowest", validation: "OTHER", want: 0.1},
+		{name: "Test Unspecified Granularity Has No Score", validation: "GRANULARITY_UNSPECIFIED", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"result": {
+					"verdict": {"inputGranularity": "PREMISE", "validationGranularity": %q, "addressComplete": true},
+					"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"}
+				}}`, tt.validation)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if got.Confidence != tt.want {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() Confidence = %v, want %v", got.Confidence, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_Suggestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "Test Missing Unit Suggests Adding It",
+			result: `{
+				"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": false},
+				"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA", "missingComponentTypes": ["subpremise"]}
+			}`,
+			want:    []string{"Add a unit number"},
+			wantErr: "Address is incomplete.",
+		},
+		{
+			name: "Test Unconfirmed Postal Code And Street",
+			result: `{
+				"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "BLOCK", "addressComplete": true},
+				"address": {"formattedAddress": "1600 Grand Concurse, Bronx, NY 10499, USA", "unconfirmedComponentTypes": ["route", "postal_code"]}
+			}`,
+			want:    []string{"Street not recognized, check its spelling", "Confirm postal code"},
+			wantErr: "Address validation failed based on granularity.",
+		},
+		{
+			name: "Test Unrecognized Input With Leftover Tokens",
+			result: `{
+				"verdict": {"inputGranularity": "OTHER", "validationGranularity": "OTHER", "addressComplete": false},
+				"address": {"formattedAddress": "Bronx, NY, USA", "missingComponentTypes": ["street_number", "route", "postal_code"], "unresolvedTokens": ["asdf", "qwerty"]}
+			}`,
+			want: []string{
+				"Address not recognized, check it for typos",
+				"Add a street number",
+				"Add a street name",
+				"Add a postal code",
+				`Remove or correct "asdf qwerty"`,
+			},
+			wantErr: "Input address was not recognized. Address is incomplete.",
+		},
+		{
+			name: "Test Unmapped Component Types Use Their Name",
+			result: `{
+				"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": false},
+				"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA", "missingComponentTypes": ["point_of_interest"], "unconfirmedComponentTypes": ["administrative_area_level_2"]}
+			}`,
+			want:    []string{"Add the point of interest", "Confirm the administrative area level 2"},
+			wantErr: "Address is incomplete.",
+		},
+		{
+			name: "Test Valid Address Has No Suggestions",
+			result: `{
+				"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+				"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA", "unconfirmedComponentTypes": ["postal_code_suffix"]}
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"result": %s}`, tt.result)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Suggestions, tt.want) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() Suggestions = %q, want %q", got.Suggestions, tt.want)
+			}
+			if got.Error != tt.wantErr {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() Error = %q, want %q", got.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_Locality(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       config.MapConfig
+		region       string
+		locality     string
+		wantLocality string
+	}{
+		{
+			name:         "Test Configured Locality Is Sent When None Is Requested",
+			config:       config.MapConfig{Country: "us", Locality: "Bronx"},
+			wantLocality: "Bronx",
+		},
+		{
+			name:         "Test Requested Locality Overrides The Configured One",
+			config:       config.MapConfig{Country: "us", Locality: "Bronx"},
+			locality:     "Yonkers",
+			wantLocality: "Yonkers",
+		},
+		{
+			name:         "Test Locality Is Omitted When Neither Is Set",
+			config:       config.MapConfig{Country: "us"},
+			wantLocality: "",
+		},
+		{
+			name:         "Test Requested Region Drops The Configured Locality",
+			config:       config.MapConfig{Country: "us", Locality: "Bronx"},
+			region:       "GB",
+			wantLocality: "",
+		},
+		{
+			name:         "Test Requested Locality Applies In A Requested Region",
+			config:       config.MapConfig{Country: "us", Locality: "Bronx"},
+			region:       "GB",
+			locality:     "London",
+			wantLocality: "London",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Unset stays absent from the request rather than being sent empty
+			gotLocality, sent := "", false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				var req struct {
+					Address struct {
+						Locality *string `json:"locality"`
+					} `json:"address"`
+				}
+				if err := json.Unmarshal(body, &req); err != nil {
+					t.Errorf("invalid request body: %v", err)
+				}
+				if req.Address.Locality != nil {
+					gotLocality, sent = *req.Address.Locality, true
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, `{"result": {"verdict": {"validationGranularity": "PREMISE", "addressComplete": true}}}`)
+			}))
+			defer server.Close()
+
+			tt.config.GoogleMapsAPIKey = "test-key"
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(tt.config, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.region != "" {
+				ctx = ports.WithRegionCode(ctx, tt.region)
+			}
+			if tt.locality != "" {
+				ctx = ports.WithLocality(ctx, tt.locality)
+			}
+			if _, err := adapter.ValidateAddress(ctx, "1600 Grand Concourse"); err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if gotLocality != tt.wantLocality || sent != (tt.wantLocality != "") {
+				t.Errorf("request locality = %q (sent %v), want %q", gotLocality, sent, tt.wantLocality)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_RawCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"result": {"verdict": {"validationGranularity": "PREMISE", "addressComplete": true}}, "responseId": "abc"}`)
+	}))
+	defer server.Close()
+
+	adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+		option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+	}
+
+	// Nothing is captured unless the caller asks for it
+	if _, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY"); err != nil {
+		t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+	}
+
+	capture := &ports.RawCapture{}
+	ctx := ports.WithRawCapture(context.Background(), capture)
+	if _, err := adapter.ValidateAddress(ctx, "1600 Grand Concourse, Bronx, NY"); err != nil {
+		t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+	}
+
+	var got struct {
+		ResponseID string `json:"responseId"`
+	}
+	if err := json.Unmarshal(capture.Raw(), &got); err != nil || got.ResponseID != "abc" {
+		t.Errorf("captured raw response = %s, error = %v", capture.Raw(), err)
+	}
+	if bytes.Contains(capture.Raw(), []byte("test-key")) {
+		t.Errorf("captured raw response contains the API key: %s", capture.Raw())
+	}
+}
+
+func TestGoogleAddressValidationAdapter_PartialResponses(t *testing.T) {
+	deliverable := true
+	tests := []struct {
+		name     string
+		response string
+		want     ports.AddressValidationResult
+		wantErr  error
+	}{
+		{
+			name: "Test Missing Verdict Keeps Address And Geocode",
+			response: `{"result": {
+				"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA", "postalAddress": {"regionCode": "us"}},
+				"geocode": {"location": {"latitude": 40.8399, "longitude": -73.9115}, "plusCode": {"globalCode": "87G8Q2QQ+XC"}}
+			}}`,
+			want: ports.AddressValidationResult{
+				FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457, USA",
+				RegionCode:       "US",
+				CountryCode:      "US",
+				Latitude:         40.8399,
+				Longitude:        -73.9115,
+				PlusCode:         "87G8Q2QQ+XC",
+				Error:            "Address could not be verified.",
+			},
+		},
+		{
+			name: "Test Address Only Returns Formatted Address",
+			response: `{"result": {
+				"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"}
+			}}`,
+			want: ports.AddressValidationResult{
+				FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457, USA",
+				Error:            "Address could not be verified.",
+			},
+		},
+		{
+			name: "Test Geocode Only Returns Location",
+			response: `{"result": {
+				"geocode": {"location": {"latitude": 40.8399, "longitude": -73.9115}}
+			}}`,
+			want: ports.AddressValidationResult{
+				Latitude:  40.8399,
+				Longitude: -73.9115,
+				Error:     "Address could not be verified.",
+			},
+		},
+		{
+			name: "Test Verdict Without Geocode Is Validated",
+			response: `{"result": {
+				"verdict": {"validationGranularity": "PREMISE", "addressComplete": true},
+				"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"}
+			}}`,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457, USA",
+				Granularity:      "PREMISE",
+				Confidence:       0.9,
+				Deliverable:      &deliverable,
+			},
+		},
+		{
+			name:     "Test Empty Result Returns Not Found",
+			response: `{"result": {}}`,
+			want:     ports.AddressValidationResult{Error: "No validation result found."},
+			wantErr:  ports.ErrAddressNotFound,
+		},
+		{
+			name: "Test Empty Address And Geocode Return Not Found",
+			response: `{"result": {
+				"address": {"formattedAddress": ""},
+				"geocode": {"plusCode": {"globalCode": "87G8Q2QQ+XC"}}
+			}}`,
+			want:    ports.AddressValidationResult{Error: "No validation result found."},
+			wantErr: ports.ErrAddressNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_ProviderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantErr  error
+		wantCode string
+		wantName string
+	}{
+		{
+			name:     "Test Over Query Limit Returns Provider Quota",
+			status:   http.StatusTooManyRequests,
+			body:     `{"error": {"code": 429, "message": "Quota exceeded.", "status": "RESOURCE_EXHAUSTED"}}`,
+			wantErr:  ports.ErrProviderQuota,
+			wantCode: ports.ERROR_CODE_PROVIDER_QUOTA,
+			wantName: "provider_quota_errors_total",
+		},
+		{
+			name:     "Test Legacy Over Query Limit Status Returns Provider Quota",
+			status:   http.StatusBadRequest,
+			body:     `{"error": {"code": 400, "message": "OVER_QUERY_LIMIT", "status": "FAILED_PRECONDITION"}}`,
+			wantErr:  ports.ErrProviderQuota,
+			wantCode: ports.ERROR_CODE_PROVIDER_QUOTA,
+			wantName: "provider_quota_errors_total",
+		},
+		{
+			name:     "Test Request Denied Returns Provider Denied",
+			status:   http.StatusForbidden,
+			body:     `{"error": {"code": 403, "message": "API key not valid.", "status": "PERMISSION_DENIED"}}`,
+			wantErr:  ports.ErrProviderDenied,
+			wantCode: ports.ERROR_CODE_PROVIDER_DENIED,
+			wantName: "provider_denied_errors_total",
+		},
+		{
+			name:     "Test Billing Not Enabled Returns Provider Denied",
+			status:   http.StatusBadRequest,
+			body:     `{"error": {"code": 400, "message": "Billing must be enabled.", "status": "FAILED_PRECONDITION", "details": [{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "BILLING_DISABLED"}]}}`,
+			wantErr:  ports.ErrProviderDenied,
+			wantCode: ports.ERROR_CODE_PROVIDER_DENIED,
+			wantName: "provider_denied_errors_total",
+		},
+		{
+			name:   "Test Other Errors Are Not Classified",
+			status: http.StatusInternalServerError,
+			body:   `{"error": {"code": 500, "message": "Internal error.", "status": "INTERNAL"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1 Main St")
+			if err == nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = nil, want error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ports.ErrProviderQuota) || errors.Is(err, ports.ErrProviderDenied) {
+				if tt.wantErr == nil {
+					t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() error = %v, want unclassified", err)
+				}
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+
+			for _, metric := range adapter.CollectMetrics() {
+				want := 0.0
+				if metric.Name == tt.wantName {
+					want = 1
+				}
+				if metric.Value != want {
+					t.Errorf("metric %s = %v, want %v", metric.Name, metric.Value, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_APIKeyRotation(t *testing.T) {
+	const okResponse = `{
+		"result": {
+			"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+			"address": {"formattedAddress": "1 Main St, Bronx, NY 10456, USA"},
+			"geocode": {"location": {"latitude": 40.8448, "longitude": -73.8648}}
+		}
+	}`
+	const quotaResponse = `{"error": {"code": 429, "message": "OVER_QUERY_LIMIT", "status": "RESOURCE_EXHAUSTED"}}`
+
+	tests := []struct {
+		name        string
+		keys        []string
+		limitedKeys map[string]bool
+		calls       int
+		wantKeys    []string
+		wantErr     error
+	}{
+		{
+			name:     "Test Successive Calls Rotate Keys",
+			keys:     []string{"key-a", "key-b", "key-c"},
+			calls:    4,
+			wantKeys: []string{"key-a", "key-b", "key-c", "key-a"},
+		},
+		{
+			name:        "Test Rate Limited Key Fails Over And Is Skipped",
+			keys:        []string{"key-a", "key-b", "key-c"},
+			limitedKeys: map[string]bool{"key-b": true},
+			calls:       4,
+			// key-b fails over to key-c, then stays skipped while cooling down
+			wantKeys: []string{"key-a", "key-b", "key-c", "key-a", "key-c"},
+		},
+		{
+			name:        "Test Every Key Rate Limited Returns Provider Quota",
+			keys:        []string{"key-a", "key-b"},
+			limitedKeys: map[string]bool{"key-a": true, "key-b": true},
+			calls:       2,
+			// The second call only spends one attempt on the key recovering first
+			wantKeys: []string{"key-a", "key-b", "key-a"},
+			wantErr:  ports.ErrProviderQuota,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotKeys []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				key := r.Header.Get(adapters.API_KEY_HEADER)
+				gotKeys = append(gotKeys, key)
+
+				w.Header().Set("Content-Type", "application/json")
+				if tt.limitedKeys[key] {
+					w.WriteHeader(http.StatusTooManyRequests)
+					io.WriteString(w, quotaResponse)
+					return
+				}
+				io.WriteString(w, okResponse)
+			}))
+			defer server.Close()
+
+			mapConfig := config.MapConfig{GoogleMapsAPIKeys: tt.keys, APIKeyCooldown: time.Minute}
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(mapConfig, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			for i := 0; i < tt.calls; i++ {
+				_, err := adapter.ValidateAddress(context.Background(), "1 Main St")
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() call %d error = %v, want %v", i, err, tt.wantErr)
+				}
+			}
+			if !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Errorf("keys used = %v, want %v", gotKeys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_ProviderTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		wantCalls  int32
+	}{
+		{name: "Test Timeout Without Retries Calls Once", maxRetries: 0, wantCalls: 1},
+		{name: "Test Timeout Is Retried Up To The Limit", maxRetries: 2, wantCalls: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				<-release
+			}))
+			defer server.Close()
+			defer close(release)
+
+			mapConfig := config.MapConfig{GoogleMapsAPIKey: "test-key", ProviderMaxRetries: tt.maxRetries}
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(mapConfig, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+			// A single transient failure opens the breaker
+			breaker := adapters.NewCircuitBreakerValidator(adapter, config.CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute}, zap.NewNop())
+
+			got, err := breaker.ValidateAddress(context.Background(), "1 Main St")
+			if !errors.Is(err, ports.ErrProviderTimeout) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() error = %v, want %v", err, ports.ErrProviderTimeout)
+			}
+			if got.ErrorCode != ports.ERROR_CODE_PROVIDER_TIMEOUT {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_PROVIDER_TIMEOUT)
+			}
+			if n := calls.Load(); n != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", n, tt.wantCalls)
+			}
+
+			if _, err := breaker.ValidateAddress(context.Background(), "1 Main St"); !errors.Is(err, ports.ErrUpstreamUnavailable) {
+				t.Errorf("CircuitBreakerValidator.ValidateAddress() after timeout error = %v, want %v", err, ports.ErrUpstreamUnavailable)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_ContextErrors(t *testing.T) {
+	const validResponse = `{"result": {
+		"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+		"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"}
+	}}`
+
+	tests := []struct {
+		name string
+		// ctx returns the caller's context, cancel is called once the first attempt reached the provider
+		ctx       func() (context.Context, context.CancelFunc)
+		cancel    bool
+		wantCalls int32
+		wantErr   error
+		wantValid bool
+	}{
+		{
+			name: "Test Attempt Deadline Is Retried Within Caller Budget",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 5*time.Second)
+			},
+			wantCalls: 2,
+			wantValid: true,
+		},
+		{
+			name:      "Test Client Cancellation Stops Without Retry",
+			ctx:       func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			cancel:    true,
+			wantCalls: 1,
+			wantErr:   context.Canceled,
+		},
+		{
+			name: "Test Caller Deadline Too Short For Backoff Stops Without Retry",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 60*time.Millisecond)
+			},
+			wantCalls: 1,
+			wantErr:   ports.ErrProviderTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			// The first call hangs past the attempt deadline, later ones answer at once
+			var calls atomic.Int32
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					if tt.cancel {
+						cancel()
+					}
+					<-release
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, validResponse)
+			}))
+			defer server.Close()
+			defer close(release)
+
+			mapConfig := config.MapConfig{GoogleMapsAPIKey: "test-key", ProviderTimeout: 20 * time.Millisecond, ProviderMaxRetries: 3}
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(mapConfig, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			start := time.Now()
+			got, err := adapter.ValidateAddress(ctx, "1600 Grand Concourse, Bronx, NY")
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() took %v, want prompt return", elapsed)
+			}
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(tt.wantErr, context.Canceled) && errors.Is(err, ports.ErrProviderTimeout) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() error = %v, want no provider timeout", err)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+			if n := calls.Load(); n != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", n, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_AddressType(t *testing.T) {
+	tests := []struct {
+		name   string
+		result string
+		want   string
+	}{
+		{
+			name:   "Test PO Box From Metadata",
+			result: `"metadata": {"poBox": true, "residential": true}`,
+			want:   ports.ADDRESS_TYPE_PO_BOX,
+		},
+		{
+			name:   "Test Business From Metadata",
+			result: `"metadata": {"business": true}`,
+			want:   ports.ADDRESS_TYPE_COMMERCIAL,
+		},
+		{
+			name:   "Test Residential From Metadata",
+			result: `"metadata": {"residential": true}`,
+			want:   ports.ADDRESS_TYPE_RESIDENTIAL,
+		},
+		{
+			name:   "Test PO Box From USPS Record Type",
+			result: `"uspsData": {"addressRecordType": "P"}`,
+			want:   ports.ADDRESS_TYPE_PO_BOX,
+		},
+		{
+			name:   "Test Firm From USPS Record Type",
+			result: `"uspsData": {"addressRecordType": "F"}`,
+			want:   ports.ADDRESS_TYPE_COMMERCIAL,
+		},
+		{
+			name:   "Test Unknown Without Metadata Or USPS Data",
+			result: `"uspsData": {"addressRecordType": "S"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"result": {
+					"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+					"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"},
+					%s
+				}}`, tt.result)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if got.AddressType != tt.want {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() AddressType = %q, want %q", got.AddressType, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_Deliverable(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name    string
+		verdict string
+		usps    string
+		want    *bool
+	}{
+		{
+			name:    "Test Confirmed DPV Is Deliverable",
+			verdict: `{"validationGranularity": "PREMISE", "addressComplete": true, "hasUnconfirmedComponents": true}`,
+			usps:    `"uspsData": {"dpvConfirmation": "Y"},`,
+			want:    &yes,
+		},
+		{
+			name:    "Test Missing Unit DPV Is Not Deliverable",
+			verdict: `{"validationGranularity": "PREMISE", "addressComplete": true}`,
+			usps:    `"uspsData": {"dpvConfirmation": "D"},`,
+			want:    &no,
+		},
+		{
+			name:    "Test Unconfirmed DPV Is Not Deliverable",
+			verdict: `{"validationGranularity": "PREMISE", "addressComplete": true}`,
+			usps:    `"uspsData": {"dpvConfirmation": "N"},`,
+			want:    &no,
+		},
+		{
+			name:    "Test Confirmed Premise Without DPV Is Deliverable",
+			verdict: `{"validationGranularity": "SUB_PREMISE", "addressComplete": true}`,
+			want:    &yes,
+		},
+		{
+			name:    "Test Route Level Is Not Deliverable",
+			verdict: `{"validationGranularity": "ROUTE", "addressComplete": true}`,
+			want:    &no,
+		},
+		{
+			name:    "Test Incomplete Premise Is Not Deliverable",
+			verdict: `{"validationGranularity": "PREMISE", "addressComplete": false}`,
+			want:    &no,
+		},
+		{
+			name:    "Test Unconfirmed Components Are Unknown",
+			verdict: `{"validationGranularity": "PREMISE", "addressComplete": true, "hasUnconfirmedComponents": true}`,
+		},
+		{
+			name:    "Test Unspecified Granularity Is Unknown",
+			verdict: `{"addressComplete": true}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"result": {
+					"verdict": %s,
+					%s
+					"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"}
+				}}`, tt.verdict, tt.usps)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Deliverable, tt.want) {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() Deliverable = %v, want %v", got.Deliverable, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_Components(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"result": {
+			"verdict": {"inputGranularity": "ROUTE", "validationGranularity": "ROUTE", "addressComplete": false},
+			"address": {
+				"formattedAddress": "Grand Concourse, Bronx, NY 10457, USA",
+				"addressComponents": [
+					{"componentName": {"text": "Grand Concourse"}, "componentType": "route"},
+					{"componentName": {"text": "10457"}, "componentType": "postal_code"},
+					{"componentName": {"text": "unlabeled"}}
+				],
+				"missingComponentTypes": ["street_number"]
+			}
+		}}`)
+	}))
+	defer server.Close()
+
+	adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+		option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+	}
+
+	got, err := adapter.ValidateAddress(context.Background(), "Grand Concourse, Bronx, NY")
+	if err != nil {
+		t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+	}
+	want := []ports.AddressComponent{
+		{Type: "route", Text: "Grand Concourse"},
+		{Type: "postal_code", Text: "10457"},
+	}
+	if !reflect.DeepEqual(got.Components, want) {
+		t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() Components = %+v, want %+v", got.Components, want)
+	}
+}
+
+func TestGoogleAddressValidationAdapter_ISOCodes(t *testing.T) {
+	tests := []struct {
+		name          string
+		address       string
+		wantCountry   string
+		wantAdminArea string
+	}{
+		{
+			name: "Test US Address Maps Postal Address To Codes",
+			address: `{"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA",
+				"postalAddress": {"regionCode": "us", "administrativeArea": "ny"}}`,
+			wantCountry:   "US",
+			wantAdminArea: "US-NY",
+		},
+		{
+			name: "Test CA Address Falls Back To The Province Component",
+			address: `{"formattedAddress": "290 Bremner Blvd, Toronto, ON M5V 3L9, Canada",
+				"postalAddress": {"regionCode": "CA"},
+				"addressComponents": [
+					{"componentName": {"text": "Toronto"}, "componentType": "locality"},
+					{"componentName": {"text": "ON"}, "componentType": "administrative_area_level_1"}
+				]}`,
+			wantCountry:   "CA",
+			wantAdminArea: "CA-ON",
+		},
+		{
+			name: "Test Area Named In Full Has No Code",
+			address: `{"formattedAddress": "10 Downing St, London SW1A 2AA, UK",
+				"postalAddress": {"regionCode": "GB", "administrativeArea": "England"}}`,
+			wantCountry: "GB",
+		},
+		{
+			name:    "Test Missing Postal Address Omits Codes",
+			address: `{"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, `{"result": {
+					"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+					"address": `+tt.address+`
+				}}`)
+			}))
+			defer server.Close()
+
+			adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+				option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+			if err != nil {
+				t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+			}
+
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+			if err != nil {
+				t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+			}
+			if got.CountryCode != tt.wantCountry || got.AdminAreaCode != tt.wantAdminArea {
+				t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() codes = %q, %q, want %q, %q",
+					got.CountryCode, got.AdminAreaCode, tt.wantCountry, tt.wantAdminArea)
+			}
+		})
+	}
+}
+
+func TestGoogleAddressValidationAdapter_PlaceTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"result": {
+			"verdict": {"inputGranularity": "PREMISE", "validationGranularity": "PREMISE", "addressComplete": true},
+			"address": {"formattedAddress": "1600 Grand Concourse, Bronx, NY 10457, USA"},
+			"geocode": {"location": {"latitude": 40.8399, "longitude": -73.9106}, "placeTypes": ["premise", "establishment", "point_of_interest"]}
+		}}`)
+	}))
+	defer server.Close()
+
+	adapter, err := adapters.NewGoogleAddressValidationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, zap.NewNop(),
+		option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewGoogleAddressValidationAdapter() error = %v", err)
+	}
+
+	got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY")
+	if err != nil {
+		t.Fatalf("GoogleAddressValidationAdapter.ValidateAddress() error = %v", err)
+	}
+	want := []string{"premise", "establishment", "point_of_interest"}
+	if !reflect.DeepEqual(got.Types, want) {
+		t.Errorf("GoogleAddressValidationAdapter.ValidateAddress() Types = %q, want %q", got.Types, want)
+	}
+}
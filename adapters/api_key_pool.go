@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"sync"
+	"time"
+)
+
+// apiKeyPool hands out API keys round-robin, skipping keys that were recently rate-limited
+type apiKeyPool struct {
+	keys     []string
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu           sync.Mutex
+	next         int
+	coolingUntil []time.Time
+}
+
+func newAPIKeyPool(keys []string, cooldown time.Duration) *apiKeyPool {
+	return &apiKeyPool{
+		keys:         keys,
+		cooldown:     cooldown,
+		now:          time.Now,
+		coolingUntil: make([]time.Time, len(keys)),
+	}
+}
+
+// pick returns the index of the next key that is not cooling down. When every key is
+// cooling down it returns the one that recovers first and reports it as unavailable.
+func (p *apiKeyPool) pick() (index int, available bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	soonest := p.next
+	for i := range p.keys {
+		candidate := (p.next + i) % len(p.keys)
+		if !now.Before(p.coolingUntil[candidate]) {
+			p.next = (candidate + 1) % len(p.keys)
+			return candidate, true
+		}
+		if p.coolingUntil[candidate].Before(p.coolingUntil[soonest]) {
+			soonest = candidate
+		}
+	}
+
+	p.next = (soonest + 1) % len(p.keys)
+	return soonest, false
+}
+
+// rateLimited skips the key until its cooldown has passed
+func (p *apiKeyPool) rateLimited(index int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.coolingUntil[index] = p.now().Add(p.cooldown)
+}
@@ -0,0 +1,163 @@
+package adapters
+
+import (
+	"address-validator/ports"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BudgetValidator caps the calls that reach the wrapped validator's provider per UTC day.
+// Place it behind the cache so cache hits never spend budget.
+type BudgetValidator struct {
+	next   ports.AddressValidator
+	logger *zap.Logger
+	budget uint
+	used   uint
+	day    time.Time
+	mu     sync.Mutex
+}
+
+// NewBudgetValidator wraps a validator with a daily call budget
+func NewBudgetValidator(next ports.AddressValidator, budget uint, logger *zap.Logger) *BudgetValidator {
+	return &BudgetValidator{
+		next:   next,
+		logger: logger,
+		budget: budget,
+		day:    utcDay(time.Now()),
+	}
+}
+
+// ValidateAddress delegates to the wrapped validator while budget remains
+func (bv *BudgetValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	if bv.exhausted() {
+		return exhaustedResult(), ports.ErrQuotaExhausted
+	}
+
+	result, err := bv.next.ValidateAddress(ctx, address)
+	bv.charge(err)
+	return result, err
+}
+
+// WrapReverseGeocoder returns a reverse geocoder that spends the same daily budget as the validator,
+// reverse geocodes are billed upstream calls too
+func (bv *BudgetValidator) WrapReverseGeocoder(next ports.ReverseGeocoder) ports.ReverseGeocoder {
+	return &budgetReverseGeocoder{budget: bv, next: next}
+}
+
+// budgetReverseGeocoder charges reverse geocodes to a BudgetValidator
+type budgetReverseGeocoder struct {
+	budget *BudgetValidator
+	next   ports.ReverseGeocoder
+}
+
+// ReverseGeocode delegates to the wrapped reverse geocoder while budget remains
+func (br *budgetReverseGeocoder) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	if br.budget.exhausted() {
+		return exhaustedResult(), ports.ErrQuotaExhausted
+	}
+
+	result, err := br.next.ReverseGeocode(ctx, latitude, longitude)
+	br.budget.charge(err)
+	return result, err
+}
+
+// Provider reports the provider of the wrapped reverse geocoder
+func (br *budgetReverseGeocoder) Provider() string {
+	return ports.ProviderOf(br.next)
+}
+
+// Provider reports the provider of the wrapped validator
+func (bv *BudgetValidator) Provider() string {
+	return ports.ProviderOf(bv.next)
+}
+
+// Used returns the number of upstream calls the provider answered today
+func (bv *BudgetValidator) Used() uint {
+	bv.mu.Lock()
+	defer bv.mu.Unlock()
+
+	bv.resetIfNewDay()
+	return bv.used
+}
+
+// CheckHealth reports the budget as down once it is spent for the day
+func (bv *BudgetValidator) CheckHealth(ctx context.Context) error {
+	if bv.Used() >= bv.budget {
+		return ports.ErrQuotaExhausted
+	}
+	return nil
+}
+
+// CollectMetrics reports the budget usage for the metrics endpoint
+func (bv *BudgetValidator) CollectMetrics() []ports.Metric {
+	return []ports.Metric{
+		{Name: "geocode_budget_used", Help: "Upstream geocode calls answered by the provider today (UTC).", Type: ports.METRIC_GAUGE, Value: float64(bv.Used())},
+		{Name: "geocode_budget_limit", Help: "Daily upstream geocode call budget.", Type: ports.METRIC_GAUGE, Value: float64(bv.budget)},
+	}
+}
+
+// exhausted reports whether the budget is spent, logging the refusal.
+// The cap is soft, calls already in flight when the budget runs out still complete.
+func (bv *BudgetValidator) exhausted() bool {
+	if bv.Used() < bv.budget {
+		return false
+	}
+	bv.logger.Warn("daily geocode budget exhausted", zap.Uint("budget", bv.budget))
+	return true
+}
+
+// charge records an upstream call with outcome err against today's budget
+func (bv *BudgetValidator) charge(err error) {
+	if !reachedProvider(err) {
+		return
+	}
+
+	bv.mu.Lock()
+	defer bv.mu.Unlock()
+
+	bv.resetIfNewDay()
+	bv.used++
+}
+
+// reachedProvider reports whether a call with outcome err got an answer from the provider.
+// Not found and rejected lookups are billed like successful ones, only calls that failed
+// before the provider answered are free.
+func reachedProvider(err error) bool {
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, ports.ErrUpstreamUnavailable) || errors.Is(err, ports.ErrProviderTimeout) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// Connection failures surface as net errors, *url.Error included
+	var netErr net.Error
+	return !errors.As(err, &netErr)
+}
+
+// exhaustedResult is the result of a call refused for lack of budget
+func exhaustedResult() ports.AddressValidationResult {
+	return ports.AddressValidationResult{
+		IsValid:   false,
+		Error:     ports.ErrQuotaExhausted.Error(),
+		ErrorCode: ports.ERROR_CODE_QUOTA_EXHAUSTED,
+	}
+}
+
+// resetIfNewDay zeroes the usage at midnight UTC, callers must hold the lock
+func (bv *BudgetValidator) resetIfNewDay() {
+	if today := utcDay(time.Now()); today.After(bv.day) {
+		bv.day = today
+		bv.used = 0
+	}
+}
+
+// utcDay truncates a time to midnight UTC
+func utcDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,147 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestBudgetValidator_ValidateAddress(t *testing.T) {
+	type call struct {
+		address  string
+		wantErr  error
+		wantCode string
+	}
+
+	tests := []struct {
+		name      string
+		budget    uint
+		calls     []call
+		wantUsed  uint
+		wantCalls int
+	}{
+		{
+			name:   "Test Misses Past Budget Are Rejected",
+			budget: 2,
+			calls: []call{
+				{address: "1 Main St"},
+				{address: "2 Main St"},
+				{address: "3 Main St", wantErr: ports.ErrQuotaExhausted, wantCode: ports.ERROR_CODE_QUOTA_EXHAUSTED},
+			},
+			wantUsed:  2,
+			wantCalls: 2,
+		},
+		{
+			name:   "Test Cache Hits Still Succeed Past Budget",
+			budget: 1,
+			calls: []call{
+				{address: "1 Main St"},
+				{address: "2 Main St", wantErr: ports.ErrQuotaExhausted, wantCode: ports.ERROR_CODE_QUOTA_EXHAUSTED},
+				{address: "1 Main St"},
+				{address: "1 MAIN ST"},
+			},
+			wantUsed:  1,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &countingValidator{}
+			budget := adapters.NewBudgetValidator(upstream, tt.budget, zap.NewNop())
+			cache := adapters.NewCachingValidator(budget, config.CacheConfig{TTL: time.Minute}, zap.NewNop())
+
+			for i, c := range tt.calls {
+				got, err := cache.ValidateAddress(context.Background(), c.address)
+				if !errors.Is(err, c.wantErr) {
+					t.Errorf("call %d: ValidateAddress(%q) error = %v, want %v", i, c.address, err, c.wantErr)
+				}
+				if got.ErrorCode != c.wantCode {
+					t.Errorf("call %d: ValidateAddress(%q) ErrorCode = %v, want %v", i, c.address, got.ErrorCode, c.wantCode)
+				}
+			}
+
+			if got := budget.Used(); got != tt.wantUsed {
+				t.Errorf("BudgetValidator.Used() = %v, want %v", got, tt.wantUsed)
+			}
+			if upstream.calls != tt.wantCalls {
+				t.Errorf("upstream calls = %v, want %v", upstream.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// reverseStub answers every reverse geocode with the same address
+type reverseStub struct{ calls int }
+
+func (r *reverseStub) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	r.calls++
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: "1 Main St"}, nil
+}
+
+func TestBudgetValidator_WrapReverseGeocoder(t *testing.T) {
+	budget := adapters.NewBudgetValidator(&countingValidator{}, 2, zap.NewNop())
+	upstream := &reverseStub{}
+	reverse := budget.WrapReverseGeocoder(upstream)
+
+	if _, err := budget.ValidateAddress(context.Background(), "1 Main St"); err != nil {
+		t.Fatalf("BudgetValidator.ValidateAddress() error = %v", err)
+	}
+	if _, err := reverse.ReverseGeocode(context.Background(), 40.8448, -73.8648); err != nil {
+		t.Fatalf("ReverseGeocode() error = %v", err)
+	}
+	// Both calls spent the shared budget
+	got, err := reverse.ReverseGeocode(context.Background(), 40.8448, -73.8648)
+	if !errors.Is(err, ports.ErrQuotaExhausted) || got.ErrorCode != ports.ERROR_CODE_QUOTA_EXHAUSTED {
+		t.Errorf("ReverseGeocode() past budget = %q, %v, want %q, %v", got.ErrorCode, err, ports.ERROR_CODE_QUOTA_EXHAUSTED, ports.ErrQuotaExhausted)
+	}
+	if upstream.calls != 1 {
+		t.Errorf("upstream reverse calls = %d, want 1", upstream.calls)
+	}
+	if got := budget.Used(); got != 2 {
+		t.Errorf("BudgetValidator.Used() = %d, want 2", got)
+	}
+}
+
+// failingValidator fails every call with err
+type failingValidator struct{ err error }
+
+func (f failingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	return ports.AddressValidationResult{}, f.err
+}
+
+func TestBudgetValidator_ValidateAddress_Charges(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantUsed uint
+	}{
+		{name: "Test Success Is Charged", err: nil, wantUsed: 1},
+		{name: "Test Not Found Is Charged", err: ports.ErrAddressNotFound, wantUsed: 1},
+		{name: "Test Provider Rejection Is Charged", err: fmt.Errorf("address validation error: %w", ports.ErrProviderDenied), wantUsed: 1},
+		{name: "Test Provider Status Error Is Charged", err: errors.New("geocoding error: UNKNOWN_ERROR"), wantUsed: 1},
+		{name: "Test Connection Failure Is Free", err: &url.Error{Op: "Get", URL: "https://maps.googleapis.com", Err: errors.New("connection refused")}, wantUsed: 0},
+		{name: "Test Provider Timeout Is Free", err: fmt.Errorf("address validation error: %w", ports.ErrProviderTimeout), wantUsed: 0},
+		{name: "Test Canceled Call Is Free", err: context.Canceled, wantUsed: 0},
+		{name: "Test Open Breaker Is Free", err: ports.ErrUpstreamUnavailable, wantUsed: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := adapters.NewBudgetValidator(failingValidator{err: tt.err}, 10, zap.NewNop())
+
+			if _, err := budget.ValidateAddress(context.Background(), "1 Main St"); !errors.Is(err, tt.err) {
+				t.Errorf("BudgetValidator.ValidateAddress() error = %v, want %v", err, tt.err)
+			}
+			if got := budget.Used(); got != tt.wantUsed {
+				t.Errorf("BudgetValidator.Used() = %v, want %v", got, tt.wantUsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package adapters
+
+import (
+	"address-validator/config"
+	"address-validator/logging"
+	"address-validator/ports"
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CacheStats reports the effectiveness of the validation result cache
+type CacheStats struct {
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	StaleHits uint64  `json:"staleHits"`
+	Size      int     `json:"size"`
+	HitRatio  float64 `json:"hitRatio"`
+}
+
+type cacheEntry struct {
+	key       string
+	result    ports.AddressValidationResult
+	expiresAt time.Time
+	// size approximates the memory held by the entry
+	size int64
+}
+
+// CachingValidator caches successful results of the wrapped validator in memory.
+// Past MaxEntries or MaxBytes the least recently used entries are evicted.
+type CachingValidator struct {
+	next       ports.AddressValidator
+	logger     *zap.Logger
+	ttl        time.Duration
+	serveStale bool
+	maxEntries int
+	maxBytes   int64
+
+	// entries index the elements of recency, which is ordered from most to least recently used
+	entries map[string]*list.Element
+	recency *list.List
+	bytes   int64
+	mu      sync.Mutex
+
+	// Counters are kept outside the map lock so Stats doesn't contend on it
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+	staleHits atomic.Uint64
+}
+
+// NewCachingValidator wraps a validator with an in-memory result cache
+func NewCachingValidator(next ports.AddressValidator, config config.CacheConfig, logger *zap.Logger) *CachingValidator {
+	return &CachingValidator{
+		next:       next,
+		logger:     logger,
+		ttl:        config.TTL,
+		serveStale: config.ServeStale,
+		maxEntries: int(config.MaxEntries),
+		maxBytes:   config.MaxBytes,
+		entries:    make(map[string]*list.Element),
+		recency:    list.New(),
+	}
+}
+
+// ValidateAddress returns a cached result when one is fresh, otherwise delegates to the wrapped validator
+func (cv *CachingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	key := canonicalCacheKey(address)
+	// The same address can resolve differently in another region
+	if regionCode := ports.RegionCode(ctx); regionCode != "" {
+		key = strings.ToLower(regionCode) + "|" + key
+	}
+	// As can the same address line in another locality
+	if locality := ports.Locality(ctx); locality != "" {
+		key = "locality:" + strings.ToLower(locality) + "|" + key
+	}
+	now := time.Now()
+
+	cv.mu.Lock()
+	var entry cacheEntry
+	element, ok := cv.entries[key]
+	if ok {
+		entry = *element.Value.(*cacheEntry)
+		cv.recency.MoveToFront(element)
+	}
+	cv.mu.Unlock()
+
+	if ok && now.Before(entry.expiresAt) {
+		cv.hits.Add(1)
+		logging.FromContext(ctx, cv.logger).Debug("validation cache hit")
+		return entry.result, nil
+	}
+
+	// Expired entries are kept as an outage fallback when serving stale results
+	if ok && !cv.serveStale {
+		cv.mu.Lock()
+		// Only evict if another request hasn't refreshed the entry meanwhile
+		if current, found := cv.entries[key]; found && !now.Before(current.Value.(*cacheEntry).expiresAt) {
+			cv.remove(current)
+			cv.evictions.Add(1)
+		}
+		cv.mu.Unlock()
+	}
+
+	cv.misses.Add(1)
+	result, err := cv.next.ValidateAddress(ctx, address)
+	if err != nil {
+		if ok && cv.serveStale {
+			cv.staleHits.Add(1)
+			cv.logger.Warn("serving stale validation result", zap.Error(err))
+			stale := entry.result
+			stale.Stale = true
+			return stale, nil
+		}
+		return result, err
+	}
+
+	cv.store(key, result, now.Add(cv.ttl))
+
+	return result, nil
+}
+
+// canonicalCacheKey returns the cache key of an address, its comma-separated components in order,
+// each lowercased with its whitespace collapsed and surrounding periods dropped, without empty components.
+// "1 Main St., Bronx" and " 1 main st ,bronx," share a key, so do structured requests joined into a line.
+func canonicalCacheKey(address string) string {
+	var components []string
+	for _, component := range strings.Split(strings.ToLower(address), ",") {
+		component = strings.Trim(strings.Join(strings.Fields(component), " "), ". ")
+		if component != "" {
+			components = append(components, component)
+		}
+	}
+	return strings.Join(components, ", ")
+}
+
+// store caches result under key as the most recently used entry, then evicts the least
+// recently used entries until the cache is back within its caps
+func (cv *CachingValidator) store(key string, result ports.AddressValidationResult, expiresAt time.Time) {
+	entry := &cacheEntry{key: key, result: result, expiresAt: expiresAt, size: entrySize(key, result)}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	if element, ok := cv.entries[key]; ok {
+		cv.remove(element)
+	}
+	cv.entries[key] = cv.recency.PushFront(entry)
+	cv.bytes += entry.size
+
+	// The entry just stored is kept even when it alone exceeds the byte cap
+	for cv.recency.Len() > 1 && cv.overCap() {
+		cv.remove(cv.recency.Back())
+		cv.evictions.Add(1)
+	}
+}
+
+// overCap reports whether the cache holds more than its caps allow, the caller must hold the lock
+func (cv *CachingValidator) overCap() bool {
+	return (cv.maxEntries > 0 && cv.recency.Len() > cv.maxEntries) ||
+		(cv.maxBytes > 0 && cv.bytes > cv.maxBytes)
+}
+
+// remove drops element from the cache, the caller must hold the lock
+func (cv *CachingValidator) remove(element *list.Element) {
+	entry := cv.recency.Remove(element).(*cacheEntry)
+	delete(cv.entries, entry.key)
+	cv.bytes -= entry.size
+}
+
+// entrySize approximates the memory held by a cached result as its key plus its encoded size
+func entrySize(key string, result ports.AddressValidationResult) int64 {
+	data, _ := json.Marshal(result)
+	return int64(len(key) + len(data))
+}
+
+// Provider reports the provider of the wrapped validator
+func (cv *CachingValidator) Provider() string {
+	return ports.ProviderOf(cv.next)
+}
+
+// Stats returns a snapshot of the cache counters
+func (cv *CachingValidator) Stats() CacheStats {
+	cv.mu.Lock()
+	size := len(cv.entries)
+	cv.mu.Unlock()
+
+	stats := CacheStats{
+		Hits:      cv.hits.Load(),
+		Misses:    cv.misses.Load(),
+		Evictions: cv.evictions.Load(),
+		StaleHits: cv.staleHits.Load(),
+		Size:      size,
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	return stats
+}
+
+// CollectMetrics reports the cache counters, its size and hit ratio for the metrics endpoint
+func (cv *CachingValidator) CollectMetrics() []ports.Metric {
+	stats := cv.Stats()
+	cv.mu.Lock()
+	bytes := cv.bytes
+	cv.mu.Unlock()
+
+	return []ports.Metric{
+		{Name: "validation_cache_hits_total", Help: "Validations answered from a fresh cached result.", Type: ports.METRIC_COUNTER, Value: float64(stats.Hits)},
+		{Name: "validation_cache_misses_total", Help: "Validations not answered from a fresh cached result.", Type: ports.METRIC_COUNTER, Value: float64(stats.Misses)},
+		{Name: "validation_cache_evictions_total", Help: "Cached results dropped when expired or to stay within the cache caps.", Type: ports.METRIC_COUNTER, Value: float64(stats.Evictions)},
+		{Name: "validation_cache_stale_hits_total", Help: "Expired cached results served because the provider failed.", Type: ports.METRIC_COUNTER, Value: float64(stats.StaleHits)},
+		{Name: "validation_cache_entries", Help: "Results currently cached.", Type: ports.METRIC_GAUGE, Value: float64(stats.Size)},
+		{Name: "validation_cache_bytes", Help: "Approximate memory held by the cached results.", Type: ports.METRIC_GAUGE, Value: float64(bytes)},
+		{Name: "validation_cache_hit_ratio", Help: "Share of validations answered from the cache since start.", Type: ports.METRIC_GAUGE, Value: stats.HitRatio},
+	}
+}
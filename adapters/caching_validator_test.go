@@ -0,0 +1,388 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// countingValidator counts calls that reach the upstream validator
+type countingValidator struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: address}, nil
+}
+
+func TestCachingValidator_Stats(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		addresses []string
+		wait      time.Duration
+		after     []string
+		want      adapters.CacheStats
+		wantCalls int
+	}{
+		{
+			name:      "Test Repeated Address Counts Hits",
+			ttl:       time.Minute,
+			addresses: []string{"1 Main St", "1 Main St", "1 MAIN ST", "2 Main St"},
+			want:      adapters.CacheStats{Hits: 2, Misses: 2, Size: 2, HitRatio: 0.5},
+			wantCalls: 2,
+		},
+		{
+			name:      "Test Expired Entry Counts Eviction And Miss",
+			ttl:       time.Millisecond,
+			addresses: []string{"1 Main St"},
+			wait:      5 * time.Millisecond,
+			after:     []string{"1 Main St"},
+			want:      adapters.CacheStats{Misses: 2, Evictions: 1, Size: 1},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &countingValidator{}
+			cv := adapters.NewCachingValidator(upstream, config.CacheConfig{TTL: tt.ttl}, zap.NewNop())
+
+			for _, address := range tt.addresses {
+				cv.ValidateAddress(context.Background(), address)
+			}
+			time.Sleep(tt.wait)
+			for _, address := range tt.after {
+				cv.ValidateAddress(context.Background(), address)
+			}
+
+			if got := cv.Stats(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CachingValidator.Stats() = %+v, want %+v", got, tt.want)
+			}
+			if upstream.calls != tt.wantCalls {
+				t.Errorf("upstream calls = %v, want %v", upstream.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachingValidator_CollectMetrics(t *testing.T) {
+	cv := adapters.NewCachingValidator(&countingValidator{}, config.CacheConfig{TTL: time.Minute, MaxEntries: 1}, zap.NewNop())
+	for _, address := range []string{"1 Main St", "1 Main St", "2 Main St"} {
+		cv.ValidateAddress(context.Background(), address)
+	}
+
+	got := map[string]float64{}
+	for _, metric := range cv.CollectMetrics() {
+		got[metric.Name] = metric.Value
+	}
+	if got["validation_cache_bytes"] <= 0 {
+		t.Errorf("validation_cache_bytes = %v, want the size of the cached entry", got["validation_cache_bytes"])
+	}
+	delete(got, "validation_cache_bytes")
+
+	// The second address evicts the first from the single entry cache
+	want := map[string]float64{
+		"validation_cache_hits_total":       1,
+		"validation_cache_misses_total":     2,
+		"validation_cache_evictions_total":  1,
+		"validation_cache_stale_hits_total": 0,
+		"validation_cache_entries":          1,
+		"validation_cache_hit_ratio":        1.0 / 3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CachingValidator.CollectMetrics() = %v, want %v", got, want)
+	}
+}
+
+func TestCachingValidator_ValidateAddress_RegionCode(t *testing.T) {
+	upstream := &countingValidator{}
+	cv := adapters.NewCachingValidator(upstream, config.CacheConfig{TTL: time.Minute}, zap.NewNop())
+
+	// The same address is cached separately per requested region
+	for _, regionCode := range []string{"", "GB", "gb", "CA", ""} {
+		ctx := context.Background()
+		if regionCode != "" {
+			ctx = ports.WithRegionCode(ctx, regionCode)
+		}
+		if _, err := cv.ValidateAddress(ctx, "10 Main St"); err != nil {
+			t.Fatalf("CachingValidator.ValidateAddress() error = %v", err)
+		}
+	}
+
+	if upstream.calls != 3 {
+		t.Errorf("upstream calls = %d, want 3", upstream.calls)
+	}
+}
+
+func TestCachingValidator_ValidateAddress_Locality(t *testing.T) {
+	upstream := &countingValidator{}
+	cv := adapters.NewCachingValidator(upstream, config.CacheConfig{TTL: time.Minute}, zap.NewNop())
+
+	// The same address is cached separately per requested locality, "gb" is not mistaken for a region
+	for _, locality := range []string{"", "Yonkers", "yonkers", "gb", ""} {
+		ctx := context.Background()
+		if locality != "" {
+			ctx = ports.WithLocality(ctx, locality)
+		}
+		if _, err := cv.ValidateAddress(ctx, "10 Main St"); err != nil {
+			t.Fatalf("CachingValidator.ValidateAddress() error = %v", err)
+		}
+	}
+	if _, err := cv.ValidateAddress(ports.WithRegionCode(context.Background(), "GB"), "10 Main St"); err != nil {
+		t.Fatalf("CachingValidator.ValidateAddress() error = %v", err)
+	}
+
+	if upstream.calls != 4 {
+		t.Errorf("upstream calls = %d, want 4", upstream.calls)
+	}
+}
+
+func TestCachingValidator_Stats_Concurrent(t *testing.T) {
+	cv := adapters.NewCachingValidator(&countingValidator{}, config.CacheConfig{TTL: time.Minute}, zap.NewNop())
+	cv.ValidateAddress(context.Background(), "1 Main St")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cv.ValidateAddress(context.Background(), "1 Main St")
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := cv.Stats()
+	if got.Hits != 5000 || got.Misses != 1 {
+		t.Errorf("CachingValidator.Stats() = %+v, want 5000 hits and 1 miss", got)
+	}
+}
+
+// flakyValidator fails every call once down is set
+type flakyValidator struct {
+	countingValidator
+	down bool
+}
+
+func (f *flakyValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	if f.down {
+		return ports.AddressValidationResult{IsValid: false}, errors.New("upstream unavailable")
+	}
+	return f.countingValidator.ValidateAddress(ctx, address)
+}
+
+func TestCachingValidator_ValidateAddress_ServeStale(t *testing.T) {
+	tests := []struct {
+		name       string
+		serveStale bool
+		warm       string
+		address    string
+		wantErr    bool
+		wantStale  bool
+	}{
+		{
+			name:       "Test Outage Serves Expired Entry As Stale",
+			serveStale: true,
+			warm:       "1 Main St",
+			address:    "1 Main St",
+			wantStale:  true,
+		},
+		{
+			name:       "Test Outage Without Entry Returns Error",
+			serveStale: true,
+			warm:       "1 Main St",
+			address:    "2 Main St",
+			wantErr:    true,
+		},
+		{
+			name:    "Test Outage Without Stale Mode Returns Error",
+			warm:    "1 Main St",
+			address: "1 Main St",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &flakyValidator{}
+			cv := adapters.NewCachingValidator(upstream, config.CacheConfig{TTL: time.Millisecond, ServeStale: tt.serveStale}, zap.NewNop())
+
+			if _, err := cv.ValidateAddress(context.Background(), tt.warm); err != nil {
+				t.Fatalf("warming the cache failed: %v", err)
+			}
+			time.Sleep(5 * time.Millisecond)
+			upstream.down = true
+
+			got, err := cv.ValidateAddress(context.Background(), tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CachingValidator.ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Stale != tt.wantStale {
+				t.Errorf("CachingValidator.ValidateAddress() Stale = %v, want %v", got.Stale, tt.wantStale)
+			}
+			if tt.wantStale && (!got.IsValid || got.FormattedAddress != tt.warm) {
+				t.Errorf("CachingValidator.ValidateAddress() = %+v, want the cached result", got)
+			}
+		})
+	}
+}
+
+func TestCachingValidator_ValidateAddress_StaleNotServedWhenFresh(t *testing.T) {
+	upstream := &flakyValidator{}
+	cv := adapters.NewCachingValidator(upstream, config.CacheConfig{TTL: time.Minute, ServeStale: true}, zap.NewNop())
+
+	cv.ValidateAddress(context.Background(), "1 Main St")
+	upstream.down = true
+
+	// A fresh entry is a normal hit and never reaches the failing upstream
+	got, err := cv.ValidateAddress(context.Background(), "1 Main St")
+	if err != nil || got.Stale {
+		t.Errorf("CachingValidator.ValidateAddress() = %+v, %v, want a fresh hit", got, err)
+	}
+}
+
+func TestCachingValidator_ValidateAddress_Eviction(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    config.CacheConfig
+		addresses []string
+		after     []string
+		want      adapters.CacheStats
+		wantCalls int
+	}{
+		{
+			name:      "Test Entry Cap Evicts Oldest Entries",
+			config:    config.CacheConfig{TTL: time.Minute, MaxEntries: 2},
+			addresses: []string{"1 Main St", "2 Main St", "3 Main St", "4 Main St"},
+			after:     []string{"4 Main St", "3 Main St", "1 Main St"},
+			want:      adapters.CacheStats{Hits: 2, Misses: 5, Evictions: 3, Size: 2, HitRatio: 2.0 / 7},
+			wantCalls: 5,
+		},
+		{
+			name:      "Test Hit Keeps Entry Recently Used",
+			config:    config.CacheConfig{TTL: time.Minute, MaxEntries: 2},
+			addresses: []string{"1 Main St", "2 Main St", "1 Main St", "3 Main St"},
+			after:     []string{"1 Main St", "2 Main St"},
+			want:      adapters.CacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2, HitRatio: 2.0 / 6},
+			wantCalls: 4,
+		},
+		{
+			name:      "Test Byte Cap Evicts Oldest Entries",
+			config:    config.CacheConfig{TTL: time.Minute, MaxBytes: 200},
+			addresses: []string{"1 Main St", "2 Main St", "3 Main St", "4 Main St"},
+			after:     []string{"4 Main St", "1 Main St"},
+			want:      adapters.CacheStats{Hits: 1, Misses: 5, Evictions: 3, Size: 2, HitRatio: 1.0 / 6},
+			wantCalls: 5,
+		},
+		{
+			name:      "Test Zero Caps Keep Every Entry",
+			config:    config.CacheConfig{TTL: time.Minute},
+			addresses: []string{"1 Main St", "2 Main St", "3 Main St", "4 Main St"},
+			after:     []string{"1 Main St"},
+			want:      adapters.CacheStats{Hits: 1, Misses: 4, Size: 4, HitRatio: 0.2},
+			wantCalls: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &countingValidator{}
+			cv := adapters.NewCachingValidator(upstream, tt.config, zap.NewNop())
+
+			for _, address := range append(tt.addresses, tt.after...) {
+				if _, err := cv.ValidateAddress(context.Background(), address); err != nil {
+					t.Fatalf("CachingValidator.ValidateAddress() error = %v", err)
+				}
+			}
+
+			if got := cv.Stats(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CachingValidator.Stats() = %+v, want %+v", got, tt.want)
+			}
+			if upstream.calls != tt.wantCalls {
+				t.Errorf("upstream calls = %v, want %v", upstream.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachingValidator_ValidateAddress_EvictionConcurrent(t *testing.T) {
+	const maxEntries = 10
+	cv := adapters.NewCachingValidator(&countingValidator{}, config.CacheConfig{TTL: time.Minute, MaxEntries: maxEntries}, zap.NewNop())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cv.ValidateAddress(context.Background(), fmt.Sprintf("%d Main St", (i*j)%37))
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := cv.Stats()
+	if got.Size != maxEntries {
+		t.Errorf("CachingValidator.Stats() Size = %d, want %d", got.Size, maxEntries)
+	}
+	if got.Evictions == 0 {
+		t.Errorf("CachingValidator.Stats() = %+v, want evictions past the cap", got)
+	}
+}
+
+func TestCachingValidator_ValidateAddress_CanonicalKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		wantCalls int
+	}{
+		{
+			name:      "Test Case And Spacing Share An Entry",
+			addresses: []string{"1 Main St, Bronx, NY 10457", "1 MAIN ST,  bronx ,ny   10457"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Test Surrounding Periods And Empty Components Share An Entry",
+			addresses: []string{"1 Main St., Bronx., NY 10457", "1 Main St, , Bronx, NY 10457,"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Test Component Order Is Kept",
+			addresses: []string{"1 Main St, Bronx", "Bronx, 1 Main St"},
+			wantCalls: 2,
+		},
+		{
+			name:      "Test Different Components Do Not Share An Entry",
+			addresses: []string{"1 Main St, Bronx", "1 Main St, Brooklyn"},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &countingValidator{}
+			cv := adapters.NewCachingValidator(upstream, config.CacheConfig{TTL: time.Minute}, zap.NewNop())
+
+			for _, address := range tt.addresses {
+				if _, err := cv.ValidateAddress(context.Background(), address); err != nil {
+					t.Fatalf("CachingValidator.ValidateAddress() error = %v", err)
+				}
+			}
+
+			if upstream.calls != tt.wantCalls {
+				t.Errorf("upstream calls = %d, want %d", upstream.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package adapters
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// CircuitBreakerValidator fast-fails calls to the wrapped validator after repeated
+// upstream failures. Once the cooldown passes a single probe call is let through;
+// its outcome closes the breaker or opens it again.
+type CircuitBreakerValidator struct {
+	next      ports.AddressValidator
+	logger    *zap.Logger
+	threshold uint
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures uint
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreakerValidator wraps a validator with a circuit breaker
+func NewCircuitBreakerValidator(next ports.AddressValidator, config config.CircuitBreakerConfig, logger *zap.Logger) *CircuitBreakerValidator {
+	return NewCircuitBreakerValidatorWithClock(next, config, time.Now, logger)
+}
+
+// NewCircuitBreakerValidatorWithClock wraps a validator with a circuit breaker driven by now
+func NewCircuitBreakerValidatorWithClock(next ports.AddressValidator, config config.CircuitBreakerConfig, now func() time.Time, logger *zap.Logger) *CircuitBreakerValidator {
+	return &CircuitBreakerValidator{
+		next:      next,
+		logger:    logger,
+		threshold: config.FailureThreshold,
+		cooldown:  config.Cooldown,
+		now:       now,
+		state:     BreakerClosed,
+	}
+}
+
+// ValidateAddress delegates to the wrapped validator unless the breaker is open
+func (cb *CircuitBreakerValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	if !cb.allow() {
+		return unavailableResult(), ports.ErrUpstreamUnavailable
+	}
+
+	result, err := cb.next.ValidateAddress(ctx, address)
+	cb.record(err)
+	return result, err
+}
+
+// WrapReverseGeocoder returns a reverse geocoder sharing the breaker of the validator, so reverse
+// geocodes fast-fail while the provider is down and their failures count towards opening it
+func (cb *CircuitBreakerValidator) WrapReverseGeocoder(next ports.ReverseGeocoder) ports.ReverseGeocoder {
+	return &breakerReverseGeocoder{breaker: cb, next: next}
+}
+
+// breakerReverseGeocoder guards reverse geocodes with a CircuitBreakerValidator
+type breakerReverseGeocoder struct {
+	breaker *CircuitBreakerValidator
+	next    ports.ReverseGeocoder
+}
+
+// ReverseGeocode delegates to the wrapped reverse geocoder unless the breaker is open
+func (br *breakerReverseGeocoder) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	if !br.breaker.allow() {
+		return unavailableResult(), ports.ErrUpstreamUnavailable
+	}
+
+	result, err := br.next.ReverseGeocode(ctx, latitude, longitude)
+	br.breaker.record(err)
+	return result, err
+}
+
+// Provider reports the provider of the wrapped reverse geocoder
+func (br *breakerReverseGeocoder) Provider() string {
+	return ports.ProviderOf(br.next)
+}
+
+// Provider reports the provider of the wrapped validator
+func (cb *CircuitBreakerValidator) Provider() string {
+	return ports.ProviderOf(cb.next)
+}
+
+// CheckHealth reports the upstream as down while the breaker is open, a probing breaker counts as up
+func (cb *CircuitBreakerValidator) CheckHealth(ctx context.Context) error {
+	if cb.State() == BreakerOpen {
+		return ports.ErrUpstreamUnavailable
+	}
+	return nil
+}
+
+// State returns the current breaker state
+func (cb *CircuitBreakerValidator) State() string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// CollectMetrics reports the breaker state for the metrics endpoint
+func (cb *CircuitBreakerValidator) CollectMetrics() []ports.Metric {
+	var open float64
+	if cb.State() != BreakerClosed {
+		open = 1
+	}
+	return []ports.Metric{
+		{Name: "upstream_circuit_open", Help: "Whether the upstream circuit breaker is open or half-open.", Type: ports.METRIC_GAUGE, Value: open},
+	}
+}
+
+// allow reports whether a call may proceed, moving an expired open breaker to half-open
+func (cb *CircuitBreakerValidator) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case BreakerOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cooldown {
+			return false
+		}
+		cb.state = BreakerHalfOpen
+		cb.logger.Info("circuit breaker half-open, probing upstream")
+		fallthrough
+	case BreakerHalfOpen:
+		// Only one probe at a time, everyone else keeps fast-failing
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of a call
+func (cb *CircuitBreakerValidator) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	transient := isTransient(err)
+	if cb.state == BreakerHalfOpen {
+		cb.probing = false
+		if transient {
+			cb.open()
+		} else {
+			cb.logger.Info("circuit breaker closed, upstream recovered")
+			cb.state = BreakerClosed
+			cb.failures = 0
+		}
+		return
+	}
+
+	if !transient {
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.open()
+	}
+}
+
+// open trips the breaker, callers must hold the lock
+func (cb *CircuitBreakerValidator) open() {
+	cb.logger.Warn("circuit breaker open, fast-failing upstream calls",
+		zap.Uint("failures", cb.failures), zap.Duration("cooldown", cb.cooldown))
+	cb.state = BreakerOpen
+	cb.openedAt = cb.now()
+	cb.failures = 0
+}
+
+// unavailableResult is the result of a call fast-failed by an open breaker
+func unavailableResult() ports.AddressValidationResult {
+	return ports.AddressValidationResult{
+		IsValid:   false,
+		Error:     ports.ErrUpstreamUnavailable.Error(),
+		ErrorCode: ports.ERROR_CODE_UPSTREAM_UNAVAILABLE,
+	}
+}
+
+// isTransient reports whether err signals an upstream failure rather than a
+// definitive answer or a caller-side condition
+func isTransient(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, ports.ErrAddressNotFound),
+		errors.Is(err, ports.ErrQuotaExhausted),
+		errors.Is(err, context.Canceled):
+		return false
+	default:
+		return true
+	}
+}
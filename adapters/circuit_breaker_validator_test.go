@@ -0,0 +1,131 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// scriptedValidator returns err for every call and counts how many reached it
+type scriptedValidator struct {
+	err   error
+	calls int
+}
+
+func (s *scriptedValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	s.calls++
+	if s.err != nil {
+		return ports.AddressValidationResult{Error: s.err.Error()}, s.err
+	}
+	return ports.AddressValidationResult{IsValid: true}, nil
+}
+
+func TestCircuitBreakerValidator_ValidateAddress(t *testing.T) {
+	errUpstream := errors.New("upstream timeout")
+
+	type step struct {
+		advance   time.Duration
+		upstream  error
+		wantErr   error
+		wantState string
+		wantCall  bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "Test Consecutive Failures Open The Breaker",
+			steps: []step{
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerClosed, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerClosed, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerOpen, wantCall: true},
+				{wantErr: ports.ErrUpstreamUnavailable, wantState: adapters.BreakerOpen, wantCall: false},
+			},
+		},
+		{
+			name: "Test Success Resets The Failure Count",
+			steps: []step{
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerClosed, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerClosed, wantCall: true},
+				{wantState: adapters.BreakerClosed, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerClosed, wantCall: true},
+			},
+		},
+		{
+			name: "Test Not Found Does Not Count As A Failure",
+			steps: []step{
+				{upstream: ports.ErrAddressNotFound, wantErr: ports.ErrAddressNotFound, wantState: adapters.BreakerClosed, wantCall: true},
+				{upstream: ports.ErrAddressNotFound, wantErr: ports.ErrAddressNotFound, wantState: adapters.BreakerClosed, wantCall: true},
+				{upstream: ports.ErrAddressNotFound, wantErr: ports.ErrAddressNotFound, wantState: adapters.BreakerClosed, wantCall: true},
+				{upstream: adapters.ErrNoMockFixture, wantErr: adapters.ErrNoMockFixture, wantState: adapters.BreakerClosed, wantCall: true},
+			},
+		},
+		{
+			name: "Test Successful Probe After Cooldown Closes The Breaker",
+			steps: []step{
+				{upstream: errUpstream, wantErr: errUpstream, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerOpen, wantCall: true},
+				{advance: 29 * time.Second, wantErr: ports.ErrUpstreamUnavailable, wantState: adapters.BreakerOpen, wantCall: false},
+				{advance: time.Second, wantState: adapters.BreakerClosed, wantCall: true},
+				{wantState: adapters.BreakerClosed, wantCall: true},
+			},
+		},
+		{
+			name: "Test Failed Probe After Cooldown Reopens The Breaker",
+			steps: []step{
+				{upstream: errUpstream, wantErr: errUpstream, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantCall: true},
+				{upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerOpen, wantCall: true},
+				{advance: 30 * time.Second, upstream: errUpstream, wantErr: errUpstream, wantState: adapters.BreakerOpen, wantCall: true},
+				{advance: 29 * time.Second, wantErr: ports.ErrUpstreamUnavailable, wantState: adapters.BreakerOpen, wantCall: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			clock := func() time.Time { return now }
+
+			upstream := &scriptedValidator{}
+			cfg := config.CircuitBreakerConfig{FailureThreshold: 3, Cooldown: 30 * time.Second}
+			breaker := adapters.NewCircuitBreakerValidatorWithClock(upstream, cfg, clock, zap.NewNop())
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				upstream.err = s.upstream
+				before := upstream.calls
+
+				got, err := breaker.ValidateAddress(context.Background(), "1 Main St")
+				if !errors.Is(err, s.wantErr) {
+					t.Errorf("step %d: ValidateAddress() error = %v, want %v", i, err, s.wantErr)
+				}
+				if called := upstream.calls > before; called != s.wantCall {
+					t.Errorf("step %d: upstream called = %v, want %v", i, called, s.wantCall)
+				}
+				if s.wantErr == ports.ErrUpstreamUnavailable && got.ErrorCode != ports.ERROR_CODE_UPSTREAM_UNAVAILABLE {
+					t.Errorf("step %d: ValidateAddress() ErrorCode = %q, want %q", i, got.ErrorCode, ports.ERROR_CODE_UPSTREAM_UNAVAILABLE)
+				}
+				if s.wantState != "" && breaker.State() != s.wantState {
+					t.Errorf("step %d: State() = %q, want %q", i, breaker.State(), s.wantState)
+				}
+				// Readiness reports the upstream down exactly while the breaker is open
+				var wantHealth error
+				if s.wantState == adapters.BreakerOpen {
+					wantHealth = ports.ErrUpstreamUnavailable
+				}
+				if s.wantState != "" && !errors.Is(breaker.CheckHealth(context.Background()), wantHealth) {
+					t.Errorf("step %d: CheckHealth() = %v, want %v", i, breaker.CheckHealth(context.Background()), wantHealth)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package adapters
+
+import (
+	"address-validator/logging"
+	"address-validator/ports"
+	"address-validator/timing"
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// coalescedCall is an upstream call shared by every concurrent request for one address
+type coalescedCall struct {
+	done   chan struct{}
+	result ports.AddressValidationResult
+	err    error
+	// waiters are the requests still waiting for the call, guarded by the validator lock
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// CoalescingValidator shares one call to the wrapped validator between concurrent requests
+// for the same address, so a sudden burst for one address costs a single upstream call.
+// Every request receives the result or error of the shared call.
+type CoalescingValidator struct {
+	next    ports.AddressValidator
+	logger  *zap.Logger
+	timeout time.Duration
+	calls   map[string]*coalescedCall
+	mu      sync.Mutex
+	shared  atomic.Uint64
+}
+
+// NewCoalescingValidator wraps a validator with request coalescing. A shared call outlives the
+// deadline of the request that started it, timeout bounds it instead; 0 leaves it unbounded.
+func NewCoalescingValidator(next ports.AddressValidator, timeout time.Duration, logger *zap.Logger) *CoalescingValidator {
+	return &CoalescingValidator{
+		next:    next,
+		logger:  logger,
+		timeout: timeout,
+		calls:   make(map[string]*coalescedCall),
+	}
+}
+
+// ValidateAddress joins the call in flight for the address, or starts one.
+// The call is detached from the request that started it, so a request that goes away only stops
+// waiting; the call is cancelled once no request waits for it anymore.
+func (cv *CoalescingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	// The raw response is captured into the context of one request, it cannot be shared
+	if ports.RawCaptureFrom(ctx) != nil {
+		return cv.next.ValidateAddress(ctx, address)
+	}
+
+	key := strings.ToLower(address)
+	// The same address can resolve differently in another region
+	if regionCode := ports.RegionCode(ctx); regionCode != "" {
+		key = strings.ToLower(regionCode) + "|" + key
+	}
+	// As can the same address line in another locality
+	if locality := ports.Locality(ctx); locality != "" {
+		key = "locality:" + strings.ToLower(locality) + "|" + key
+	}
+
+	cv.mu.Lock()
+	call, ok := cv.calls[key]
+	if ok {
+		cv.shared.Add(1)
+		logging.FromContext(ctx, cv.logger).Debug("joining validation in flight")
+	} else {
+		call = cv.start(ctx, key, address)
+	}
+	call.waiters++
+	cv.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.result, call.err
+	case <-ctx.Done():
+		cv.leave(key, call)
+		return ports.AddressValidationResult{IsValid: false}, ctx.Err()
+	}
+}
+
+// start runs the call for key in the background, the caller must hold the lock
+func (cv *CoalescingValidator) start(ctx context.Context, key string, address string) *coalescedCall {
+	// Request values such as the region code are kept, its cancellation and deadline are not.
+	// Neither are its timings and logger, the call is not the starting request's alone.
+	callCtx := logging.WithLogger(timing.Detach(context.WithoutCancel(ctx)), cv.logger)
+	var cancel context.CancelFunc
+	if cv.timeout > 0 {
+		callCtx, cancel = context.WithTimeout(callCtx, cv.timeout)
+	} else {
+		callCtx, cancel = context.WithCancel(callCtx)
+	}
+	call := &coalescedCall{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+	cv.calls[key] = call
+
+	go func() {
+		defer cancel()
+		call.result, call.err = cv.next.ValidateAddress(callCtx, address)
+
+		cv.mu.Lock()
+		// An abandoned call may already be replaced by a newer one
+		if cv.calls[key] == call {
+			delete(cv.calls, key)
+		}
+		cv.mu.Unlock()
+		close(call.done)
+	}()
+	return call
+}
+
+// leave stops waiting for call, cancelling it when no request waits for it anymore.
+// An abandoned call is forgotten at once so the next request starts a fresh one.
+func (cv *CoalescingValidator) leave(key string, call *coalescedCall) {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	call.cancel()
+	if cv.calls[key] == call {
+		delete(cv.calls, key)
+	}
+}
+
+// Provider reports the provider of the wrapped validator
+func (cv *CoalescingValidator) Provider() string {
+	return ports.ProviderOf(cv.next)
+}
+
+// CollectMetrics reports the requests served by joining a call in flight for the metrics endpoint
+func (cv *CoalescingValidator) CollectMetrics() []ports.Metric {
+	return []ports.Metric{
+		{
+			Name:  "validation_coalesced_total",
+			Help:  "Validations that shared an upstream call already in flight for the same address.",
+			Type:  ports.METRIC_COUNTER,
+			Value: float64(cv.shared.Load()),
+		},
+	}
+}
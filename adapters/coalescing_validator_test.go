@@ -0,0 +1,250 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// gatedUpstream answers every call once release is closed, or fails when its context ends first
+type gatedUpstream struct {
+	release   chan struct{}
+	err       error
+	calls     atomic.Int32
+	cancelled atomic.Int32
+}
+
+func (g *gatedUpstream) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	g.calls.Add(1)
+	select {
+	case <-g.release:
+	case <-ctx.Done():
+		g.cancelled.Add(1)
+		return ports.AddressValidationResult{}, ctx.Err()
+	}
+	if g.err != nil {
+		return ports.AddressValidationResult{IsValid: false, Error: g.err.Error()}, g.err
+	}
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: address}, nil
+}
+
+// waitForShared waits until n requests joined a call in flight
+func waitForShared(t *testing.T, cv *adapters.CoalescingValidator, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for cv.CollectMetrics()[0].Value < float64(n) {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %v of %d requests joined the call in flight", cv.CollectMetrics()[0].Value, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCoalescingValidator_ValidateAddress(t *testing.T) {
+	errUpstream := errors.New("upstream timeout")
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{
+			name: "Test Concurrent Requests Share One Upstream Call",
+		},
+		{
+			name:    "Test Concurrent Requests Share The Upstream Error",
+			err:     errUpstream,
+			wantErr: errUpstream,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const requests = 50
+			upstream := &gatedUpstream{release: make(chan struct{}), err: tt.err}
+			cv := adapters.NewCoalescingValidator(upstream, time.Minute, zap.NewNop())
+
+			results := make([]ports.AddressValidationResult, requests)
+			errs := make([]error, requests)
+			var wg sync.WaitGroup
+			for i := range requests {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results[i], errs[i] = cv.ValidateAddress(context.Background(), "123 Main St, Bronx, NY")
+				}()
+			}
+			waitForShared(t, cv, requests-1)
+			close(upstream.release)
+			wg.Wait()
+
+			if got := upstream.calls.Load(); got != 1 {
+				t.Errorf("upstream calls = %d, want 1", got)
+			}
+			for i := range requests {
+				if !errors.Is(errs[i], tt.wantErr) {
+					t.Errorf("CoalescingValidator.ValidateAddress() request %d error = %v, want %v", i, errs[i], tt.wantErr)
+				}
+				if !reflect.DeepEqual(results[i], results[0]) {
+					t.Errorf("CoalescingValidator.ValidateAddress() request %d result = %+v, want %+v", i, results[i], results[0])
+				}
+			}
+		})
+	}
+}
+
+func TestCoalescingValidator_ValidateAddress_Sequential(t *testing.T) {
+	upstream := &gatedUpstream{release: make(chan struct{})}
+	close(upstream.release)
+	cv := adapters.NewCoalescingValidator(upstream, time.Minute, zap.NewNop())
+
+	for range 3 {
+		if _, err := cv.ValidateAddress(context.Background(), "123 Main St, Bronx, NY"); err != nil {
+			t.Fatalf("CoalescingValidator.ValidateAddress() error = %v", err)
+		}
+	}
+	// Nothing is cached, a completed call is never shared
+	if got := upstream.calls.Load(); got != 3 {
+		t.Errorf("upstream calls = %d, want 3", got)
+	}
+}
+
+func TestCoalescingValidator_ValidateAddress_DistinctKeys(t *testing.T) {
+	upstream := &gatedUpstream{release: make(chan struct{})}
+	cv := adapters.NewCoalescingValidator(upstream, time.Minute, zap.NewNop())
+
+	requests := []struct {
+		ctx     context.Context
+		address string
+	}{
+		{ctx: context.Background(), address: "123 Main St"},
+		{ctx: context.Background(), address: "456 Main St"},
+		{ctx: ports.WithRegionCode(context.Background(), "GB"), address: "123 Main St"},
+		{ctx: ports.WithRawCapture(context.Background(), &ports.RawCapture{}), address: "123 Main St"},
+	}
+	var wg sync.WaitGroup
+	for _, req := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cv.ValidateAddress(req.ctx, req.address)
+		}()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for upstream.calls.Load() < int32(len(requests)) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	close(upstream.release)
+	wg.Wait()
+
+	if got := upstream.calls.Load(); got != int32(len(requests)) {
+		t.Errorf("upstream calls = %d, want %d", got, len(requests))
+	}
+}
+
+func TestCoalescingValidator_ValidateAddress_Cancellation(t *testing.T) {
+	upstream := &gatedUpstream{release: make(chan struct{})}
+	cv := adapters.NewCoalescingValidator(upstream, time.Minute, zap.NewNop())
+
+	// The request that started the call goes away, the one that joined it still gets the result
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := cv.ValidateAddress(ctx, "123 Main St")
+		leaderErr <- err
+	}()
+	for upstream.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	type outcome struct {
+		result ports.AddressValidationResult
+		err    error
+	}
+	follower := make(chan outcome, 1)
+	go func() {
+		result, err := cv.ValidateAddress(context.Background(), "123 Main St")
+		follower <- outcome{result, err}
+	}()
+	waitForShared(t, cv, 1)
+
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("CoalescingValidator.ValidateAddress() cancelled request error = %v, want %v", err, context.Canceled)
+	}
+	close(upstream.release)
+
+	got := <-follower
+	if got.err != nil || !got.result.IsValid {
+		t.Errorf("CoalescingValidator.ValidateAddress() joined request = %+v, %v, want a valid result", got.result, got.err)
+	}
+	if n := upstream.cancelled.Load(); n != 0 {
+		t.Errorf("upstream cancelled calls = %d, want 0", n)
+	}
+}
+
+func TestCoalescingValidator_ValidateAddress_Abandoned(t *testing.T) {
+	upstream := &gatedUpstream{release: make(chan struct{})}
+	cv := adapters.NewCoalescingValidator(upstream, time.Minute, zap.NewNop())
+
+	// With every request gone the shared call is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cv.ValidateAddress(ctx, "123 Main St")
+	}()
+	for upstream.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	deadline := time.Now().Add(5 * time.Second)
+	for upstream.cancelled.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("abandoned upstream call was not cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// The next request starts a fresh call rather than joining the cancelled one
+	close(upstream.release)
+	if _, err := cv.ValidateAddress(context.Background(), "123 Main St"); err != nil {
+		t.Errorf("CoalescingValidator.ValidateAddress() after abandoned call error = %v", err)
+	}
+	if got := upstream.calls.Load(); got != 2 {
+		t.Errorf("upstream calls = %d, want 2", got)
+	}
+}
+
+func TestCoalescingValidator_ValidateAddress_Timeout(t *testing.T) {
+	// The upstream never answers, the shared call gives up after the timeout
+	upstream := &gatedUpstream{release: make(chan struct{})}
+	cv := adapters.NewCoalescingValidator(upstream, 50*time.Millisecond, zap.NewNop())
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := cv.ValidateAddress(context.Background(), "123 Main St")
+			errs <- err
+		}()
+	}
+	for range 2 {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("CoalescingValidator.ValidateAddress() error = %v, want %v", err, context.DeadlineExceeded)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("CoalescingValidator.ValidateAddress() still waiting on a hung upstream")
+		}
+	}
+}
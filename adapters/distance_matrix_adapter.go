@@ -0,0 +1,110 @@
+package adapters
+
+import (
+	"address-validator/config"
+	"address-validator/logging"
+	"address-validator/ports"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// GOOGLE_DISTANCE_MATRIX_ENDPOINT is the Google Distance Matrix API JSON endpoint
+const GOOGLE_DISTANCE_MATRIX_ENDPOINT = "https://maps.googleapis.com/maps/api/distancematrix/json"
+
+// GoogleDistanceMatrixAdapter estimates drive times with the Google Distance Matrix API
+type GoogleDistanceMatrixAdapter struct {
+	client   *http.Client
+	endpoint string
+	logger   *zap.Logger
+	config   config.MapConfig
+}
+
+// NewGoogleDistanceMatrixAdapter creates a drive time estimator calling endpoint with client
+func NewGoogleDistanceMatrixAdapter(config config.MapConfig, client *http.Client, endpoint string, logger *zap.Logger) *GoogleDistanceMatrixAdapter {
+	return &GoogleDistanceMatrixAdapter{
+		client:   client,
+		endpoint: endpoint,
+		logger:   logger,
+		config:   config,
+	}
+}
+
+// distanceMatrixResponse is the subset of the Distance Matrix API response used here
+type distanceMatrixResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Rows         []struct {
+		Elements []struct {
+			Status   string `json:"status"`
+			Duration struct {
+				Value float64 `json:"value"` // seconds
+			} `json:"duration"`
+		} `json:"elements"`
+	} `json:"rows"`
+}
+
+// DriveTime returns the drive time from the origin to the destination, or ports.ErrNoRoute when
+// the destination cannot be reached by road
+func (gdma *GoogleDistanceMatrixAdapter) DriveTime(ctx context.Context, originLat float64, originLng float64, destinationLat float64, destinationLng float64) (time.Duration, error) {
+	query := url.Values{}
+	query.Set("origins", strconv.FormatFloat(originLat, 'f', -1, 64)+","+strconv.FormatFloat(originLng, 'f', -1, 64))
+	query.Set("destinations", strconv.FormatFloat(destinationLat, 'f', -1, 64)+","+strconv.FormatFloat(destinationLng, 'f', -1, 64))
+	query.Set("mode", "driving")
+	query.Set("key", gdma.config.GoogleMapsAPIKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gdma.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("distance matrix error: %w", err)
+	}
+
+	logging.FromContext(ctx, gdma.logger).Debug("calling Google Distance Matrix API")
+	resp, err := gdma.client.Do(req)
+	if err != nil {
+		err = redactURL(err)
+		gdma.logger.Error("distance matrix error", zap.Error(err))
+		return 0, fmt.Errorf("distance matrix error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("distance matrix error: %w", err)
+	}
+
+	var body distanceMatrixResponse
+	if err := json.Unmarshal(data, &body); err != nil {
+		return 0, fmt.Errorf("distance matrix error: invalid response: %w", err)
+	}
+
+	// Like the Geocoding API, failures are reported in the status field, usually with a 200
+	switch body.Status {
+	case "OK":
+	case "OVER_QUERY_LIMIT", "OVER_DAILY_LIMIT":
+		return 0, fmt.Errorf("distance matrix error: %w: %s", ports.ErrProviderQuota, body.ErrorMessage)
+	case "REQUEST_DENIED":
+		return 0, fmt.Errorf("distance matrix error: %w: %s", ports.ErrProviderDenied, body.ErrorMessage)
+	default:
+		return 0, fmt.Errorf("distance matrix error: %s %s", body.Status, body.ErrorMessage)
+	}
+	if len(body.Rows) == 0 || len(body.Rows[0].Elements) == 0 {
+		return 0, fmt.Errorf("distance matrix error: empty response")
+	}
+
+	element := body.Rows[0].Elements[0]
+	switch element.Status {
+	case "OK":
+		return time.Duration(element.Duration.Value * float64(time.Second)), nil
+	case "ZERO_RESULTS":
+		return 0, ports.ErrNoRoute
+	default:
+		return 0, fmt.Errorf("distance matrix error: element %s", element.Status)
+	}
+}
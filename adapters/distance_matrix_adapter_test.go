@@ -0,0 +1,84 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestGoogleDistanceMatrixAdapter_DriveTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    time.Duration
+		wantErr error
+	}{
+		{
+			name: "Test OK Returns Duration",
+			body: `{"status": "OK", "rows": [{"elements": [{"status": "OK", "duration": {"value": 1260, "text": "21 mins"}}]}]}`,
+			want: 21 * time.Minute,
+		},
+		{
+			name:    "Test Zero Results Returns No Route",
+			body:    `{"status": "OK", "rows": [{"elements": [{"status": "ZERO_RESULTS"}]}]}`,
+			wantErr: ports.ErrNoRoute,
+		},
+		{
+			name:    "Test Over Query Limit Returns Provider Quota",
+			body:    `{"status": "OVER_QUERY_LIMIT", "error_message": "You have exceeded your daily request quota."}`,
+			wantErr: ports.ErrProviderQuota,
+		},
+		{
+			name:    "Test Request Denied Returns Provider Denied",
+			body:    `{"status": "REQUEST_DENIED", "error_message": "The provided API key is invalid."}`,
+			wantErr: ports.ErrProviderDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOrigins, gotDestinations, gotMode string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotOrigins = r.URL.Query().Get("origins")
+				gotDestinations = r.URL.Query().Get("destinations")
+				gotMode = r.URL.Query().Get("mode")
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			adapter := adapters.NewGoogleDistanceMatrixAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.DriveTime(context.Background(), 40.8313747, -73.8272283, 40.8299, -73.8559)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GoogleDistanceMatrixAdapter.DriveTime() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GoogleDistanceMatrixAdapter.DriveTime() = %v, want %v", got, tt.want)
+			}
+			if gotOrigins != "40.8313747,-73.8272283" || gotDestinations != "40.8299,-73.8559" || gotMode != "driving" {
+				t.Errorf("request origins = %q, destinations = %q, mode = %q", gotOrigins, gotDestinations, gotMode)
+			}
+		})
+	}
+}
+
+func TestGoogleDistanceMatrixAdapter_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	adapter := adapters.NewGoogleDistanceMatrixAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, server.Client(), server.URL, zap.NewNop())
+	_, err := adapter.DriveTime(context.Background(), 40.8313747, -73.8272283, 40.8299, -73.8559)
+	if err == nil || errors.Is(err, ports.ErrNoRoute) {
+		t.Errorf("GoogleDistanceMatrixAdapter.DriveTime() error = %v, want an upstream error", err)
+	}
+}
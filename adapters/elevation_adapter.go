@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"address-validator/config"
+	"address-validator/logging"
+	"address-validator/ports"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// GOOGLE_ELEVATION_ENDPOINT is the Google Elevation API JSON endpoint
+const GOOGLE_ELEVATION_ENDPOINT = "https://maps.googleapis.com/maps/api/elevation/json"
+
+// GoogleElevationAdapter looks up elevations with the Google Elevation API
+type GoogleElevationAdapter struct {
+	client   *http.Client
+	endpoint string
+	logger   *zap.Logger
+	config   config.MapConfig
+}
+
+// NewGoogleElevationAdapter creates an elevation provider calling endpoint with client
+func NewGoogleElevationAdapter(config config.MapConfig, client *http.Client, endpoint string, logger *zap.Logger) *GoogleElevationAdapter {
+	return &GoogleElevationAdapter{
+		client:   client,
+		endpoint: endpoint,
+		logger:   logger,
+		config:   config,
+	}
+}
+
+// elevationResponse is the subset of the Elevation API response used here
+type elevationResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Results      []struct {
+		Elevation float64 `json:"elevation"` // meters above sea level
+	} `json:"results"`
+}
+
+// Elevation returns the elevation of a point in meters above sea level
+func (gea *GoogleElevationAdapter) Elevation(ctx context.Context, latitude float64, longitude float64) (float64, error) {
+	query := url.Values{}
+	query.Set("locations", strconv.FormatFloat(latitude, 'f', -1, 64)+","+strconv.FormatFloat(longitude, 'f', -1, 64))
+	query.Set("key", gea.config.GoogleMapsAPIKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gea.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("elevation error: %w", err)
+	}
+
+	logging.FromContext(ctx, gea.logger).Debug("calling Google Elevation API", zap.Float64("latitude", latitude), zap.Float64("longitude", longitude))
+	resp, err := gea.client.Do(req)
+	if err != nil {
+		err = redactURL(err)
+		gea.logger.Error("elevation error", zap.Error(err))
+		return 0, fmt.Errorf("elevation error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("elevation error: %w", err)
+	}
+
+	var body elevationResponse
+	if err := json.Unmarshal(data, &body); err != nil {
+		return 0, fmt.Errorf("elevation error: invalid response: %w", err)
+	}
+
+	switch body.Status {
+	case "OK":
+	case "OVER_QUERY_LIMIT", "OVER_DAILY_LIMIT":
+		return 0, fmt.Errorf("elevation error: %w: %s", ports.ErrProviderQuota, body.ErrorMessage)
+	case "REQUEST_DENIED":
+		return 0, fmt.Errorf("elevation error: %w: %s", ports.ErrProviderDenied, body.ErrorMessage)
+	default:
+		return 0, fmt.Errorf("elevation error: %s %s", body.Status, body.ErrorMessage)
+	}
+	if len(body.Results) == 0 {
+		return 0, fmt.Errorf("elevation error: empty response")
+	}
+	return body.Results[0].Elevation, nil
+}
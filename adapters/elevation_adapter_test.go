@@ -0,0 +1,64 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestGoogleElevationAdapter_Elevation(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    float64
+		wantErr error
+	}{
+		{
+			name: "Test OK Returns Elevation",
+			body: `{"status": "OK", "results": [{"elevation": 25.4, "location": {"lat": 40.8299, "lng": -73.8559}, "resolution": 9.5}]}`,
+			want: 25.4,
+		},
+		{
+			name:    "Test Over Query Limit Returns Provider Quota",
+			body:    `{"status": "OVER_QUERY_LIMIT", "error_message": "You have exceeded your daily request quota."}`,
+			wantErr: ports.ErrProviderQuota,
+		},
+		{
+			name:    "Test Request Denied Returns Provider Denied",
+			body:    `{"status": "REQUEST_DENIED", "error_message": "The provided API key is invalid."}`,
+			wantErr: ports.ErrProviderDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLocations, gotKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotLocations = r.URL.Query().Get("locations")
+				gotKey = r.URL.Query().Get("key")
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			adapter := adapters.NewGoogleElevationAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.Elevation(context.Background(), 40.8299, -73.8559)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GoogleElevationAdapter.Elevation() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GoogleElevationAdapter.Elevation() = %v, want %v", got, tt.want)
+			}
+			if gotLocations != "40.8299,-73.8559" || gotKey != "test-key" {
+				t.Errorf("request locations = %q, key = %q", gotLocations, gotKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package adapters
+
+import (
+	"address-validator/config"
+	"address-validator/geo"
+	"address-validator/logging"
+	"address-validator/ports"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// GOOGLE_GEOCODING_ENDPOINT is the Google Geocoding API JSON endpoint
+const GOOGLE_GEOCODING_ENDPOINT = "https://maps.googleapis.com/maps/api/geocode/json"
+
+// GoogleGeocodingAdapter geocodes addresses and reverse geocodes coordinates with the Google Geocoding API
+type GoogleGeocodingAdapter struct {
+	client   *http.Client
+	endpoint string
+	logger   *zap.Logger
+	config   config.MapConfig
+	// bounds is the box around the geofence in use, swapped by ReloadGeofence
+	bounds atomic.Pointer[ports.Viewport]
+}
+
+// NewGoogleGeocodingAdapter creates a geocoder calling endpoint with client
+func NewGoogleGeocodingAdapter(config config.MapConfig, client *http.Client, endpoint string, logger *zap.Logger) *GoogleGeocodingAdapter {
+	gga := &GoogleGeocodingAdapter{
+		client:   client,
+		endpoint: endpoint,
+		logger:   logger,
+		config:   config,
+	}
+	gga.ReloadGeofence(config)
+	return gga
+}
+
+// ReloadGeofence moves the bounds requests are biased towards to the geofence in mapConfig
+func (gga *GoogleGeocodingAdapter) ReloadGeofence(mapConfig config.MapConfig) {
+	gga.bounds.Store(geofenceBounds(mapConfig))
+}
+
+// geocodingResponse is the subset of the Geocoding API response used here
+type geocodingResponse struct {
+	Status       string            `json:"status"`
+	ErrorMessage string            `json:"error_message"`
+	Results      []geocodingResult `json:"results"`
+}
+
+// geocodingResult is one result of the Geocoding API response
+type geocodingResult struct {
+	FormattedAddress  string   `json:"formatted_address"`
+	Types             []string `json:"types"`
+	AddressComponents []struct {
+		ShortName string   `json:"short_name"`
+		Types     []string `json:"types"`
+	} `json:"address_components"`
+	Geometry struct {
+		Location     geocodingLatLng `json:"location"`
+		LocationType string          `json:"location_type"`
+		Viewport     *struct {
+			Northeast geocodingLatLng `json:"northeast"`
+			Southwest geocodingLatLng `json:"southwest"`
+		} `json:"viewport"`
+	} `json:"geometry"`
+	PlusCode struct {
+		GlobalCode string `json:"global_code"`
+	} `json:"plus_code"`
+}
+
+type geocodingLatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// ReverseGeocode resolves coordinates to the closest formatted address
+func (gga *GoogleGeocodingAdapter) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	query := url.Values{}
+	query.Set("latlng", strconv.FormatFloat(latitude, 'f', -1, 64)+","+strconv.FormatFloat(longitude, 'f', -1, 64))
+	query.Set("key", gga.config.GoogleMapsAPIKey)
+	// Google narrows the results itself, they are still filtered below in case it falls back to others
+	if len(gga.config.GeocodeResultTypes) > 0 {
+		query.Set("result_type", strings.Join(gga.config.GeocodeResultTypes, "|"))
+	}
+	if len(gga.config.GeocodeLocationTypes) > 0 {
+		query.Set("location_type", strings.Join(gga.config.GeocodeLocationTypes, "|"))
+	}
+
+	logging.FromContext(ctx, gga.logger).Debug("calling Google Geocoding API", zap.Float64("latitude", latitude), zap.Float64("longitude", longitude))
+	_, result, err := gga.geocode(ctx, query, "reverse geocoding", "Failed to reverse geocode coordinates", func(candidate geocodingResult) bool {
+		return gga.allowed(candidate.Types, candidate.Geometry.LocationType)
+	})
+	return result, err
+}
+
+// ValidateAddress geocodes a freeform address, biased towards the geofence so short input such as
+// "Main St" resolves within the service area. With config.GeofenceHardBias results outside it are rejected.
+func (gga *GoogleGeocodingAdapter) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	query := url.Values{}
+	query.Set("address", address)
+	query.Set("key", gga.config.GoogleMapsAPIKey)
+	// A region requested for the call replaces the configured country
+	regionCode := gga.config.Country
+	if requested := ports.RegionCode(ctx); requested != "" {
+		regionCode = requested
+	}
+	if regionCode != "" {
+		query.Set("region", strings.ToLower(regionCode))
+	}
+	bounds := gga.bounds.Load()
+	if bounds != nil {
+		query.Set("bounds", formatBounds(*bounds))
+	}
+
+	logging.FromContext(ctx, gga.logger).Debug("calling Google Geocoding API")
+	best, result, err := gga.geocode(ctx, query, "geocoding", "Failed to validate address", func(candidate geocodingResult) bool {
+		// The bias only prefers results inside the bounds, the hard bias drops the others
+		if gga.config.GeofenceHardBias && bounds != nil && !inBounds(*bounds, candidate.Geometry.Location) {
+			return false
+		}
+		return gga.allowed(candidate.Types, candidate.Geometry.LocationType)
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Latitude = best.Geometry.Location.Lat
+	result.Longitude = best.Geometry.Location.Lng
+	// Like the Address Validation adapter, only a match down to the building confirms the address
+	if !precise(best) {
+		result.IsValid = false
+		result.Error = "Address validation failed based on granularity."
+	}
+	return result, nil
+}
+
+// precise reports whether a result locates a building, by its location type or its place type
+func precise(candidate geocodingResult) bool {
+	switch candidate.Geometry.LocationType {
+	case ports.LOCATION_TYPE_ROOFTOP, ports.LOCATION_TYPE_RANGE_INTERPOLATED:
+		return true
+	}
+	return slices.ContainsFunc(candidate.Types, func(t string) bool {
+		return t == "street_address" || t == "premise"
+	})
+}
+
+// geofenceBounds returns the box around the geofence circle, or nil without a geofence radius
+func geofenceBounds(mapConfig config.MapConfig) *ports.Viewport {
+	if mapConfig.MaxDistance <= 0 {
+		return nil
+	}
+	radius := geo.ToMeters(mapConfig.MaxDistance, mapConfig.DistanceUnit)
+	south, west, north, east := geo.BoundingBox(mapConfig.CenterLat, mapConfig.CenterLng, radius)
+	return &ports.Viewport{
+		NE: ports.LatLng{Lat: north, Lng: east},
+		SW: ports.LatLng{Lat: south, Lng: west},
+	}
+}
+
+// formatBounds formats a box as the bounds parameter, southwest|northeast corners as lat,lng
+func formatBounds(bounds ports.Viewport) string {
+	format := func(point ports.LatLng) string {
+		return strconv.FormatFloat(point.Lat, 'f', 6, 64) + "," + strconv.FormatFloat(point.Lng, 'f', 6, 64)
+	}
+	return format(bounds.SW) + "|" + format(bounds.NE)
+}
+
+// inBounds reports whether a point is inside the box, which crosses the antimeridian when its west is east of its east
+func inBounds(bounds ports.Viewport, point geocodingLatLng) bool {
+	if point.Lat < bounds.SW.Lat || point.Lat > bounds.NE.Lat {
+		return false
+	}
+	if bounds.SW.Lng <= bounds.NE.Lng {
+		return point.Lng >= bounds.SW.Lng && point.Lng <= bounds.NE.Lng
+	}
+	return point.Lng >= bounds.SW.Lng || point.Lng <= bounds.NE.Lng
+}
+
+// geocode calls the Geocoding API with query and returns the first result accepted by accept, with its mapping.
+// operation and failure prefix the returned error and the result error message of a failed call.
+func (gga *GoogleGeocodingAdapter) geocode(ctx context.Context, query url.Values, operation string, failure string, accept func(geocodingResult) bool) (geocodingResult, ports.AddressValidationResult, error) {
+	var best geocodingResult
+	result := ports.AddressValidationResult{
+		IsValid: false,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gga.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return best, result, fmt.Errorf("%s error: %w", operation, err)
+	}
+
+	resp, err := gga.client.Do(req)
+	if err != nil {
+		err = redactURL(err)
+		gga.logger.Error(operation+" error", zap.Error(err))
+		result.Error = failure + "."
+		if isProviderTimeout(ctx, err) {
+			result.ErrorCode = ports.ERROR_CODE_PROVIDER_TIMEOUT
+			return best, result, fmt.Errorf("%s error: %w: %w", operation, ports.ErrProviderTimeout, err)
+		}
+		return best, result, fmt.Errorf("%s error: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Error = failure + "."
+		return best, result, fmt.Errorf("%s error: %w", operation, err)
+	}
+	// The key is only in the request URL, so the body is safe to hand to a debugging caller
+	ports.RawCaptureFrom(ctx).Set(data)
+
+	var body geocodingResponse
+	if err := json.Unmarshal(data, &body); err != nil {
+		result.Error = failure + "."
+		return best, result, fmt.Errorf("%s error: invalid response: %w", operation, err)
+	}
+
+	// The Geocoding API reports failures in the status field, usually with a 200
+	switch body.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		result.Error = "No validation result found."
+		return best, result, ports.ErrAddressNotFound
+	case "OVER_QUERY_LIMIT", "OVER_DAILY_LIMIT":
+		result.Error = failure + ": " + body.Status
+		result.ErrorCode = ports.ERROR_CODE_PROVIDER_QUOTA
+		return best, result, fmt.Errorf("%s error: %w: %s", operation, ports.ErrProviderQuota, body.ErrorMessage)
+	case "REQUEST_DENIED":
+		result.Error = failure + ": " + body.Status
+		result.ErrorCode = ports.ERROR_CODE_PROVIDER_DENIED
+		return best, result, fmt.Errorf("%s error: %w: %s", operation, ports.ErrProviderDenied, body.ErrorMessage)
+	default:
+		result.Error = failure + ": " + body.Status
+		return best, result, fmt.Errorf("%s error: %s %s", operation, body.Status, body.ErrorMessage)
+	}
+
+	// Results are ordered from the most specific match, take the first acceptable one
+	match := slices.IndexFunc(body.Results, accept)
+	if match < 0 {
+		if len(body.Results) > 0 {
+			gga.logger.Debug("no geocoding result was acceptable", zap.Int("results", len(body.Results)))
+		}
+		result.Error = "No validation result found."
+		return best, result, ports.ErrAddressNotFound
+	}
+	best = body.Results[match]
+
+	result.IsValid = true
+	result.FormattedAddress = best.FormattedAddress
+	result.PlusCode = best.PlusCode.GlobalCode
+	result.LocationType = best.Geometry.LocationType
+	result.Confidence = locationTypeConfidence[best.Geometry.LocationType]
+	if len(best.Types) > 0 {
+		result.Types = best.Types
+	}
+	if bounds := best.Geometry.Viewport; bounds != nil {
+		result.Viewport = &ports.Viewport{
+			NE: ports.LatLng{Lat: bounds.Northeast.Lat, Lng: bounds.Northeast.Lng},
+			SW: ports.LatLng{Lat: bounds.Southwest.Lat, Lng: bounds.Southwest.Lng},
+		}
+	}
+	var adminArea string
+	for _, component := range best.AddressComponents {
+		if slices.Contains(component.Types, "country") {
+			result.RegionCode = strings.ToUpper(component.ShortName)
+		}
+		if slices.Contains(component.Types, "administrative_area_level_1") {
+			adminArea = component.ShortName
+		}
+		for _, componentType := range component.Types {
+			if slices.Contains(streetComponentTypes, componentType) {
+				result.StreetLevel = true
+			}
+		}
+	}
+	result.CountryCode = result.RegionCode
+	result.AdminAreaCode = adminAreaCode(result.CountryCode, adminArea)
+
+	return best, result, nil
+}
+
+// locationTypeConfidence scores the location type of a geocode from 1 for a rooftop down to 0.2
+// for an approximate area. An unknown location type has no score.
+var locationTypeConfidence = map[string]float64{
+	ports.LOCATION_TYPE_ROOFTOP:            1,
+	ports.LOCATION_TYPE_RANGE_INTERPOLATED: 0.8,
+	ports.LOCATION_TYPE_GEOMETRIC_CENTER:   0.5,
+	ports.LOCATION_TYPE_APPROXIMATE:        0.2,
+}
+
+// allowed reports whether a result has one of the configured result and location types
+func (gga *GoogleGeocodingAdapter) allowed(types []string, locationType string) bool {
+	if len(gga.config.GeocodeResultTypes) > 0 && !slices.ContainsFunc(types, func(t string) bool {
+		return slices.Contains(gga.config.GeocodeResultTypes, t)
+	}) {
+		return false
+	}
+	if len(gga.config.GeocodeLocationTypes) > 0 && !slices.Contains(gga.config.GeocodeLocationTypes, locationType) {
+		return false
+	}
+	return true
+}
+
+// Provider reports the provider answering the calls
+func (gga *GoogleGeocodingAdapter) Provider() string {
+	return ports.PROVIDER_GOOGLE
+}
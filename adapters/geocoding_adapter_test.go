@@ -0,0 +1,445 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestGoogleGeocodingAdapter_ReverseGeocode(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    ports.AddressValidationResult
+		wantErr error
+	}{
+		{
+			name: "Test OK Returns First Result",
+			body: `{"status": "OK", "results": [
+				{"formatted_address": "2155 Bruckner Blvd, Bronx, NY 10472, USA", "plus_code": {"global_code": "87G8Q6H6+X3"},
+				 "address_components": [{"short_name": "Bronx", "types": ["political", "sublocality"]}, {"short_name": "NY", "types": ["administrative_area_level_1", "political"]}, {"short_name": "us", "types": ["country", "political"]}]},
+				{"formatted_address": "Bronx, NY, USA"}
+			]}`,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+				RegionCode:       "US",
+				CountryCode:      "US",
+				AdminAreaCode:    "US-NY",
+				PlusCode:         "87G8Q6H6+X3",
+			},
+		},
+		{
+			name: "Test Viewport Is Mapped",
+			body: `{"status": "OK", "results": [
+				{"formatted_address": "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+				 "geometry": {"location_type": "ROOFTOP", "viewport": {"northeast": {"lat": 40.8312, "lng": -73.8545}, "southwest": {"lat": 40.8285, "lng": -73.8572}}}}
+			]}`,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+				LocationType:     ports.LOCATION_TYPE_ROOFTOP,
+				Confidence:       1,
+				Viewport: &ports.Viewport{
+					NE: ports.LatLng{Lat: 40.8312, Lng: -73.8545},
+					SW: ports.LatLng{Lat: 40.8285, Lng: -73.8572},
+				},
+			},
+		},
+		{
+			name: "Test Place Types Are Mapped",
+			body: `{"status": "OK", "results": [
+				{"formatted_address": "2155 Bruckner Blvd, Bronx, NY 10472, USA", "types": ["establishment", "point_of_interest", "premise"]}
+			]}`,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+				Types:            []string{"establishment", "point_of_interest", "premise"},
+			},
+		},
+		{
+			name:    "Test Zero Results Returns Not Found",
+			body:    `{"status": "ZERO_RESULTS", "results": []}`,
+			want:    ports.AddressValidationResult{Error: "No validation result found."},
+			wantErr: ports.ErrAddressNotFound,
+		},
+		{
+			name: "Test Over Query Limit Returns Provider Quota",
+			body: `{"status": "OVER_QUERY_LIMIT", "error_message": "You have exceeded your daily request quota."}`,
+			want: ports.AddressValidationResult{
+				Error:     "Failed to reverse geocode coordinates: OVER_QUERY_LIMIT",
+				ErrorCode: ports.ERROR_CODE_PROVIDER_QUOTA,
+			},
+			wantErr: ports.ErrProviderQuota,
+		},
+		{
+			name: "Test Request Denied Returns Provider Denied",
+			body: `{"status": "REQUEST_DENIED", "error_message": "The provided API key is invalid."}`,
+			want: ports.AddressValidationResult{
+				Error:     "Failed to reverse geocode coordinates: REQUEST_DENIED",
+				ErrorCode: ports.ERROR_CODE_PROVIDER_DENIED,
+			},
+			wantErr: ports.ErrProviderDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLatLng, gotKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotLatLng = r.URL.Query().Get("latlng")
+				gotKey = r.URL.Query().Get("key")
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			adapter := adapters.NewGoogleGeocodingAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.ReverseGeocode(context.Background(), 40.8299, -73.8559)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GoogleGeocodingAdapter.ReverseGeocode() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GoogleGeocodingAdapter.ReverseGeocode() = %+v, want %+v", got, tt.want)
+			}
+			if gotLatLng != "40.8299,-73.8559" || gotKey != "test-key" {
+				t.Errorf("request latlng = %q, key = %q", gotLatLng, gotKey)
+			}
+		})
+	}
+}
+
+func TestGoogleGeocodingAdapter_ResultTypeFilter(t *testing.T) {
+	body := `{"status": "OK", "results": [
+		{"formatted_address": "Bronx, NY, USA", "types": ["political", "sublocality"], "geometry": {"location_type": "APPROXIMATE"}},
+		{"formatted_address": "2155 Bruckner Blvd, Bronx, NY 10472, USA", "types": ["street_address"], "geometry": {"location_type": "ROOFTOP"}}
+	]}`
+
+	tests := []struct {
+		name              string
+		body              string
+		config            config.MapConfig
+		want              ports.AddressValidationResult
+		wantErr           error
+		wantResultType    string
+		wantLocationTypes string
+	}{
+		{
+			name:   "Test No Filter Returns First Result",
+			body:   body,
+			config: config.MapConfig{GoogleMapsAPIKey: "test-key"},
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "Bronx, NY, USA",
+				LocationType:     ports.LOCATION_TYPE_APPROXIMATE,
+				Confidence:       0.2,
+				Types:            []string{"political", "sublocality"},
+			},
+		},
+		{
+			name:           "Test Street Level Required Skips Locality",
+			body:           body,
+			config:         config.MapConfig{GoogleMapsAPIKey: "test-key", GeocodeResultTypes: []string{"street_address", "premise"}},
+			wantResultType: "street_address|premise",
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+				LocationType:     ports.LOCATION_TYPE_ROOFTOP,
+				Confidence:       1,
+				Types:            []string{"street_address"},
+			},
+		},
+		{
+			name: "Test Locality Only Rejected When Street Level Required",
+			body: `{"status": "OK", "results": [
+				{"formatted_address": "Bronx, NY, USA", "types": ["political", "sublocality"], "geometry": {"location_type": "APPROXIMATE"}}
+			]}`,
+			config:         config.MapConfig{GoogleMapsAPIKey: "test-key", GeocodeResultTypes: []string{"street_address"}},
+			wantResultType: "street_address",
+			want:           ports.AddressValidationResult{Error: "No validation result found."},
+			wantErr:        ports.ErrAddressNotFound,
+		},
+		{
+			name:              "Test Location Type Filter Rejects Approximate",
+			body:              body,
+			config:            config.MapConfig{GoogleMapsAPIKey: "test-key", GeocodeLocationTypes: []string{ports.LOCATION_TYPE_ROOFTOP}},
+			wantLocationTypes: ports.LOCATION_TYPE_ROOFTOP,
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+				LocationType:     ports.LOCATION_TYPE_ROOFTOP,
+				Confidence:       1,
+				Types:            []string{"street_address"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotResultType, gotLocationType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotResultType = r.URL.Query().Get("result_type")
+				gotLocationType = r.URL.Query().Get("location_type")
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			adapter := adapters.NewGoogleGeocodingAdapter(tt.config, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.ReverseGeocode(context.Background(), 40.8299, -73.8559)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GoogleGeocodingAdapter.ReverseGeocode() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GoogleGeocodingAdapter.ReverseGeocode() = %+v, want %+v", got, tt.want)
+			}
+			if gotResultType != tt.wantResultType || gotLocationType != tt.wantLocationTypes {
+				t.Errorf("request result_type = %q, location_type = %q, want %q, %q", gotResultType, gotLocationType, tt.wantResultType, tt.wantLocationTypes)
+			}
+		})
+	}
+}
+
+func TestGoogleGeocodingAdapter_Confidence(t *testing.T) {
+	tests := []struct {
+		name         string
+		locationType string
+		want         float64
+	}{
+		{name: "Test Rooftop Scores Highest", locationType: "ROOFTOP", want: 1},
+		{name: "Test Range Interpolated Scores High", locationType: "RANGE_INTERPOLATED", want: 0.8},
+		{name: "Test Geometric Center Scores Medium", locationType: "GEOMETRIC_CENTER", want: 0.5},
+		{name: "Test Approximate Scores Low", locationType: "APPROXIMATE", want: 0.2},
+		{name: "Test Missing Location Type Has No Score", locationType: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, `{"status": "OK", "results": [
+					{"formatted_address": "2155 Bruckner Blvd, Bronx, NY 10472, USA", "geometry": {"location_type": "`+tt.locationType+`"}}
+				]}`)
+			}))
+			defer server.Close()
+
+			adapter := adapters.NewGoogleGeocodingAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.ReverseGeocode(context.Background(), 40.8299, -73.8559)
+			if err != nil {
+				t.Fatalf("GoogleGeocodingAdapter.ReverseGeocode() error = %v", err)
+			}
+			if got.Confidence != tt.want {
+				t.Errorf("GoogleGeocodingAdapter.ReverseGeocode() Confidence = %v, want %v", got.Confidence, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleGeocodingAdapter_ValidateAddress(t *testing.T) {
+	// Main St in the Bronx first, then one outside the two mile geofence
+	body := `{"status": "OK", "results": [
+		{"formatted_address": "Main St, Bronx, NY 10461, USA", "types": ["route"],
+		 "geometry": {"location": {"lat": 40.8401, "lng": -73.8430}, "location_type": "GEOMETRIC_CENTER"}},
+		{"formatted_address": "Main St, Queens, NY 11355, USA", "types": ["route"],
+		 "geometry": {"location": {"lat": 40.7510, "lng": -73.8290}, "location_type": "GEOMETRIC_CENTER"}}
+	]}`
+	outside := `{"status": "OK", "results": [
+		{"formatted_address": "Main St, Queens, NY 11355, USA", "types": ["route"],
+		 "geometry": {"location": {"lat": 40.7510, "lng": -73.8290}, "location_type": "GEOMETRIC_CENTER"}}
+	]}`
+	geofence := config.MapConfig{
+		GoogleMapsAPIKey: "test-key",
+		MaxDistance:      2,
+		DistanceUnit:     ports.DISTANCE_MILES,
+		CenterLat:        40.8313747,
+		CenterLng:        -73.8272283,
+		Country:          "us",
+	}
+	hardBias := geofence
+	hardBias.GeofenceHardBias = true
+
+	tests := []struct {
+		name       string
+		body       string
+		config     config.MapConfig
+		want       ports.AddressValidationResult
+		wantErr    error
+		wantBounds string
+	}{
+		{
+			name:       "Test Geofence Bounds Are Sent As Bias",
+			body:       body,
+			config:     geofence,
+			wantBounds: "40.802428,-73.865485|40.860321,-73.788972",
+			want: ports.AddressValidationResult{
+				Error:            "Address validation failed based on granularity.",
+				FormattedAddress: "Main St, Bronx, NY 10461, USA",
+				Latitude:         40.8401,
+				Longitude:        -73.8430,
+				LocationType:     ports.LOCATION_TYPE_GEOMETRIC_CENTER,
+				Confidence:       0.5,
+				Types:            []string{"route"},
+			},
+		},
+		{
+			name:       "Test Soft Bias Keeps Result Outside Bounds",
+			body:       outside,
+			config:     geofence,
+			wantBounds: "40.802428,-73.865485|40.860321,-73.788972",
+			want: ports.AddressValidationResult{
+				Error:            "Address validation failed based on granularity.",
+				FormattedAddress: "Main St, Queens, NY 11355, USA",
+				Latitude:         40.7510,
+				Longitude:        -73.8290,
+				LocationType:     ports.LOCATION_TYPE_GEOMETRIC_CENTER,
+				Confidence:       0.5,
+				Types:            []string{"route"},
+			},
+		},
+		{
+			name:       "Test Hard Bias Rejects Result Outside Bounds",
+			body:       outside,
+			config:     hardBias,
+			wantBounds: "40.802428,-73.865485|40.860321,-73.788972",
+			want:       ports.AddressValidationResult{Error: "No validation result found."},
+			wantErr:    ports.ErrAddressNotFound,
+		},
+		{
+			name: "Test Street Address Is Valid",
+			body: `{"status": "OK", "results": [
+				{"formatted_address": "2155 Bruckner Blvd, Bronx, NY 10472, USA", "types": ["street_address"],
+				 "geometry": {"location": {"lat": 40.8299, "lng": -73.8559}, "location_type": "ROOFTOP"}}
+			]}`,
+			config:     geofence,
+			wantBounds: "40.802428,-73.865485|40.860321,-73.788972",
+			want: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+				Latitude:         40.8299,
+				Longitude:        -73.8559,
+				LocationType:     ports.LOCATION_TYPE_ROOFTOP,
+				Confidence:       1,
+				Types:            []string{"street_address"},
+			},
+		},
+		{
+			name: "Test Locality Only Is Invalid",
+			body: `{"status": "OK", "results": [
+				{"formatted_address": "Bronx, NY, USA", "types": ["political", "sublocality"],
+				 "geometry": {"location": {"lat": 40.8448, "lng": -73.8648}, "location_type": "APPROXIMATE"}}
+			]}`,
+			config:     geofence,
+			wantBounds: "40.802428,-73.865485|40.860321,-73.788972",
+			want: ports.AddressValidationResult{
+				Error:            "Address validation failed based on granularity.",
+				FormattedAddress: "Bronx, NY, USA",
+				Latitude:         40.8448,
+				Longitude:        -73.8648,
+				LocationType:     ports.LOCATION_TYPE_APPROXIMATE,
+				Confidence:       0.2,
+				Types:            []string{"political", "sublocality"},
+			},
+		},
+		{
+			name:   "Test No Geofence Sends No Bounds",
+			body:   body,
+			config: config.MapConfig{GoogleMapsAPIKey: "test-key", Country: "us"},
+			want: ports.AddressValidationResult{
+				Error:            "Address validation failed based on granularity.",
+				FormattedAddress: "Main St, Bronx, NY 10461, USA",
+				Latitude:         40.8401,
+				Longitude:        -73.8430,
+				LocationType:     ports.LOCATION_TYPE_GEOMETRIC_CENTER,
+				Confidence:       0.5,
+				Types:            []string{"route"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery map[string][]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotQuery = r.URL.Query()
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			adapter := adapters.NewGoogleGeocodingAdapter(tt.config, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.ValidateAddress(context.Background(), "Main St")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GoogleGeocodingAdapter.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GoogleGeocodingAdapter.ValidateAddress() = %+v, want %+v", got, tt.want)
+			}
+
+			wantQuery := map[string][]string{
+				"address": {"Main St"},
+				"key":     {"test-key"},
+				"region":  {"us"},
+			}
+			if tt.wantBounds != "" {
+				wantQuery["bounds"] = []string{tt.wantBounds}
+			}
+			if !reflect.DeepEqual(gotQuery, wantQuery) {
+				t.Errorf("request query = %v, want %v", gotQuery, wantQuery)
+			}
+		})
+	}
+}
+
+func TestGoogleGeocodingAdapter_TransportErrorRedactsKey(t *testing.T) {
+	// A closed server fails the call before any response, like a provider outage
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	adapter := adapters.NewGoogleGeocodingAdapter(config.MapConfig{GoogleMapsAPIKey: "secret-key"}, server.Client(), server.URL, zap.NewNop())
+	got, err := adapter.ValidateAddress(context.Background(), "2155 Bruckner Blvd")
+	if err == nil {
+		t.Fatal("GoogleGeocodingAdapter.ValidateAddress() error = nil, want a transport error")
+	}
+	if strings.Contains(err.Error(), "secret-key") {
+		t.Errorf("GoogleGeocodingAdapter.ValidateAddress() error = %q, leaks the API key", err)
+	}
+	if got.Error != "Failed to validate address." {
+		t.Errorf("GoogleGeocodingAdapter.ValidateAddress() Error = %q, want %q", got.Error, "Failed to validate address.")
+	}
+}
+
+func TestGoogleGeocodingAdapter_ReloadGeofence(t *testing.T) {
+	var gotBounds string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBounds = r.URL.Query().Get("bounds")
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status": "ZERO_RESULTS", "results": []}`)
+	}))
+	defer server.Close()
+
+	geofence := config.MapConfig{
+		GoogleMapsAPIKey: "test-key",
+		MaxDistance:      2,
+		DistanceUnit:     ports.DISTANCE_MILES,
+		CenterLat:        40.8313747,
+		CenterLng:        -73.8272283,
+	}
+	adapter := adapters.NewGoogleGeocodingAdapter(geofence, server.Client(), server.URL, zap.NewNop())
+
+	// The geofence moves to Manhattan, requests are biased towards it from then on
+	reloaded := geofence
+	reloaded.CenterLat = 40.7580
+	reloaded.CenterLng = -73.9855
+	adapter.ReloadGeofence(reloaded)
+
+	adapter.ValidateAddress(context.Background(), "Main St")
+	if want := "40.729054,-74.023714|40.786946,-73.947286"; gotBounds != want {
+		t.Errorf("request bounds after reload = %q, want %q", gotBounds, want)
+	}
+}
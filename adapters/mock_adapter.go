@@ -0,0 +1,122 @@
+package adapters
+
+import (
+	"address-validator/geo"
+	"address-validator/ports"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ErrNoMockFixture is returned when no fixture matches the address
+var ErrNoMockFixture = fmt.Errorf("no mock fixture matches address: %w", ports.ErrAddressNotFound)
+
+// MockFixture maps an address substring to a canned validation result
+type MockFixture struct {
+	Match  string                        `json:"match"`
+	Result ports.AddressValidationResult `json:"result"`
+}
+
+// MockValidator returns canned results for offline development and integration tests
+type MockValidator struct {
+	fixtures []MockFixture
+	logger   *zap.Logger
+}
+
+// NewMockValidator creates a mock validator from the given fixtures.
+// Fixtures are evaluated in order and the first case-insensitive substring match wins.
+func NewMockValidator(fixtures []MockFixture, logger *zap.Logger) *MockValidator {
+	return &MockValidator{
+		fixtures: fixtures,
+		logger:   logger,
+	}
+}
+
+// LoadMockFixtures reads a JSON array of fixtures from path
+func LoadMockFixtures(path string) ([]MockFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock fixtures: %w", err)
+	}
+
+	var fixtures []MockFixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse mock fixtures: %w", err)
+	}
+
+	return fixtures, nil
+}
+
+// ValidateAddress returns the result of the first fixture whose match is contained in the address,
+// with the provider error of its error code if it has one
+func (m *MockValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return ports.AddressValidationResult{IsValid: false}, err
+	}
+
+	normalized := strings.ToLower(address)
+	for _, fixture := range m.fixtures {
+		if strings.Contains(normalized, strings.ToLower(fixture.Match)) {
+			m.logger.Debug("mock fixture matched", zap.String("match", fixture.Match))
+			return fixture.Result, mockProviderErrors[fixture.Result.ErrorCode]
+		}
+	}
+
+	m.logger.Warn("no mock fixture matched address")
+	return ports.AddressValidationResult{
+		IsValid: false,
+		Error:   "No validation result found.",
+	}, ErrNoMockFixture
+}
+
+// mockProviderErrors are returned with fixtures carrying a provider error code, to simulate an outage
+var mockProviderErrors = map[string]error{
+	ports.ERROR_CODE_UPSTREAM_UNAVAILABLE: ports.ErrUpstreamUnavailable,
+	ports.ERROR_CODE_PROVIDER_QUOTA:       ports.ErrProviderQuota,
+	ports.ERROR_CODE_PROVIDER_DENIED:      ports.ErrProviderDenied,
+	ports.ERROR_CODE_PROVIDER_TIMEOUT:     ports.ErrProviderTimeout,
+}
+
+// Provider reports the provider answering the calls
+func (m *MockValidator) Provider() string {
+	return ports.PROVIDER_MOCK
+}
+
+// mockReverseRadiusMeters is how close coordinates must be to a fixture to match it
+const mockReverseRadiusMeters = 100
+
+// ReverseGeocode returns the valid fixture nearest to the coordinates, within mockReverseRadiusMeters
+func (m *MockValidator) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return ports.AddressValidationResult{IsValid: false}, err
+	}
+
+	var nearest *MockFixture
+	nearestDistance := math.Inf(1)
+	for i, fixture := range m.fixtures {
+		if !fixture.Result.IsValid {
+			continue
+		}
+		distance := geo.HaversineMeters(latitude, longitude, fixture.Result.Latitude, fixture.Result.Longitude)
+		if distance <= mockReverseRadiusMeters && distance < nearestDistance {
+			nearest = &m.fixtures[i]
+			nearestDistance = distance
+		}
+	}
+
+	if nearest == nil {
+		m.logger.Warn("no mock fixture near coordinates")
+		return ports.AddressValidationResult{
+			IsValid: false,
+			Error:   "No validation result found.",
+		}, ErrNoMockFixture
+	}
+
+	m.logger.Debug("mock fixture matched coordinates", zap.String("match", nearest.Match))
+	return nearest.Result, nil
+}
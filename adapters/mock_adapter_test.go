@@ -0,0 +1,159 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestMockValidator_ValidateAddress(t *testing.T) {
+	fixtures := []adapters.MockFixture{
+		{
+			Match: "123 Main St, Bronx",
+			Result: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+				Latitude:         40.8448,
+				Longitude:        -73.8648,
+			},
+		},
+		{
+			Match: "Main St",
+			Result: ports.AddressValidationResult{
+				IsValid: false,
+				Error:   "Address is incomplete.",
+			},
+		},
+		{
+			Match: "Outage Ave",
+			Result: ports.AddressValidationResult{
+				Error:     "Failed to validate address.",
+				ErrorCode: ports.ERROR_CODE_PROVIDER_TIMEOUT,
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		want    ports.AddressValidationResult
+		wantErr error
+	}{
+		{
+			name:    "Test Exact Fixture Returns Canned Result",
+			address: "123 Main St, Bronx, NY",
+			want:    fixtures[0].Result,
+		},
+		{
+			name:    "Test Match Is Case Insensitive",
+			address: "123 MAIN ST, BRONX, NY",
+			want:    fixtures[0].Result,
+		},
+		{
+			name:    "Test First Matching Fixture Wins",
+			address: "456 Main St, Manhattan, NY",
+			want:    fixtures[1].Result,
+		},
+		{
+			name:    "Test Provider Error Code Returns Provider Error",
+			address: "1 Outage Ave",
+			want:    fixtures[2].Result,
+			wantErr: ports.ErrProviderTimeout,
+		},
+		{
+			name:    "Test Unmatched Address Returns Error",
+			address: "1 Nowhere Rd",
+			want: ports.AddressValidationResult{
+				IsValid: false,
+				Error:   "No validation result found.",
+			},
+			wantErr: adapters.ErrNoMockFixture,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := adapters.NewMockValidator(fixtures, zap.NewNop())
+			got, err := m.ValidateAddress(context.Background(), tt.address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("MockValidator.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MockValidator.ValidateAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMockFixtures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	data := `[{"match": "Bronx", "result": {"isValid": true, "formattedAddress": "Bronx, NY, USA"}}]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := adapters.LoadMockFixtures(path)
+	if err != nil {
+		t.Fatalf("LoadMockFixtures() error = %v", err)
+	}
+
+	want := []adapters.MockFixture{
+		{Match: "Bronx", Result: ports.AddressValidationResult{IsValid: true, FormattedAddress: "Bronx, NY, USA"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadMockFixtures() = %v, want %v", got, want)
+	}
+}
+
+func TestMockValidator_ReverseGeocode(t *testing.T) {
+	bronx := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+		Latitude:         40.8448,
+		Longitude:        -73.8648,
+	}
+	fixtures := []adapters.MockFixture{
+		{Match: "Invalid", Result: ports.AddressValidationResult{IsValid: false}},
+		{Match: "123 Main St, Bronx", Result: bronx},
+	}
+
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		want      ports.AddressValidationResult
+		wantErr   error
+	}{
+		{
+			name:      "Test Nearby Coordinates Return Fixture",
+			latitude:  40.8450,
+			longitude: -73.8650,
+			want:      bronx,
+		},
+		{
+			name:      "Test Distant Coordinates Return Error",
+			latitude:  40.7128,
+			longitude: -74.0060,
+			want:      ports.AddressValidationResult{IsValid: false, Error: "No validation result found."},
+			wantErr:   adapters.ErrNoMockFixture,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := adapters.NewMockValidator(fixtures, zap.NewNop())
+			got, err := m.ReverseGeocode(context.Background(), tt.latitude, tt.longitude)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("MockValidator.ReverseGeocode() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MockValidator.ReverseGeocode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
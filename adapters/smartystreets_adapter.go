@@ -0,0 +1,252 @@
+package adapters
+
+import (
+	"address-validator/config"
+	"address-validator/logging"
+	"address-validator/ports"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SMARTYSTREETS_US_STREET_ENDPOINT is the SmartyStreets US Street API endpoint
+const SMARTYSTREETS_US_STREET_ENDPOINT = "https://us-street.api.smarty.com/street-address"
+
+// SmartyStreetsAdapter verifies US addresses with the SmartyStreets US Street API
+type SmartyStreetsAdapter struct {
+	client   *http.Client
+	endpoint string
+	logger   *zap.Logger
+	config   config.MapConfig
+}
+
+// NewSmartyStreetsAdapter creates a validator calling endpoint with client,
+// authenticated with config.SmartyAuthID and config.SmartyAuthToken
+func NewSmartyStreetsAdapter(config config.MapConfig, client *http.Client, endpoint string, logger *zap.Logger) *SmartyStreetsAdapter {
+	return &SmartyStreetsAdapter{
+		client:   client,
+		endpoint: endpoint,
+		logger:   logger,
+		config:   config,
+	}
+}
+
+// smartyCandidate is the subset of a US Street API candidate used here
+type smartyCandidate struct {
+	DeliveryLine1 string `json:"delivery_line_1"`
+	DeliveryLine2 string `json:"delivery_line_2"`
+	LastLine      string `json:"last_line"`
+	Components    struct {
+		PrimaryNumber       string `json:"primary_number"`
+		StreetPredirection  string `json:"street_predirection"`
+		StreetName          string `json:"street_name"`
+		StreetSuffix        string `json:"street_suffix"`
+		StreetPostdirection string `json:"street_postdirection"`
+		SecondaryDesignator string `json:"secondary_designator"`
+		SecondaryNumber     string `json:"secondary_number"`
+		CityName            string `json:"city_name"`
+		StateAbbreviation   string `json:"state_abbreviation"`
+		Zipcode             string `json:"zipcode"`
+		Plus4Code           string `json:"plus4_code"`
+	} `json:"components"`
+	Metadata struct {
+		RecordType   string  `json:"record_type"`
+		CarrierRoute string  `json:"carrier_route"`
+		RDI          string  `json:"rdi"`
+		Latitude     float64 `json:"latitude"`
+		Longitude    float64 `json:"longitude"`
+		Precision    string  `json:"precision"`
+	} `json:"metadata"`
+	Analysis struct {
+		DPVMatchCode string `json:"dpv_match_code"`
+	} `json:"analysis"`
+}
+
+// ValidateAddress verifies a freeform US address, valid when USPS DPV confirms the building
+func (ssa *SmartyStreetsAdapter) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	result := ports.AddressValidationResult{
+		IsValid: false,
+	}
+
+	query := url.Values{}
+	query.Set("auth-id", ssa.config.SmartyAuthID)
+	query.Set("auth-token", ssa.config.SmartyAuthToken)
+	query.Set("street", address)
+	query.Set("candidates", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ssa.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return result, fmt.Errorf("address validation error: %w", err)
+	}
+
+	logging.FromContext(ctx, ssa.logger).Debug("calling SmartyStreets US Street API")
+	resp, err := ssa.client.Do(req)
+	if err != nil {
+		err = redactURL(err)
+		ssa.logger.Error("address validation error", zap.Error(err))
+		result.Error = "Failed to validate address."
+		if isProviderTimeout(ctx, err) {
+			result.ErrorCode = ports.ERROR_CODE_PROVIDER_TIMEOUT
+			return result, fmt.Errorf("address validation error: %w: %w", ports.ErrProviderTimeout, err)
+		}
+		return result, fmt.Errorf("address validation error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// SmartyStreets reports failures with the status code alone
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusTooManyRequests:
+		result.Error = "Failed to validate address: " + resp.Status
+		result.ErrorCode = ports.ERROR_CODE_PROVIDER_QUOTA
+		return result, fmt.Errorf("address validation error: %w: %s", ports.ErrProviderQuota, resp.Status)
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusPaymentRequired, resp.StatusCode == http.StatusForbidden:
+		result.Error = "Failed to validate address: " + resp.Status
+		result.ErrorCode = ports.ERROR_CODE_PROVIDER_DENIED
+		return result, fmt.Errorf("address validation error: %w: %s", ports.ErrProviderDenied, resp.Status)
+	default:
+		result.Error = "Failed to validate address: " + resp.Status
+		return result, fmt.Errorf("address validation error: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Error = "Failed to validate address."
+		return result, fmt.Errorf("address validation error: %w", err)
+	}
+	// The credentials are only in the request URL, so the body is safe to hand to a debugging caller
+	ports.RawCaptureFrom(ctx).Set(data)
+
+	var candidates []smartyCandidate
+	if err := json.Unmarshal(data, &candidates); err != nil {
+		result.Error = "Failed to validate address."
+		return result, fmt.Errorf("address validation error: invalid response: %w", err)
+	}
+	// An address SmartyStreets cannot match at all yields no candidate
+	if len(candidates) == 0 {
+		ssa.logger.Warn("no validation result found for address")
+		result.Error = "No validation result found."
+		return result, ports.ErrAddressNotFound
+	}
+	candidate := candidates[0]
+
+	lines := []string{candidate.DeliveryLine1, candidate.DeliveryLine2, candidate.LastLine}
+	result.FormattedAddress = strings.Join(nonEmpty(lines), ", ")
+	result.RegionCode = "US"
+	result.CountryCode = result.RegionCode
+	result.AdminAreaCode = adminAreaCode(result.CountryCode, candidate.Components.StateAbbreviation)
+	result.Latitude = candidate.Metadata.Latitude
+	result.Longitude = candidate.Metadata.Longitude
+	result.LocationType = smartyLocationType(candidate.Metadata.Precision)
+	result.Confidence = locationTypeConfidence[result.LocationType]
+	result.StreetLevel = candidate.Components.PrimaryNumber != "" || candidate.Components.StreetName != ""
+	result.Components = smartyComponents(candidate)
+	result.AddressType = smartyAddressType(candidate.Metadata.RecordType, candidate.Metadata.RDI)
+	result.USPS = &ports.USPSData{
+		StandardizedAddress: result.FormattedAddress,
+		DPVConfirmation:     candidate.Analysis.DPVMatchCode,
+		CarrierRoute:        candidate.Metadata.CarrierRoute,
+	}
+
+	// Y confirms the delivery point, S and D confirm the building but not the unit
+	yes, no := true, false
+	switch candidate.Analysis.DPVMatchCode {
+	case "Y":
+		result.IsValid = true
+		result.Deliverable = &yes
+	case "S":
+		result.IsValid = true
+		result.Deliverable = &no
+		result.Suggestions = []string{"Confirm unit number"}
+	case "D":
+		result.IsValid = true
+		result.Deliverable = &no
+		result.Suggestions = []string{"Add a unit number"}
+	case "N":
+		result.Deliverable = &no
+		result.Error = "Address is not a USPS delivery point."
+	default:
+		result.Error = "Address could not be verified."
+	}
+
+	return result, nil
+}
+
+// nonEmpty returns the non-empty strings of values
+func nonEmpty(values []string) []string {
+	var kept []string
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
+
+// smartyLocationType maps the precision of a SmartyStreets geocode to the Geocoding API location type scale
+func smartyLocationType(precision string) string {
+	switch precision {
+	case "Rooftop", "Parcel", "Structure":
+		return ports.LOCATION_TYPE_ROOFTOP
+	case "Zip9", "Zip8", "Zip7":
+		return ports.LOCATION_TYPE_RANGE_INTERPOLATED
+	case "Zip6", "Zip5":
+		return ports.LOCATION_TYPE_GEOMETRIC_CENTER
+	case "", "Unknown", "None":
+		return ""
+	default:
+		return ports.LOCATION_TYPE_APPROXIMATE
+	}
+}
+
+// smartyAddressType classifies the address from the USPS record type, P for a PO box and F for a firm,
+// falling back to the residential delivery indicator
+func smartyAddressType(recordType string, rdi string) string {
+	switch {
+	case recordType == "P":
+		return ports.ADDRESS_TYPE_PO_BOX
+	case recordType == "F", rdi == "Commercial":
+		return ports.ADDRESS_TYPE_COMMERCIAL
+	case rdi == "Residential":
+		return ports.ADDRESS_TYPE_RESIDENTIAL
+	default:
+		return ""
+	}
+}
+
+// smartyComponents maps the candidate components to the Google component types, in address order
+func smartyComponents(candidate smartyCandidate) []ports.AddressComponent {
+	parts := candidate.Components
+	route := nonEmpty([]string{parts.StreetPredirection, parts.StreetName, parts.StreetSuffix, parts.StreetPostdirection})
+	unit := nonEmpty([]string{parts.SecondaryDesignator, parts.SecondaryNumber})
+	postalCode := parts.Zipcode
+	if postalCode != "" && parts.Plus4Code != "" {
+		postalCode += "-" + parts.Plus4Code
+	}
+
+	var components []ports.AddressComponent
+	for _, component := range []ports.AddressComponent{
+		{Type: "street_number", Text: parts.PrimaryNumber},
+		{Type: "route", Text: strings.Join(route, " ")},
+		{Type: "subpremise", Text: strings.Join(unit, " ")},
+		{Type: "locality", Text: parts.CityName},
+		{Type: "administrative_area_level_1", Text: parts.StateAbbreviation},
+		{Type: "postal_code", Text: postalCode},
+	} {
+		if component.Text != "" {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// Provider reports the provider answering the calls
+func (ssa *SmartyStreetsAdapter) Provider() string {
+	return ports.PROVIDER_SMARTY
+}
@@ -0,0 +1,195 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// smartyCandidate is a canned US Street API candidate for the DPV match code
+func smartyCandidate(dpvMatchCode string) string {
+	return `[{
+		"input_index": 0,
+		"candidate_index": 0,
+		"delivery_line_1": "1600 Grand Concourse Apt 4B",
+		"last_line": "Bronx NY 10457-7406",
+		"components": {
+			"primary_number": "1600",
+			"street_name": "Grand",
+			"street_suffix": "Concourse",
+			"secondary_designator": "Apt",
+			"secondary_number": "4B",
+			"city_name": "Bronx",
+			"state_abbreviation": "NY",
+			"zipcode": "10457",
+			"plus4_code": "7406"
+		},
+		"metadata": {
+			"record_type": "H",
+			"carrier_route": "C012",
+			"rdi": "Residential",
+			"latitude": 40.8399,
+			"longitude": -73.9115,
+			"precision": "Zip9"
+		},
+		"analysis": {"dpv_match_code": "` + dpvMatchCode + `", "dpv_footnotes": "AABB"}
+	}]`
+}
+
+func TestSmartyStreetsAdapter_ValidateAddress(t *testing.T) {
+	yes, no := true, false
+	components := []ports.AddressComponent{
+		{Type: "street_number", Text: "1600"},
+		{Type: "route", Text: "Grand Concourse"},
+		{Type: "subpremise", Text: "Apt 4B"},
+		{Type: "locality", Text: "Bronx"},
+		{Type: "administrative_area_level_1", Text: "NY"},
+		{Type: "postal_code", Text: "10457-7406"},
+	}
+	verified := func(dpvMatchCode string) ports.AddressValidationResult {
+		return ports.AddressValidationResult{
+			FormattedAddress: "1600 Grand Concourse Apt 4B, Bronx NY 10457-7406",
+			RegionCode:       "US",
+			CountryCode:      "US",
+			AdminAreaCode:    "US-NY",
+			Latitude:         40.8399,
+			Longitude:        -73.9115,
+			LocationType:     ports.LOCATION_TYPE_RANGE_INTERPOLATED,
+			Confidence:       0.8,
+			StreetLevel:      true,
+			AddressType:      ports.ADDRESS_TYPE_RESIDENTIAL,
+			Components:       components,
+			USPS: &ports.USPSData{
+				StandardizedAddress: "1600 Grand Concourse Apt 4B, Bronx NY 10457-7406",
+				DPVConfirmation:     dpvMatchCode,
+				CarrierRoute:        "C012",
+			},
+		}
+	}
+
+	confirmed := verified("Y")
+	confirmed.IsValid = true
+	confirmed.Deliverable = &yes
+
+	missingUnit := verified("D")
+	missingUnit.IsValid = true
+	missingUnit.Deliverable = &no
+	missingUnit.Suggestions = []string{"Add a unit number"}
+
+	notDeliverable := verified("N")
+	notDeliverable.Deliverable = &no
+	notDeliverable.Error = "Address is not a USPS delivery point."
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    ports.AddressValidationResult
+		wantErr error
+	}{
+		{
+			name:   "Test Confirmed Delivery Point Is Valid And Deliverable",
+			status: http.StatusOK,
+			body:   smartyCandidate("Y"),
+			want:   confirmed,
+		},
+		{
+			name:   "Test Missing Unit Is Valid But Not Deliverable",
+			status: http.StatusOK,
+			body:   smartyCandidate("D"),
+			want:   missingUnit,
+		},
+		{
+			name:   "Test Unconfirmed Address Is Invalid",
+			status: http.StatusOK,
+			body:   smartyCandidate("N"),
+			want:   notDeliverable,
+		},
+		{
+			name:    "Test No Candidate Returns Not Found",
+			status:  http.StatusOK,
+			body:    `[]`,
+			want:    ports.AddressValidationResult{Error: "No validation result found."},
+			wantErr: ports.ErrAddressNotFound,
+		},
+		{
+			name:   "Test Unauthorized Returns Provider Denied",
+			status: http.StatusUnauthorized,
+			want: ports.AddressValidationResult{
+				Error:     "Failed to validate address: 401 Unauthorized",
+				ErrorCode: ports.ERROR_CODE_PROVIDER_DENIED,
+			},
+			wantErr: ports.ErrProviderDenied,
+		},
+		{
+			name:   "Test Too Many Requests Returns Provider Quota",
+			status: http.StatusTooManyRequests,
+			want: ports.AddressValidationResult{
+				Error:     "Failed to validate address: 429 Too Many Requests",
+				ErrorCode: ports.ERROR_CODE_PROVIDER_QUOTA,
+			},
+			wantErr: ports.ErrProviderQuota,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery map[string][]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotQuery = r.URL.Query()
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			mapConfig := config.MapConfig{SmartyAuthID: "test-id", SmartyAuthToken: "test-token"}
+			adapter := adapters.NewSmartyStreetsAdapter(mapConfig, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse Apt 4B, Bronx, NY")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SmartyStreetsAdapter.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SmartyStreetsAdapter.ValidateAddress() = %+v, want %+v", got, tt.want)
+			}
+
+			wantQuery := map[string][]string{
+				"auth-id":    {"test-id"},
+				"auth-token": {"test-token"},
+				"street":     {"1600 Grand Concourse Apt 4B, Bronx, NY"},
+				"candidates": {"1"},
+			}
+			if !reflect.DeepEqual(gotQuery, wantQuery) {
+				t.Errorf("request query = %v, want %v", gotQuery, wantQuery)
+			}
+		})
+	}
+}
+
+func TestSmartyStreetsAdapter_TransportErrorRedactsCredentials(t *testing.T) {
+	// A closed server fails the call before any response, like a provider outage
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	mapConfig := config.MapConfig{SmartyAuthID: "secret-id", SmartyAuthToken: "secret-token"}
+	adapter := adapters.NewSmartyStreetsAdapter(mapConfig, server.Client(), server.URL, zap.NewNop())
+	got, err := adapter.ValidateAddress(context.Background(), "1600 Grand Concourse Apt 4B, Bronx, NY")
+	if err == nil {
+		t.Fatal("SmartyStreetsAdapter.ValidateAddress() error = nil, want a transport error")
+	}
+	if strings.Contains(err.Error(), "secret-id") || strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("SmartyStreetsAdapter.ValidateAddress() error = %q, leaks the credentials", err)
+	}
+	if got.Error != "Failed to validate address." {
+		t.Errorf("SmartyStreetsAdapter.ValidateAddress() Error = %q, want %q", got.Error, "Failed to validate address.")
+	}
+}
@@ -0,0 +1,151 @@
+package adapters
+
+import (
+	"address-validator/config"
+	"address-validator/logging"
+	"address-validator/ports"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// GOOGLE_TIME_ZONE_ENDPOINT is the Google Time Zone API JSON endpoint
+const GOOGLE_TIME_ZONE_ENDPOINT = "https://maps.googleapis.com/maps/api/timezone/json"
+
+// GoogleTimeZoneAdapter looks up time zones with the Google Time Zone API
+type GoogleTimeZoneAdapter struct {
+	client   *http.Client
+	endpoint string
+	logger   *zap.Logger
+	config   config.MapConfig
+}
+
+// NewGoogleTimeZoneAdapter creates a time zone provider calling endpoint with client
+func NewGoogleTimeZoneAdapter(config config.MapConfig, client *http.Client, endpoint string, logger *zap.Logger) *GoogleTimeZoneAdapter {
+	return &GoogleTimeZoneAdapter{
+		client:   client,
+		endpoint: endpoint,
+		logger:   logger,
+		config:   config,
+	}
+}
+
+// timeZoneResponse is the subset of the Time Zone API response used here
+type timeZoneResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"errorMessage"`
+	TimeZoneID   string `json:"timeZoneId"`
+}
+
+// TimeZone returns the IANA time zone of a point, e.g. America/New_York
+func (gta *GoogleTimeZoneAdapter) TimeZone(ctx context.Context, latitude float64, longitude float64) (string, error) {
+	query := url.Values{}
+	query.Set("location", strconv.FormatFloat(latitude, 'f', -1, 64)+","+strconv.FormatFloat(longitude, 'f', -1, 64))
+	// The zone of a point does not depend on the date, the timestamp only decides the reported offsets
+	query.Set("timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	query.Set("key", gta.config.GoogleMapsAPIKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gta.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("time zone error: %w", err)
+	}
+
+	logging.FromContext(ctx, gta.logger).Debug("calling Google Time Zone API", zap.Float64("latitude", latitude), zap.Float64("longitude", longitude))
+	resp, err := gta.client.Do(req)
+	if err != nil {
+		err = redactURL(err)
+		gta.logger.Error("time zone error", zap.Error(err))
+		return "", fmt.Errorf("time zone error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("time zone error: %w", err)
+	}
+
+	var body timeZoneResponse
+	if err := json.Unmarshal(data, &body); err != nil {
+		return "", fmt.Errorf("time zone error: invalid response: %w", err)
+	}
+
+	switch body.Status {
+	case "OK":
+	case "OVER_QUERY_LIMIT", "OVER_DAILY_LIMIT":
+		return "", fmt.Errorf("time zone error: %w: %s", ports.ErrProviderQuota, body.ErrorMessage)
+	case "REQUEST_DENIED":
+		return "", fmt.Errorf("time zone error: %w: %s", ports.ErrProviderDenied, body.ErrorMessage)
+	default:
+		// ZERO_RESULTS is returned for points without a time zone, e.g. at sea
+		return "", fmt.Errorf("time zone error: %s %s", body.Status, body.ErrorMessage)
+	}
+	if body.TimeZoneID == "" {
+		return "", fmt.Errorf("time zone error: empty response")
+	}
+	return body.TimeZoneID, nil
+}
+
+// TIME_ZONE_CACHE_PRECISION is the number of decimals coordinates are rounded to for the
+// time zone cache, about a kilometer, so nearby addresses share one lookup
+const TIME_ZONE_CACHE_PRECISION = 2
+
+// TIME_ZONE_CACHE_MAX_ENTRIES bounds the time zone cache, points past it are looked up every time
+const TIME_ZONE_CACHE_MAX_ENTRIES = 10000
+
+// CachingTimeZoneProvider caches the time zones of the wrapped provider by rounded coordinates.
+// Time zone boundaries practically never move, so entries do not expire.
+type CachingTimeZoneProvider struct {
+	next    ports.TimeZoneProvider
+	zones   map[[2]float64]string
+	mu      sync.RWMutex
+	maxSize int
+}
+
+// NewCachingTimeZoneProvider wraps a time zone provider with an in-memory cache
+func NewCachingTimeZoneProvider(next ports.TimeZoneProvider) *CachingTimeZoneProvider {
+	return &CachingTimeZoneProvider{
+		next:    next,
+		zones:   make(map[[2]float64]string),
+		maxSize: TIME_ZONE_CACHE_MAX_ENTRIES,
+	}
+}
+
+// TimeZone returns the cached time zone of the rounded point, otherwise delegates to the wrapped provider
+func (ctp *CachingTimeZoneProvider) TimeZone(ctx context.Context, latitude float64, longitude float64) (string, error) {
+	key := [2]float64{roundTo(latitude, TIME_ZONE_CACHE_PRECISION), roundTo(longitude, TIME_ZONE_CACHE_PRECISION)}
+
+	ctp.mu.RLock()
+	zone, ok := ctp.zones[key]
+	ctp.mu.RUnlock()
+	if ok {
+		return zone, nil
+	}
+
+	zone, err := ctp.next.TimeZone(ctx, latitude, longitude)
+	if err != nil {
+		return zone, err
+	}
+
+	ctp.mu.Lock()
+	if len(ctp.zones) < ctp.maxSize {
+		ctp.zones[key] = zone
+	}
+	ctp.mu.Unlock()
+
+	return zone, nil
+}
+
+// roundTo rounds value to the given number of decimals
+func roundTo(value float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,138 @@
+package adapters_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/ports"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestGoogleTimeZoneAdapter_TimeZone(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "Test OK Returns Time Zone ID",
+			body: `{"status": "OK", "timeZoneId": "America/New_York", "timeZoneName": "Eastern Daylight Time", "rawOffset": -18000, "dstOffset": 3600}`,
+			want: "America/New_York",
+		},
+		{
+			name:    "Test Over Query Limit Returns Provider Quota",
+			body:    `{"status": "OVER_QUERY_LIMIT", "errorMessage": "You have exceeded your rate-limit"}`,
+			wantErr: ports.ErrProviderQuota,
+		},
+		{
+			name:    "Test Request Denied Returns Provider Denied",
+			body:    `{"status": "REQUEST_DENIED", "errorMessage": "The provided API key is invalid."}`,
+			wantErr: ports.ErrProviderDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLocation, gotKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotLocation = r.URL.Query().Get("location")
+				gotKey = r.URL.Query().Get("key")
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			adapter := adapters.NewGoogleTimeZoneAdapter(config.MapConfig{GoogleMapsAPIKey: "test-key"}, server.Client(), server.URL, zap.NewNop())
+			got, err := adapter.TimeZone(context.Background(), 40.8399, -73.9115)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GoogleTimeZoneAdapter.TimeZone() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GoogleTimeZoneAdapter.TimeZone() = %q, want %q", got, tt.want)
+			}
+			if gotLocation != "40.8399,-73.9115" || gotKey != "test-key" {
+				t.Errorf("request location = %q, key = %q", gotLocation, gotKey)
+			}
+		})
+	}
+}
+
+// countingTimeZone counts the lookups that reach the wrapped provider
+type countingTimeZone struct {
+	calls int
+	err   error
+}
+
+func (c *countingTimeZone) TimeZone(ctx context.Context, latitude float64, longitude float64) (string, error) {
+	c.calls++
+	if c.err != nil {
+		return "", c.err
+	}
+	if longitude < -100 {
+		return "America/Los_Angeles", nil
+	}
+	return "America/New_York", nil
+}
+
+func TestCachingTimeZoneProvider_TimeZone(t *testing.T) {
+	type point struct{ lat, lng float64 }
+
+	tests := []struct {
+		name      string
+		points    []point
+		want      []string
+		wantCalls int
+	}{
+		{
+			name:      "Test Nearby Points Share One Lookup",
+			points:    []point{{40.8399, -73.9115}, {40.8401, -73.9112}, {40.8399, -73.9115}},
+			want:      []string{"America/New_York", "America/New_York", "America/New_York"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Test Distant Points Are Looked Up Apart",
+			points:    []point{{40.8399, -73.9115}, {40.86, -73.9115}, {34.0522, -118.2437}},
+			want:      []string{"America/New_York", "America/New_York", "America/Los_Angeles"},
+			wantCalls: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := &countingTimeZone{}
+			provider := adapters.NewCachingTimeZoneProvider(upstream)
+
+			for i, p := range tt.points {
+				got, err := provider.TimeZone(context.Background(), p.lat, p.lng)
+				if err != nil {
+					t.Fatalf("CachingTimeZoneProvider.TimeZone() error = %v", err)
+				}
+				if got != tt.want[i] {
+					t.Errorf("CachingTimeZoneProvider.TimeZone(%v, %v) = %q, want %q", p.lat, p.lng, got, tt.want[i])
+				}
+			}
+			if upstream.calls != tt.wantCalls {
+				t.Errorf("upstream calls = %d, want %d", upstream.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachingTimeZoneProvider_TimeZone_ErrorNotCached(t *testing.T) {
+	upstream := &countingTimeZone{err: errors.New("time zone error: UNKNOWN_ERROR")}
+	provider := adapters.NewCachingTimeZoneProvider(upstream)
+
+	for range 2 {
+		if _, err := provider.TimeZone(context.Background(), 40.8399, -73.9115); err == nil {
+			t.Fatal("CachingTimeZoneProvider.TimeZone() error = nil, want the upstream error")
+		}
+	}
+	if upstream.calls != 2 {
+		t.Errorf("upstream calls = %d, want 2", upstream.calls)
+	}
+}
@@ -0,0 +1,430 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"address-validator/adapters"
+	"address-validator/audit"
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+
+	"go.uber.org/zap"
+)
+
+// App is the fully wired address validator, for running the server or embedding in-process
+type App struct {
+	logger      *zap.Logger
+	infra       config.InfraConfig
+	service     *services.AddressService
+	serviceArea *handlers.ServiceAreaHandler
+	readiness   *handlers.ReadinessHandler
+	// warmup is the adapter chain below the budget, called by the startup check
+	warmup ports.AddressValidator
+	// geocoder biases geocoding requests towards the geofence, nil for providers without one
+	geocoder *adapters.GoogleGeocodingAdapter
+	handler  http.Handler
+	// dependencies are checked by the startup check before readiness reports ready
+	dependencies []handlers.Dependency
+}
+
+// Option overrides the configuration read from the environment
+type Option func(*config.AppConfig) error
+
+// WithDistanceUnit overrides MAP_DISTANCE_UNIT
+func WithDistanceUnit(unit string) Option {
+	return func(c *config.AppConfig) error {
+		switch unit {
+		case ports.DISTANCE_KILOMETER, ports.DISTANCE_MILES:
+			c.Map.DistanceUnit = unit
+			return nil
+		default:
+			return fmt.Errorf("distance unit %q must be %s or %s", unit, ports.DISTANCE_KILOMETER, ports.DISTANCE_MILES)
+		}
+	}
+}
+
+// NewApp wires the logger, adapters, service, rate limiter and routes from the environment read by cfg
+func NewApp(cfg config.Config, opts ...Option) (*App, error) {
+	// Parse every section up front so all misconfigurations are reported together
+	appConfig, errs := cfg.LoadAll()
+	for _, opt := range opts {
+		if err := opt(&appConfig); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+
+	infraConfig := appConfig.Infra
+	logger := appConfig.Logger
+
+	// Create address validation adapter
+	mapConfig := appConfig.Map
+	// Logged after the options so a unit overridden on the command line is the one shown
+	logger.Info("geofence configured",
+		zap.Float64("centerLat", mapConfig.CenterLat),
+		zap.Float64("centerLng", mapConfig.CenterLng),
+		zap.Float64("maxDistance", mapConfig.MaxDistance),
+		zap.String("unit", mapConfig.DistanceUnit),
+		zap.Bool("inclusive", mapConfig.GeofenceInclusive),
+	)
+	if mapConfig.PremiumZoneDistance > 0 {
+		logger.Info("premium zone configured",
+			zap.Float64("centerLat", mapConfig.PremiumZoneLat),
+			zap.Float64("centerLng", mapConfig.PremiumZoneLng),
+			zap.Float64("maxDistance", mapConfig.PremiumZoneDistance),
+		)
+	}
+
+	var addressAdapter ports.AddressValidator
+	var reverseGeocoder ports.ReverseGeocoder
+	var travelTimeEstimator ports.TravelTimeEstimator
+	var elevationProvider ports.ElevationProvider
+	var timeZoneProvider ports.TimeZoneProvider
+	var metricsCollectors []ports.MetricsCollector
+	var dependencies []handlers.Dependency
+	var geocoder *adapters.GoogleGeocodingAdapter
+	switch mapConfig.Provider {
+	case ports.PROVIDER_MOCK:
+		var fixtures []adapters.MockFixture
+		if mapConfig.MockFixturesPath != "" {
+			loaded, err := adapters.LoadMockFixtures(mapConfig.MockFixturesPath)
+			if err != nil {
+				return nil, err
+			}
+			fixtures = loaded
+		}
+		logger.Info("using mock address validation adapter", zap.Int("fixtures", len(fixtures)))
+		mockValidator := adapters.NewMockValidator(fixtures, logger)
+		addressAdapter = mockValidator
+		reverseGeocoder = mockValidator
+	case ports.PROVIDER_SMARTY:
+		// The US Street API only verifies addresses, coordinates are not reverse geocoded
+		logger.Info("using SmartyStreets address validation adapter")
+		addressAdapter = adapters.NewSmartyStreetsAdapter(mapConfig, &http.Client{Timeout: mapConfig.ProviderTimeout}, adapters.SMARTYSTREETS_US_STREET_ENDPOINT, logger)
+	default:
+		geocodingAdapter := adapters.NewGoogleGeocodingAdapter(mapConfig, &http.Client{Timeout: mapConfig.ProviderTimeout}, adapters.GOOGLE_GEOCODING_ENDPOINT, logger)
+		if mapConfig.Provider == ports.PROVIDER_GEOCODING {
+			// Unlike the Address Validation API, geocoding requests can be biased towards the geofence
+			logger.Info("using Google Geocoding address validation adapter")
+			addressAdapter = geocodingAdapter
+			geocoder = geocodingAdapter
+		} else {
+			googleAdapter, err := adapters.NewGoogleAddressValidationAdapter(mapConfig, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create Google Address Validation adapter: %w", err)
+			}
+			metricsCollectors = append(metricsCollectors, googleAdapter)
+			addressAdapter = googleAdapter
+		}
+		reverseGeocoder = geocodingAdapter
+		if mapConfig.MaxDriveTime > 0 {
+			travelTimeEstimator = adapters.NewGoogleDistanceMatrixAdapter(mapConfig, &http.Client{Timeout: mapConfig.ProviderTimeout}, adapters.GOOGLE_DISTANCE_MATRIX_ENDPOINT, logger)
+		}
+		if mapConfig.MaxElevation > 0 {
+			elevationProvider = adapters.NewGoogleElevationAdapter(mapConfig, &http.Client{Timeout: mapConfig.ProviderTimeout}, adapters.GOOGLE_ELEVATION_ENDPOINT, logger)
+		}
+		if appConfig.Validation.IncludeTimeZone {
+			timeZoneAdapter := adapters.NewGoogleTimeZoneAdapter(mapConfig, &http.Client{Timeout: mapConfig.ProviderTimeout}, adapters.GOOGLE_TIME_ZONE_ENDPOINT, logger)
+			timeZoneProvider = adapters.NewCachingTimeZoneProvider(timeZoneAdapter)
+		}
+	}
+
+	// Fast-fail while the upstream is down, closest to the adapter so only real upstream errors count
+	breakerConfig := appConfig.CircuitBreaker
+	if breakerConfig.FailureThreshold > 0 {
+		breaker := adapters.NewCircuitBreakerValidator(addressAdapter, breakerConfig, logger)
+		metricsCollectors = append(metricsCollectors, breaker)
+		dependencies = append(dependencies, handlers.Dependency{Name: "provider", Checker: breaker, Critical: true})
+		addressAdapter = breaker
+		if reverseGeocoder != nil {
+			reverseGeocoder = breaker.WrapReverseGeocoder(reverseGeocoder)
+		}
+	}
+
+	// The startup warmup confirms the provider without being audited or spending the budget
+	warmup := addressAdapter
+
+	// Cap billed upstream calls, cache hits in front of it stay free
+	if mapConfig.DailyGeocodeBudget > 0 {
+		budgetValidator := adapters.NewBudgetValidator(addressAdapter, mapConfig.DailyGeocodeBudget, logger)
+		metricsCollectors = append(metricsCollectors, budgetValidator)
+		// Cached addresses are still served once the budget is spent, so it only degrades the service
+		dependencies = append(dependencies, handlers.Dependency{Name: "geocode_budget", Checker: budgetValidator})
+		addressAdapter = budgetValidator
+		// Submitted coordinates are reverse geocoded upstream, so they spend the same budget
+		if reverseGeocoder != nil {
+			reverseGeocoder = budgetValidator.WrapReverseGeocoder(reverseGeocoder)
+		}
+	}
+
+	// Share one upstream call between concurrent cache misses for the same address, for no longer
+	// than any request may wait for it
+	coalescer := adapters.NewCoalescingValidator(addressAdapter, infraConfig.MaxRequestTimeout, logger)
+	metricsCollectors = append(metricsCollectors, coalescer)
+	addressAdapter = coalescer
+
+	// Cache validation results in front of the adapter
+	cacheConfig := appConfig.Cache
+	if cacheConfig.TTL > 0 {
+		cachingValidator := adapters.NewCachingValidator(addressAdapter, cacheConfig, logger)
+		metricsCollectors = append(metricsCollectors, cachingValidator)
+		addressAdapter = cachingValidator
+	}
+
+	// Create address service
+	validationConfig := appConfig.Validation
+	addressService := services.NewAddressService(addressAdapter, logger, mapConfig, validationConfig)
+	addressService.SetReverseGeocoder(reverseGeocoder)
+	addressService.SetTravelTimeEstimator(travelTimeEstimator)
+	addressService.SetElevationProvider(elevationProvider)
+	addressService.SetTimeZoneProvider(timeZoneProvider)
+
+	// Canonicalize locality synonyms ahead of the US abbreviations
+	if validationConfig.LocalityAliasesPath != "" {
+		aliases, err := services.LoadLocalityAliases(validationConfig.LocalityAliasesPath)
+		if err != nil {
+			return nil, err
+		}
+		var next ports.AddressNormalizer
+		if validationConfig.NormalizeUS {
+			next = services.NewUSAddressNormalizerFor(validationConfig)
+		}
+		addressService.SetNormalizer(services.NewLocalityAliasNormalizer(aliases, next))
+		logger.Info("locality aliases loaded", zap.Int("localities", len(aliases)))
+	}
+
+	// Named policy bundles requests select with profile
+	if validationConfig.ProfilesPath != "" {
+		profiles, err := services.LoadValidationProfiles(validationConfig.ProfilesPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := addressService.SetProfiles(profiles, validationConfig.DefaultProfile); err != nil {
+			return nil, err
+		}
+		logger.Info("validation profiles loaded", zap.Int("profiles", len(profiles)))
+	} else if validationConfig.DefaultProfile != "" {
+		return nil, fmt.Errorf("VALIDATION_DEFAULT_PROFILE %q needs VALIDATION_PROFILES_PATH", validationConfig.DefaultProfile)
+	}
+
+	// Record every validation decision to the audit trail
+	auditConfig := appConfig.Audit
+	if auditConfig.Enabled {
+		auditLogger, err := config.NewAuditLogger(auditConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit logger: %w", err)
+		}
+		addressService.SetAuditLogger(audit.NewLogger(auditLogger, mapConfig.Provider, auditConfig.LogRawAddress))
+	}
+
+	// Create address handler
+	rateLimitConfig := appConfig.RateLimit
+	rateLimiter := handlers.NewLimiter(rateLimitConfig)
+	addressHandler := handlers.NewAddressHandler(addressService, rateLimiter, infraConfig, logger)
+	batchConfig := appConfig.Batch
+	batchHandler := handlers.NewBatchHandler(addressService, rateLimiter, infraConfig, batchConfig, logger)
+	// Both endpoints draw from the same daily quota
+	if rateLimitConfig.DailyQuota > 0 {
+		dailyQuota := handlers.NewDailyQuota(rateLimitConfig.DailyQuota, rateLimitConfig.DailyQuotaLocation)
+		addressHandler.SetDailyQuota(dailyQuota)
+		batchHandler.SetDailyQuota(dailyQuota)
+	}
+
+	// Set up routes
+	mux := http.NewServeMux()
+	// Upstream-bound routes share a global inflight cap, cheap routes stay exempt
+	inflightLimiter := handlers.NewInflightLimiter(infraConfig.MaxInflight, time.Second, logger)
+	mux.Handle("/validate", inflightLimiter.Middleware(http.HandlerFunc(addressHandler.ValidateAddress)))
+	mux.Handle("/validate/batch", inflightLimiter.Middleware(http.HandlerFunc(batchHandler.ValidateBatch)))
+	mux.Handle("/validate/url", inflightLimiter.Middleware(http.HandlerFunc(batchHandler.ValidateURL)))
+	requestMetrics := handlers.NewRequestMetrics()
+	recoverer := handlers.NewRecoverer(infraConfig, logger)
+	tenants := handlers.NewTenantResolver(infraConfig)
+	metricsCollectors = append(metricsCollectors, requestMetrics, recoverer, tenants, addressService)
+	mux.HandleFunc("/metrics", handlers.NewMetricsHandler(metricsCollectors...).ServeMetrics)
+	serviceArea := handlers.NewServiceAreaHandler(mapConfig)
+	mux.HandleFunc("/service-area", serviceArea.ServeServiceArea)
+
+	if handlers.RegisterPprof(mux, infraConfig) {
+		logger.Warn("pprof endpoints enabled at /debug/pprof/")
+	}
+
+	// Add basic health check endpoint
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+	readiness := handlers.NewReadinessHandler(logger, dependencies...)
+	mux.HandleFunc("/readyz", readiness.ServeReady)
+
+	var handler http.Handler = mux
+	if appConfig.DebugLogger != nil {
+		handler = handlers.NewDebugSampler(appConfig.Log.DebugSampleRate, appConfig.DebugLogger).Middleware(handler)
+	}
+	// Inside the metrics so a recovered panic is counted as a 5xx, inside the correlation ID so it is logged
+	handler = recoverer.Middleware(handler)
+	handler = requestMetrics.Middleware(handler)
+	// Inside the host filter so requests for unknown hosts are not counted against a tenant
+	handler = tenants.Middleware(handler)
+	handler = handlers.NewHostFilter(infraConfig.AllowedHosts, logger).Middleware(handler)
+	handler = handlers.CorrelationMiddleware(handler)
+	handler = handlers.HSTSMiddleware(infraConfig, handler)
+	compressionConfig := appConfig.Compression
+	if compressionConfig.Enabled {
+		handler = handlers.NewCompressor(compressionConfig, logger).Middleware(handler)
+	}
+
+	return &App{
+		logger:      logger,
+		infra:       infraConfig,
+		service:     addressService,
+		serviceArea: serviceArea,
+		readiness:   readiness,
+		warmup:      warmup,
+		geocoder:    geocoder,
+		handler:     handler,
+
+		dependencies: dependencies,
+	}, nil
+}
+
+// Startup check retries, an attempt may include a warmup validation so it gets a provider call's time.
+// Every attempt is a billed provider call, so they stop after a minute of failures.
+const (
+	STARTUP_CHECK_INTERVAL     = 2 * time.Second
+	STARTUP_CHECK_TIMEOUT      = 10 * time.Second
+	STARTUP_CHECK_MAX_ATTEMPTS = 30
+)
+
+// WaitForStartup holds /readyz at 503 until the startup check succeeds, so a new instance gets no
+// traffic before its provider is confirmed. It returns at once, the check is retried in the background
+// until it succeeds, ctx is done or STARTUP_CHECK_MAX_ATTEMPTS failed.
+func (a *App) WaitForStartup(ctx context.Context) {
+	a.readiness.WaitForStartup(ctx, a.startupCheck, STARTUP_CHECK_INTERVAL, STARTUP_CHECK_TIMEOUT, STARTUP_CHECK_MAX_ATTEMPTS)
+}
+
+// startupCheck succeeds once every critical dependency is up and the warmup address got an answer from
+// the provider. An address the provider cannot find is an answer too. The dependencies alone do not
+// confirm the provider, a circuit breaker that was just built is closed without any call made.
+// The warmup skips the service, so it is neither audited nor charged to the daily budget.
+func (a *App) startupCheck(ctx context.Context) error {
+	for _, dependency := range a.dependencies {
+		if !dependency.Critical {
+			continue
+		}
+		if err := dependency.Checker.CheckHealth(ctx); err != nil {
+			return fmt.Errorf("%s is down: %w", dependency.Name, err)
+		}
+	}
+
+	_, err := a.warmup.ValidateAddress(ctx, a.infra.WarmupAddress)
+	if err != nil && !isVerdict(err) {
+		return fmt.Errorf("warmup validation failed: %w", err)
+	}
+	return nil
+}
+
+// Validate validates an address in-process, applying every check of the HTTP endpoint except rate limiting
+func (a *App) Validate(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	return a.service.ValidateAddress(ctx, address, services.ValidationOptions{})
+}
+
+// ReloadGeofence re-reads the geofence from the configuration files of cfg and swaps it in without
+// a restart, keeping the current geofence when the new one is invalid
+func (a *App) ReloadGeofence(cfg config.Config) error {
+	mapConfig, errs := cfg.ReloadMapConfig(a.logger)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid geofence: %w", errors.Join(errs...))
+	}
+	if err := a.service.ReloadGeofence(mapConfig); err != nil {
+		return fmt.Errorf("invalid geofence: %w", err)
+	}
+	a.serviceArea.Reload(a.service.Geofence())
+	if a.geocoder != nil {
+		a.geocoder.ReloadGeofence(a.service.Geofence())
+	}
+
+	a.logger.Info("geofence reloaded",
+		zap.Float64("centerLat", mapConfig.CenterLat),
+		zap.Float64("centerLng", mapConfig.CenterLng),
+		zap.Float64("maxDistance", mapConfig.MaxDistance),
+		zap.String("unit", mapConfig.DistanceUnit),
+		zap.Bool("inclusive", mapConfig.GeofenceInclusive),
+	)
+	return nil
+}
+
+// Handler returns the routes of the service for mounting on a server or another mux
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// Logger returns the application logger
+func (a *App) Logger() *zap.Logger {
+	return a.logger
+}
+
+// InfraConfig returns the server configuration the app was built with
+func (a *App) InfraConfig() config.InfraConfig {
+	return a.infra
+}
+
+// One-shot exit codes
+const (
+	EXIT_VALID   = 0
+	EXIT_INVALID = 1
+	EXIT_ERROR   = 2
+)
+
+// ValidateOnce validates a single address, writes the result to w as JSON and returns the
+// process exit code: EXIT_VALID when the address is valid and in range, EXIT_INVALID when
+// it is invalid or out of range, and EXIT_ERROR when validation failed
+func (a *App) ValidateOnce(ctx context.Context, address string, w io.Writer) int {
+	result, err := a.Validate(ctx, address)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if encodeErr := encoder.Encode(result); encodeErr != nil {
+		a.logger.Error("failed to write result", zap.Error(encodeErr))
+		return EXIT_ERROR
+	}
+
+	switch {
+	case err != nil && !isVerdict(err):
+		a.logger.Error("validation failed", zap.Error(err))
+		return EXIT_ERROR
+	case !result.IsValid, result.InRange != nil && !*result.InRange:
+		return EXIT_INVALID
+	default:
+		return EXIT_VALID
+	}
+}
+
+// isVerdict reports whether a validation error is a definitive answer about the address
+// rather than a failure to reach one
+func isVerdict(err error) bool {
+	for _, verdict := range []error{
+		ports.ErrAddressNotFound,
+		services.ErrEmptyAddress,
+		services.ErrAddressTooLong,
+		services.ErrMalformedAddress,
+		services.ErrSuspiciousPattern,
+		services.ErrDisallowedScript,
+		services.ErrBlockedRegion,
+	} {
+		if errors.Is(err, verdict) {
+			return true
+		}
+	}
+	return false
+}
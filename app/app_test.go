@@ -0,0 +1,295 @@
+package app_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/app"
+	"address-validator/config"
+	"address-validator/ports"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newMockApp builds an App on the mock provider with a Bronx fixture and a 10 mile geofence
+// centered on it
+func newMockApp(t *testing.T, opts ...app.Option) *app.App {
+	t.Helper()
+
+	fixtures := filepath.Join(t.TempDir(), "fixtures.json")
+	data := `[
+		{"match": "123 Main St, Bronx", "result": {"isValid": true, "formattedAddress": "123 Main St, Bronx, NY 10456, USA", "latitude": 40.8448, "longitude": -73.8648}},
+		{"match": "Yonkers", "result": {"isValid": true, "formattedAddress": "1 Main St, Yonkers, NY 10701, USA", "latitude": 40.9752, "longitude": -73.8648}},
+		{"match": "Boston", "result": {"isValid": true, "formattedAddress": "1 Beacon St, Boston, MA 02108, USA", "latitude": 42.3581, "longitude": -71.0636}},
+		{"match": "Outage Ave", "result": {"error": "Failed to validate address.", "errorCode": "PROVIDER_TIMEOUT"}}
+	]`
+	if err := os.WriteFile(fixtures, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PROVIDER", "mock")
+	t.Setenv("MOCK_FIXTURES_PATH", fixtures)
+	t.Setenv("MAP_CENTER_LAT", "40.8448")
+	t.Setenv("MAP_CENTER_LNG", "-73.8648")
+	t.Setenv("MAP_MAX_DISTANCE", "10")
+	t.Setenv("MAP_DISTANCE_UNIT", "mi")
+	t.Setenv("ENVIRONMENT", "development")
+	t.Setenv("OUTPUT_PATH", "stderr")
+	t.Setenv("ERROR_PATH", "stderr")
+	t.Setenv("AUDIT_LOG", "false")
+	t.Setenv("REQUIRE_HTTPS", "false")
+
+	a, err := app.NewApp(config.Config{}, opts...)
+	if err != nil {
+		t.Fatalf("NewApp() error = %v", err)
+	}
+	return a
+}
+
+func TestApp_Validate(t *testing.T) {
+	a := newMockApp(t)
+
+	tests := []struct {
+		name      string
+		address   string
+		wantValid bool
+		wantErr   error
+	}{
+		{
+			name:      "Test Fixture Address Is Valid",
+			address:   "123 Main St, Bronx, NY",
+			wantValid: true,
+		},
+		{
+			name:    "Test Unknown Address Returns Not Found",
+			address: "1 Nowhere Rd",
+			wantErr: adapters.ErrNoMockFixture,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.Validate(context.Background(), tt.address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("App.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("App.Validate() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+		})
+	}
+
+	t.Run("Test Handler Serves Health", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("App.Handler() /health status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+}
+
+func TestApp_ValidateOnce(t *testing.T) {
+	tests := []struct {
+		name        string
+		unit        string
+		address     string
+		wantCode    int
+		wantInRange bool
+	}{
+		{name: "Test Valid In Range Address Exits Zero", address: "123 Main St, Bronx, NY", wantCode: app.EXIT_VALID, wantInRange: true},
+		{name: "Test Out Of Range Address Exits One", address: "1 Beacon St, Boston", wantCode: app.EXIT_INVALID},
+		{name: "Test Unknown Address Exits One", address: "1 Nowhere Rd", wantCode: app.EXIT_INVALID},
+		// Yonkers is 9 miles from the center, inside 10 miles but outside 10 kilometers
+		{name: "Test Default Unit Uses Miles", address: "1 Main St, Yonkers", wantCode: app.EXIT_VALID, wantInRange: true},
+		{name: "Test Unit Flag Overrides Distance Unit", unit: ports.DISTANCE_KILOMETER, address: "1 Main St, Yonkers", wantCode: app.EXIT_INVALID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []app.Option
+			if tt.unit != "" {
+				opts = append(opts, app.WithDistanceUnit(tt.unit))
+			}
+			a := newMockApp(t, opts...)
+
+			var out bytes.Buffer
+			if code := a.ValidateOnce(context.Background(), tt.address, &out); code != tt.wantCode {
+				t.Errorf("App.ValidateOnce() = %d, want %d", code, tt.wantCode)
+			}
+
+			var got ports.AddressValidationResult
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("App.ValidateOnce() wrote invalid JSON %q: %v", out.String(), err)
+			}
+			if (got.InRange != nil && *got.InRange) != tt.wantInRange {
+				t.Errorf("App.ValidateOnce() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+		})
+	}
+
+	t.Run("Test Invalid Unit Fails Construction", func(t *testing.T) {
+		t.Setenv("PROVIDER", "mock")
+		t.Setenv("MAP_CENTER_LAT", "40.8448")
+		t.Setenv("MAP_CENTER_LNG", "-73.8648")
+		t.Setenv("OUTPUT_PATH", "stderr")
+		if _, err := app.NewApp(config.Config{}, app.WithDistanceUnit("furlongs")); err == nil {
+			t.Error("NewApp() error = nil, want error")
+		}
+	})
+}
+
+func TestApp_ReloadGeofence(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("CONFIG_FILE", configFile)
+	if err := os.WriteFile(configFile, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	a := newMockApp(t)
+	// The reloaded values come from the file, the environment keeps the center
+	for _, name := range []string{"MAP_MAX_DISTANCE", "PREMIUM_ZONE_MAX_DISTANCE", "PREMIUM_ZONE_CENTER_LAT", "PREMIUM_ZONE_CENTER_LNG"} {
+		t.Setenv(name, "")
+	}
+
+	inRange := func() bool {
+		t.Helper()
+		got, err := a.Validate(context.Background(), "1 Main St, Yonkers")
+		if err != nil || got.InRange == nil {
+			t.Fatalf("App.Validate() = %+v, %v, want a geofenced result", got, err)
+		}
+		return *got.InRange
+	}
+	// Yonkers is about 9 miles from the center
+	if !inRange() {
+		t.Fatalf("App.Validate() before reload InRange = false, want true")
+	}
+
+	// An invalid geofence is rejected and the current one kept
+	if err := os.WriteFile(configFile, []byte(`{"MAP_MAX_DISTANCE": 5, "PREMIUM_ZONE_MAX_DISTANCE": 1, "PREMIUM_ZONE_CENTER_LAT": 91, "PREMIUM_ZONE_CENTER_LNG": -73.8648}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReloadGeofence(config.Config{}); err == nil {
+		t.Errorf("App.ReloadGeofence() error = nil, want an invalid geofence error")
+	}
+	if !inRange() {
+		t.Errorf("App.Validate() after failed reload InRange = false, want true")
+	}
+
+	// The environment's center wins over the file's invalid one
+	if err := os.WriteFile(configFile, []byte(`{"MAP_MAX_DISTANCE": 5, "MAP_CENTER_LAT": 91}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReloadGeofence(config.Config{}); err != nil {
+		t.Fatalf("App.ReloadGeofence() error = %v", err)
+	}
+	if inRange() {
+		t.Errorf("App.Validate() after reload InRange = true, want false")
+	}
+
+	rec := httptest.NewRecorder()
+	a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/service-area", nil))
+	var area struct {
+		Properties struct {
+			Radius float64 `json:"radius"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&area); err != nil || area.Properties.Radius != 5 {
+		t.Errorf("service area radius = %v (%v), want 5", area.Properties.Radius, err)
+	}
+}
+
+func TestApp_CoordinatesSpendGeocodeBudget(t *testing.T) {
+	t.Setenv("DAILY_GEOCODE_BUDGET", "1")
+	a := newMockApp(t)
+
+	validate := func() (int, ports.AddressValidationResult) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validate?latitude=40.8448&longitude=-73.8648", nil))
+		var got ports.AddressValidationResult
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("App.Handler() /validate wrote invalid JSON: %v", err)
+		}
+		return rec.Code, got
+	}
+
+	if code, got := validate(); code != http.StatusOK || !got.IsValid {
+		t.Fatalf("App.Handler() first coordinate request = %d, %+v, want a valid result", code, got)
+	}
+	code, got := validate()
+	if code != http.StatusServiceUnavailable || got.ErrorCode != ports.ERROR_CODE_QUOTA_EXHAUSTED {
+		t.Errorf("App.Handler() coordinate request past budget = %d, %q, want %d, %q",
+			code, got.ErrorCode, http.StatusServiceUnavailable, ports.ERROR_CODE_QUOTA_EXHAUSTED)
+	}
+}
+
+func TestApp_WaitForStartup(t *testing.T) {
+	tests := []struct {
+		name          string
+		warmupAddress string
+		wantStatus    int
+	}{
+		{
+			name:       "Test Default Warmup Address Answered Reports Ready",
+			wantStatus: http.StatusOK,
+		},
+		{
+			// The mock fixture of the address times out, as a provider that is not reachable yet
+			name:          "Test Failing Provider Stays Unavailable",
+			warmupAddress: "1 Outage Ave",
+			wantStatus:    http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("STARTUP_WARMUP_ADDRESS", tt.warmupAddress)
+			a := newMockApp(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			a.WaitForStartup(ctx)
+
+			// The first attempt runs at once, give it time to settle before the last read
+			var got int
+			for deadline := time.Now().Add(500 * time.Millisecond); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+				rec := httptest.NewRecorder()
+				a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+				if got = rec.Code; got == http.StatusOK {
+					break
+				}
+			}
+			if got != tt.wantStatus {
+				t.Errorf("App.Handler() /readyz status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestApp_WaitForStartup_SpendsNoBudget(t *testing.T) {
+	t.Setenv("DAILY_GEOCODE_BUDGET", "1")
+	t.Setenv("STARTUP_WARMUP_ADDRESS", "123 Main St, Bronx")
+	a := newMockApp(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.WaitForStartup(ctx)
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		rec := httptest.NewRecorder()
+		a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		if rec.Code == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("App.Handler() /readyz status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	}
+
+	// The warmup left the whole budget to the first request
+	got, err := a.Validate(context.Background(), "123 Main St, Bronx, NY")
+	if err != nil || !got.IsValid {
+		t.Errorf("App.Validate() after warmup = %+v, %v, want a valid result", got, err)
+	}
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"address-validator/ports"
+
+	"go.uber.org/zap"
+)
+
+// AUDIT_EVENT is the message of every audit record, so the trail can be filtered from a shared sink
+const AUDIT_EVENT = "address validation decision"
+
+type contextKey struct{}
+
+// WithCorrelationID returns a context carrying the correlation ID of the request
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// CorrelationID returns the correlation ID of the context, or "" when none was set
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// NewCorrelationID returns a random 128-bit hex correlation ID
+func NewCorrelationID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Logger writes one record per validation decision in a stable schema.
+// A nil Logger records nothing.
+type Logger struct {
+	logger        *zap.Logger
+	provider      string
+	logRawAddress bool
+}
+
+// NewLogger creates an audit logger writing to logger. The raw address is only
+// included when logRawAddress is set, otherwise records carry its hash alone.
+func NewLogger(logger *zap.Logger, provider string, logRawAddress bool) *Logger {
+	return &Logger{
+		logger:        logger,
+		provider:      provider,
+		logRawAddress: logRawAddress,
+	}
+}
+
+// Record logs the decision for a validation of address
+func (l *Logger) Record(ctx context.Context, address string, result ports.AddressValidationResult, err error) {
+	if l == nil {
+		return
+	}
+
+	errText := ""
+	if err != nil {
+		errText = err.Error()
+	}
+
+	// Every key is always present so the schema stays stable for downstream consumers
+	fields := []zap.Field{
+		zap.String("correlationId", CorrelationID(ctx)),
+		zap.String("addressHash", HashAddress(address)),
+		zap.String("provider", l.provider),
+		zap.String("regionCode", result.RegionCode),
+		zap.Bool("isValid", result.IsValid),
+		zap.Boolp("inRange", result.InRange),
+		zap.String("errorCode", result.ErrorCode),
+		zap.String("error", errText),
+	}
+	if l.logRawAddress {
+		fields = append(fields, zap.String("address", address))
+	}
+
+	l.logger.Info(AUDIT_EVENT, fields...)
+}
+
+// HashAddress returns the hex SHA-256 of the address, ignoring case and surrounding whitespace,
+// so the same address can be correlated across records without being stored
+func HashAddress(address string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(address))))
+	return hex.EncodeToString(sum[:])
+}
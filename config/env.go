@@ -7,14 +7,26 @@ import (
 )
 
 // Config holds all configuration for the application
-type Config struct{}
+type Config struct {
+	// OneShot is set for the -address mode, which validates one address without serving HTTP
+	OneShot bool
+}
 
 // LoadConfig loads the configuration from environment variables
 func LoadConfig() Config {
 	// Load .env file if it exists
-	if err := godotenv.Load(); err != nil {
+	if err := loadDotEnv(); err != nil {
 		log.Fatalf("Warning: .env file not found or could not be loaded: %v\n", err)
 	}
 
 	return Config{}
 }
+
+// loadDotEnv applies the .env file like LoadConfigFile, filling only the variables the environment leaves unset
+func loadDotEnv() error {
+	values, err := godotenv.Read()
+	if err != nil {
+		return err
+	}
+	return applyFileValues(values)
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AppConfig holds every configuration section and the logger built from the logger section
+type AppConfig struct {
+	Infra          InfraConfig
+	Log            LoggerConfig
+	Map            MapConfig
+	CircuitBreaker CircuitBreakerConfig
+	Cache          CacheConfig
+	Validation     ValidationConfig
+	RateLimit      RateLimitConfig
+	Batch          BatchConfig
+	Compression    CompressionConfig
+	Audit          AuditConfig
+
+	// OneShot skips the checks that only matter to the HTTP server, see Config.OneShot
+	OneShot bool
+
+	Logger *zap.Logger
+	// DebugLogger logs sampled requests at debug level, nil when sampling is disabled
+	DebugLogger *zap.Logger
+}
+
+// LoadAll parses every configuration section and validates the result, collecting all
+// problems instead of exiting on the first. Invalid optional values still fall back to
+// their defaults with a warning, only unusable configuration is returned as an error.
+func (c Config) LoadAll() (AppConfig, []error) {
+	const CONFIG_FILE = "CONFIG_FILE"
+
+	var errs []error
+	config := AppConfig{OneShot: c.OneShot}
+
+	// Optional, file values only fill variables the environment leaves unset
+	if path := os.Getenv(CONFIG_FILE); path != "" {
+		if err := LoadConfigFile(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	config.Infra = c.NewInfraConfig()
+	config.Log = c.NewLoggerConfig(config.Infra.Environment)
+
+	logger, debugLogger, err := NewSamplingLoggers(config.Log)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to implement logger: %w", err))
+		// Keep reporting the remaining sections somewhere visible
+		logger = zap.New(zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+			zapcore.AddSync(os.Stderr),
+			zapcore.WarnLevel,
+		))
+	}
+	config.Logger = logger
+	config.DebugLogger = debugLogger
+
+	mapConfig, mapErrs := c.parseMapConfig(logger)
+	config.Map = mapConfig
+	errs = append(errs, mapErrs...)
+
+	config.CircuitBreaker = c.NewCircuitBreakerConfig(logger)
+	config.Cache = c.NewCacheConfig(logger)
+	config.Validation = c.NewValidationConfig(logger)
+	config.RateLimit = c.NewRateLimitConfig(logger)
+	config.Batch = c.NewBatchConfig(logger)
+	config.Compression = c.NewCompressionConfig(logger)
+	config.Audit = c.NewAuditConfig(config.Infra.Environment, logger)
+
+	errs = append(errs, config.Validate()...)
+
+	return config, errs
+}
+
+// Validate checks the parsed configuration for values no section can fall back from
+func (a AppConfig) Validate() []error {
+	var errs []error
+
+	errs = append(errs, a.Map.ValidateGeofence()...)
+
+	// A zero cap would turn every validation request away
+	if a.Infra.MaxInflight == 0 {
+		errs = append(errs, fmt.Errorf(NegativeValueErr, "MAX_INFLIGHT"))
+	}
+
+	// Requiring HTTPS on a server nothing can reach over HTTPS rejects every request,
+	// the one-shot mode serves nothing so has no TLS to get wrong
+	if !a.OneShot {
+		if err := a.Infra.ValidateTLS(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"address-validator/config"
+	"strings"
+	"testing"
+)
+
+func TestConfig_LoadAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        [][2]string
+		oneShot    bool
+		wantErrors []string
+	}{
+		{
+			name: "Test Valid Environment Returns No Errors",
+			env: [][2]string{
+				{"PROVIDER", "google"},
+				{"GOOGLE_MAPS_API_KEY", "test-key"},
+				{"MAP_CENTER_LAT", "40.8313747"},
+				{"MAP_CENTER_LNG", "-73.8272283"},
+			},
+		},
+		{
+			name: "Test Multiple Misconfigurations Are All Reported",
+			env: [][2]string{
+				{"PROVIDER", "google"},
+				{"MAP_CENTER_LAT", "north"},
+			},
+			wantErrors: []string{
+				"GOOGLE_MAPS_API_KEY environment variable is required",
+				"MAP_CENTER_LAT environment variable is invalid",
+				"MAP_CENTER_LNG environment variable is required",
+			},
+		},
+		{
+			name: "Test Parse And Validation Errors Are Reported Together",
+			env: [][2]string{
+				{"PROVIDER", "bing"},
+				{"MAP_CENTER_LAT", "91"},
+				{"MAP_CENTER_LNG", "-73.8272283"},
+			},
+			wantErrors: []string{
+				`PROVIDER environment variable is invalid: "bing"`,
+				// The provider falls back to Google, which then needs a key
+				"GOOGLE_MAPS_API_KEY environment variable is required",
+				"MAP_CENTER_LAT 91 is outside -90 to 90",
+			},
+		},
+		{
+			name: "Test Unreachable HTTPS Server Is Reported",
+			env: [][2]string{
+				{"PROVIDER", "google"},
+				{"GOOGLE_MAPS_API_KEY", "test-key"},
+				{"MAP_CENTER_LAT", "40.8313747"},
+				{"MAP_CENTER_LNG", "-73.8272283"},
+				{"REQUIRE_HTTPS", "true"},
+			},
+			wantErrors: []string{
+				"REQUIRE_HTTPS is on but neither TLS_CERT_FILE nor BEHIND_TLS_PROXY is set",
+			},
+		},
+		{
+			name: "Test One Shot Mode Skips The TLS Check",
+			env: [][2]string{
+				{"PROVIDER", "google"},
+				{"GOOGLE_MAPS_API_KEY", "test-key"},
+				{"MAP_CENTER_LAT", "40.8313747"},
+				{"MAP_CENTER_LNG", "-73.8272283"},
+				{"REQUIRE_HTTPS", "true"},
+			},
+			oneShot: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"PROVIDER", "GOOGLE_MAPS_API_KEY", "GOOGLE_MAPS_API_KEYS", "MAP_CENTER_LAT", "MAP_CENTER_LNG", "TLS_CERT_FILE", "TLS_KEY_FILE", "BEHIND_TLS_PROXY"} {
+				t.Setenv(name, "")
+			}
+			t.Setenv("OUTPUT_PATH", "stdout")
+			t.Setenv("ERROR_PATH", "stderr")
+			t.Setenv("REQUIRE_HTTPS", "false")
+			for _, env := range tt.env {
+				t.Setenv(env[0], env[1])
+			}
+
+			_, errs := config.Config{OneShot: tt.oneShot}.LoadAll()
+
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if len(got) != len(tt.wantErrors) {
+				t.Fatalf("Config.LoadAll() errors = %q, want %q", got, tt.wantErrors)
+			}
+			for i, want := range tt.wantErrors {
+				if !strings.HasPrefix(got[i], want) {
+					t.Errorf("Config.LoadAll() error %d = %q, want %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
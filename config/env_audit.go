@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// AuditConfig holds the audit trail configuration
+type AuditConfig struct {
+	Enabled       bool
+	OutputPath    string
+	LogRawAddress bool
+}
+
+func (c Config) NewAuditConfig(environment Environment, logger *zap.Logger) AuditConfig {
+	const (
+		AUDIT_LOG             = "AUDIT_LOG"
+		AUDIT_OUTPUT_PATH     = "AUDIT_OUTPUT_PATH"
+		AUDIT_LOG_RAW_ADDRESS = "AUDIT_LOG_RAW_ADDRESS"
+	)
+
+	config := AuditConfig{
+		Enabled:    true,
+		OutputPath: "stdout",
+	}
+
+	// On unless explicitly disabled
+	config.Enabled = os.Getenv(AUDIT_LOG) != "false"
+
+	// Kept apart from the application logs so the trail is not mixed with debug output
+	if input := os.Getenv(AUDIT_OUTPUT_PATH); input != "" {
+		config.OutputPath = input
+	}
+
+	// Raw addresses are personal data, production records carry only the hash unless opted in
+	config.LogRawAddress = environment == ENV_DEVELOPMENT || os.Getenv(AUDIT_LOG_RAW_ADDRESS) == "true"
+
+	logger.Debug("Defined Audit Configuration", zap.Any("config", config))
+
+	return config
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BatchConfig holds the batch validation endpoint configuration
+type BatchConfig struct {
+	MaxSize     uint
+	Concurrency uint
+	// StreamDecode reads the addresses one at a time, never holding more than MaxSize of them
+	StreamDecode bool
+	// URLAllowedHosts are the hostnames POST /validate/url may fetch a CSV from, empty disables the endpoint
+	URLAllowedHosts []string
+	// URLAllowedSchemes are the schemes it may fetch with, https unless configured
+	URLAllowedSchemes []string
+	// URLAllowPrivate lets it reach loopback, private and link-local addresses, e.g. an in-cluster object store
+	URLAllowPrivate bool
+	URLMaxBytes     int64
+	URLMaxRows      uint
+	URLTimeout      time.Duration
+}
+
+func (c Config) NewBatchConfig(logger *zap.Logger) BatchConfig {
+	const (
+		BATCH_MAX_SIZE    = "BATCH_MAX_SIZE"
+		BATCH_CONCURRENCY = "BATCH_CONCURRENCY"
+		BATCH_STREAM      = "BATCH_STREAM_DECODE"
+		URL_HOSTS         = "BATCH_URL_ALLOWED_HOSTS"
+		URL_SCHEMES       = "BATCH_URL_ALLOWED_SCHEMES"
+		URL_PRIVATE       = "BATCH_URL_ALLOW_PRIVATE"
+		URL_MAX_BYTES     = "BATCH_URL_MAX_BYTES"
+		URL_MAX_ROWS      = "BATCH_URL_MAX_ROWS"
+		URL_TIMEOUT       = "BATCH_URL_TIMEOUT_SECONDS"
+		INPUT             = "input"
+	)
+
+	config := BatchConfig{
+		MaxSize:           25,
+		Concurrency:       4,
+		URLAllowedSchemes: []string{"https"},
+		URLMaxBytes:       10 << 20,
+		URLMaxRows:        1000,
+		URLTimeout:        30 * time.Second,
+	}
+
+	setUint := func(value *uint, ENV_VAR string) {
+		input := os.Getenv(ENV_VAR)
+		if input == "" {
+			logger.Warn(fmt.Sprintf(MissingEnvVarWarning, ENV_VAR))
+			return
+		}
+
+		num, err := strconv.Atoi(input)
+		if err != nil || num <= 0 {
+			message := fmt.Sprintf(InvalidEnvVarErr, ENV_VAR)
+			logger.Warn(message, zap.String(INPUT, input))
+			return
+		}
+
+		*value = uint(num)
+	}
+
+	setUint(&config.MaxSize, BATCH_MAX_SIZE)
+	setUint(&config.Concurrency, BATCH_CONCURRENCY)
+
+	// Off by default, the whole array is decoded before it is truncated
+	config.StreamDecode = os.Getenv(BATCH_STREAM) == "true"
+
+	// =====================
+	// Remote CSV Section
+	// =====================
+	// Optional, hosts are matched without their port
+	splitList := func(ENV_VAR string) []string {
+		var values []string
+		for _, value := range strings.Split(os.Getenv(ENV_VAR), ",") {
+			if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	config.URLAllowedHosts = splitList(URL_HOSTS)
+	if schemes := splitList(URL_SCHEMES); len(schemes) > 0 {
+		config.URLAllowedSchemes = schemes
+	}
+	// Off by default, the SSRF guard refuses internal addresses even for allowed hosts
+	config.URLAllowPrivate = os.Getenv(URL_PRIVATE) == "true"
+
+	if input := os.Getenv(URL_MAX_BYTES); input != "" {
+		if maxBytes, err := strconv.ParseInt(input, 10, 64); err == nil && maxBytes > 0 {
+			config.URLMaxBytes = maxBytes
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, URL_MAX_BYTES)
+			logger.Warn(message, zap.String(INPUT, input))
+		}
+	}
+	if os.Getenv(URL_MAX_ROWS) != "" {
+		setUint(&config.URLMaxRows, URL_MAX_ROWS)
+	}
+	if input := os.Getenv(URL_TIMEOUT); input != "" {
+		if seconds, err := strconv.Atoi(input); err == nil && seconds > 0 {
+			config.URLTimeout = time.Duration(seconds) * time.Second
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, URL_TIMEOUT)
+			logger.Warn(message, zap.String(INPUT, input))
+		}
+	}
+
+	logger.Debug("Defined Batch Configuration", zap.Any("config", config))
+
+	return config
+}
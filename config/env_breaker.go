@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CircuitBreakerConfig holds the upstream circuit breaker configuration
+type CircuitBreakerConfig struct {
+	FailureThreshold uint
+	Cooldown         time.Duration
+}
+
+func (c Config) NewCircuitBreakerConfig(logger *zap.Logger) CircuitBreakerConfig {
+	const (
+		BREAKER_FAILURE_THRESHOLD = "BREAKER_FAILURE_THRESHOLD"
+		BREAKER_COOLDOWN_SECONDS  = "BREAKER_COOLDOWN_SECONDS"
+		INPUT                     = "input"
+	)
+
+	config := CircuitBreakerConfig{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+
+	// A zero threshold disables the breaker
+	input := os.Getenv(BREAKER_FAILURE_THRESHOLD)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(MissingEnvVarWarning, BREAKER_FAILURE_THRESHOLD))
+	} else if threshold, err := strconv.Atoi(input); err != nil || threshold < 0 {
+		message := fmt.Sprintf(InvalidEnvVarErr, BREAKER_FAILURE_THRESHOLD)
+		logger.Warn(message, zap.String(INPUT, input))
+	} else {
+		config.FailureThreshold = uint(threshold)
+	}
+
+	input = os.Getenv(BREAKER_COOLDOWN_SECONDS)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(MissingEnvVarWarning, BREAKER_COOLDOWN_SECONDS))
+	} else if seconds, err := strconv.Atoi(input); err != nil || seconds <= 0 {
+		message := fmt.Sprintf(InvalidEnvVarErr, BREAKER_COOLDOWN_SECONDS)
+		logger.Warn(message, zap.String(INPUT, input))
+	} else {
+		config.Cooldown = time.Duration(seconds) * time.Second
+	}
+
+	logger.Debug("Defined Circuit Breaker Configuration", zap.Any("config", config))
+
+	return config
+}
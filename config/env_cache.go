@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DEFAULT_CACHE_MAX_ENTRIES bounds the result cache unless configured
+const DEFAULT_CACHE_MAX_ENTRIES = 10000
+
+// CacheConfig holds the validation result cache configuration
+type CacheConfig struct {
+	TTL        time.Duration
+	ServeStale bool
+	// MaxEntries and MaxBytes cap the cache, zero leaves that cap off
+	MaxEntries uint
+	MaxBytes   int64
+}
+
+func (c Config) NewCacheConfig(logger *zap.Logger) CacheConfig {
+	const (
+		CACHE_TTL_SECONDS = "CACHE_TTL_SECONDS"
+		CACHE_SERVE_STALE = "CACHE_SERVE_STALE"
+		CACHE_MAX_ENTRIES = "CACHE_MAX_ENTRIES"
+		CACHE_MAX_BYTES   = "CACHE_MAX_BYTES"
+	)
+
+	// A zero TTL disables the cache
+	config := CacheConfig{MaxEntries: DEFAULT_CACHE_MAX_ENTRIES}
+
+	input := os.Getenv(CACHE_TTL_SECONDS)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(MissingEnvVarWarning, CACHE_TTL_SECONDS))
+	} else if seconds, err := strconv.Atoi(input); err != nil || seconds < 0 {
+		message := fmt.Sprintf(InvalidEnvVarErr, CACHE_TTL_SECONDS)
+		logger.Warn(message, zap.String("input", input))
+	} else {
+		config.TTL = time.Duration(seconds) * time.Second
+	}
+
+	// Serve expired results when the upstream fails instead of erroring
+	config.ServeStale = os.Getenv(CACHE_SERVE_STALE) == "true"
+
+	// =====================
+	// Cache Size Section
+	// =====================
+	// Least recently used entries are evicted past either cap
+	input = os.Getenv(CACHE_MAX_ENTRIES)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(DefaultedEnvVarWarning, CACHE_MAX_ENTRIES, strconv.Itoa(DEFAULT_CACHE_MAX_ENTRIES)))
+	} else if num, err := strconv.Atoi(input); err != nil || num < 0 {
+		message := fmt.Sprintf(InvalidEnvVarErr, CACHE_MAX_ENTRIES)
+		logger.Warn(message, zap.String("input", input))
+	} else {
+		config.MaxEntries = uint(num)
+	}
+
+	// Optional, an approximate bound on the memory held by cached results
+	if input = os.Getenv(CACHE_MAX_BYTES); input != "" {
+		if num, err := strconv.ParseInt(input, 10, 64); err != nil || num < 0 {
+			message := fmt.Sprintf(InvalidEnvVarErr, CACHE_MAX_BYTES)
+			logger.Warn(message, zap.String("input", input))
+		} else {
+			config.MaxBytes = num
+		}
+	}
+
+	logger.Debug("Defined Cache Configuration", zap.Any("config", config))
+
+	return config
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// CompressionConfig holds the response compression configuration
+type CompressionConfig struct {
+	Enabled bool
+	MinSize uint
+}
+
+func (c Config) NewCompressionConfig(logger *zap.Logger) CompressionConfig {
+	const (
+		COMPRESSION           = "COMPRESSION"
+		COMPRESSION_MIN_BYTES = "COMPRESSION_MIN_BYTES"
+	)
+
+	config := CompressionConfig{
+		Enabled: true,
+		MinSize: 1024,
+	}
+
+	// On unless explicitly disabled
+	config.Enabled = os.Getenv(COMPRESSION) != "false"
+
+	// Responses smaller than this are sent as is, compressing them costs more than it saves
+	input := os.Getenv(COMPRESSION_MIN_BYTES)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(MissingEnvVarWarning, COMPRESSION_MIN_BYTES))
+	} else if minSize, err := strconv.Atoi(input); err != nil || minSize < 0 {
+		message := fmt.Sprintf(InvalidEnvVarErr, COMPRESSION_MIN_BYTES)
+		logger.Warn(message, zap.String("input", input))
+	} else {
+		config.MinSize = uint(minSize)
+	}
+
+	logger.Debug("Defined Compression Configuration", zap.Any("config", config))
+
+	return config
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"crypto/tls"
+	"fmt"
 	"log"
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Environment uint8
@@ -18,23 +24,96 @@ const (
 
 var environmentStrings = []string{"PRODUCTION", "DEVELOPMENT"}
 
+// Response JSON field cases
+const (
+	JSON_CASE_CAMEL = "camel"
+	JSON_CASE_SNAKE = "snake"
+)
+
 type InfraConfig struct {
 	Environment  Environment
 	Port         uint16
 	IsHttpSecure bool
+	// ClientIPHeaders are checked in order for the client IP, only from trusted proxies
+	ClientIPHeaders []string
+	TrustedProxies  []netip.Prefix
+	ServerTiming    bool
+	JSONFieldCase   string
+	MaxInflight     uint
+	EnablePprof     bool
+	PprofToken      string
+	// MaxRequestTimeout bounds the client X-Request-Timeout header
+	MaxRequestTimeout time.Duration
+	// AllowedHosts are the lowercase hostnames requests may address, empty allows any
+	AllowedHosts []string
+	// RegionFromLanguage infers the region of a request without one from its Accept-Language header
+	RegionFromLanguage bool
+	// DebugToken is the bearer token unlocking the raw provider response, empty disables it
+	DebugToken string
+	// TLSCertFile and TLSKeyFile make the server terminate TLS itself
+	TLSCertFile string
+	TLSKeyFile  string
+	// BehindTLSProxy trusts X-Forwarded-Proto from the proxy that terminates TLS in front of the server
+	BehindTLSProxy bool
+	// TLSMinVersion is the lowest TLS version the server negotiates, e.g. tls.VersionTLS12
+	TLSMinVersion uint16
+	// HSTSMaxAge is the Strict-Transport-Security max-age sent over HTTPS, 0 disables the header
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	// PanicReraise re-raises recovered handler panics after logging them, honored in development only
+	PanicReraise bool
+	// Tenants are the X-Tenant-ID values accepted, empty accepts free-form IDs up to MaxTenants distinct ones
+	Tenants    []string
+	MaxTenants uint
+	// WarmupAddress is validated at startup before readiness reports ready, confirming the provider answers
+	WarmupAddress string
 }
 
+// DEFAULT_WARMUP_ADDRESS is validated at startup when STARTUP_WARMUP_ADDRESS is unset. Any answer
+// confirms the provider, so it need not be in the geofence or even be found.
+const DEFAULT_WARMUP_ADDRESS = "1600 Pennsylvania Ave NW, Washington, DC 20500"
+
 func (c Config) NewInfraConfig() InfraConfig {
 	config := InfraConfig{
-		Port:         8080,
-		IsHttpSecure: true,
-		Environment:  ENV_PRODUCTION,
+		Port:              8080,
+		IsHttpSecure:      true,
+		Environment:       ENV_PRODUCTION,
+		ClientIPHeaders:   []string{"X-Forwarded-For"},
+		JSONFieldCase:     JSON_CASE_CAMEL,
+		MaxInflight:       100,
+		MaxRequestTimeout: 10 * time.Second,
+		TLSMinVersion:     tls.VersionTLS12,
+		HSTSMaxAge:        365 * 24 * time.Hour,
+		MaxTenants:        100,
+		WarmupAddress:     DEFAULT_WARMUP_ADDRESS,
 	}
 
 	const (
-		PORT          = "PORT"
-		ENVIRONMENT   = "ENVIRONMENT"
-		REQUIRE_HTTPS = "REQUIRE_HTTPS"
+		PORT                   = "PORT"
+		ENVIRONMENT            = "ENVIRONMENT"
+		REQUIRE_HTTPS          = "REQUIRE_HTTPS"
+		CLIENT_IP_HEADER       = "CLIENT_IP_HEADER"
+		CLIENT_IP_HEADERS      = "CLIENT_IP_HEADERS"
+		TRUSTED_PROXIES        = "TRUSTED_PROXIES"
+		SERVER_TIMING          = "SERVER_TIMING"
+		JSON_FIELD_CASE        = "JSON_FIELD_CASE"
+		MAX_INFLIGHT           = "MAX_INFLIGHT"
+		ENABLE_PPROF           = "ENABLE_PPROF"
+		PPROF_TOKEN            = "PPROF_TOKEN"
+		MAX_REQUEST_TIMEOUT_MS = "MAX_REQUEST_TIMEOUT_MS"
+		ALLOWED_HOSTS          = "ALLOWED_HOSTS"
+		REGION_FROM_LANGUAGE   = "REGION_FROM_ACCEPT_LANGUAGE"
+		DEBUG_TOKEN            = "DEBUG_TOKEN"
+		TLS_CERT_FILE          = "TLS_CERT_FILE"
+		TLS_KEY_FILE           = "TLS_KEY_FILE"
+		BEHIND_TLS_PROXY       = "BEHIND_TLS_PROXY"
+		TLS_MIN_VERSION        = "TLS_MIN_VERSION"
+		HSTS_MAX_AGE_SECONDS   = "HSTS_MAX_AGE_SECONDS"
+		HSTS_SUBDOMAINS        = "HSTS_INCLUDE_SUBDOMAINS"
+		PANIC_RERAISE          = "PANIC_RERAISE"
+		TENANTS                = "TENANTS"
+		TENANT_MAX_DISTINCT    = "TENANT_MAX_DISTINCT"
+		WARMUP_ADDRESS         = "STARTUP_WARMUP_ADDRESS"
 	)
 
 	// =====================
@@ -62,27 +141,244 @@ func (c Config) NewInfraConfig() InfraConfig {
 		}
 	}
 
+	// =====================
+	// Environment Configuration Section
+	// =====================
+	input = os.Getenv(ENVIRONMENT)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, ENVIRONMENT)
+	} else {
+		switch input {
+		case ENV_DEVELOPMENT.ToString():
+			config.Environment = ENV_DEVELOPMENT
+		}
+	}
+
 	// =====================
 	// HTTPS Configuration Section
 	// =====================
+	// Required in production and not in development unless set, so local HTTP works out of the box
 	input = os.Getenv(REQUIRE_HTTPS)
 	if input == "" {
-		log.Printf(MissingEnvVarWarning, ENVIRONMENT)
+		config.IsHttpSecure = config.Environment != ENV_DEVELOPMENT
+		log.Printf(DefaultedEnvVarWarning, REQUIRE_HTTPS, strconv.FormatBool(config.IsHttpSecure))
+	} else {
+		config.IsHttpSecure = input != "false"
 	}
-	config.IsHttpSecure = os.Getenv(REQUIRE_HTTPS) != "false"
 
 	// =====================
-	// Environment Configuration Section
+	// Client IP Configuration Section
 	// =====================
-	input = os.Getenv(ENVIRONMENT)
+	// Proxies set different headers, e.g. CF-Connecting-IP,X-Real-IP,X-Forwarded-For, the first present wins.
+	// A single CLIENT_IP_HEADER is still accepted on its own.
+	input = os.Getenv(CLIENT_IP_HEADERS)
 	if input == "" {
-		log.Printf(MissingEnvVarWarning, ENVIRONMENT)
+		input = os.Getenv(CLIENT_IP_HEADER)
+	}
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, CLIENT_IP_HEADERS)
+	} else {
+		var headers []string
+		for _, header := range strings.Split(input, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				headers = append(headers, header)
+			}
+		}
+		if len(headers) > 0 {
+			config.ClientIPHeaders = headers
+		}
+	}
+
+	// The client IP header is only honored from these peers, everyone else could spoof it
+	input = os.Getenv(TRUSTED_PROXIES)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, TRUSTED_PROXIES)
+	} else {
+		for _, entry := range strings.Split(input, ",") {
+			prefix, err := ParsePrefix(strings.TrimSpace(entry))
+			if err != nil {
+				log.Printf(InvalidEnvVarErr+": %v", TRUSTED_PROXIES, err)
+				continue
+			}
+			config.TrustedProxies = append(config.TrustedProxies, prefix)
+		}
+	}
+
+	// =====================
+	// Tenant Configuration Section
+	// =====================
+	// Optional, requests without a known X-Tenant-ID are attributed to the anonymous tenant
+	input = os.Getenv(TENANTS)
+	if input != "" {
+		for _, tenant := range strings.Split(input, ",") {
+			if tenant = strings.TrimSpace(tenant); tenant != "" {
+				config.Tenants = append(config.Tenants, tenant)
+			}
+		}
+	}
+
+	// Bounds the metric series free-form tenant IDs can create, they never get rate limit keys of their own
+	input = os.Getenv(TENANT_MAX_DISTINCT)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, TENANT_MAX_DISTINCT)
+	} else if maxTenants, err := ParseInt(input); err != nil || maxTenants < 0 {
+		log.Printf(InvalidEnvVarErr, TENANT_MAX_DISTINCT)
+	} else {
+		config.MaxTenants = uint(maxTenants)
+	}
+
+	// =====================
+	// Server-Timing Configuration Section
+	// =====================
+	// Off by default, phase durations reveal backend behavior to the public
+	config.ServerTiming = os.Getenv(SERVER_TIMING) == "true"
+
+	// =====================
+	// Response Encoding Configuration Section
+	// =====================
+	input = os.Getenv(JSON_FIELD_CASE)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, JSON_FIELD_CASE)
 	} else {
 		switch input {
-		case ENV_DEVELOPMENT.ToString():
-			config.Environment = ENV_DEVELOPMENT
+		case JSON_CASE_CAMEL, JSON_CASE_SNAKE:
+			config.JSONFieldCase = input
+		default:
+			log.Printf(InvalidEnvVarErr, JSON_FIELD_CASE)
 		}
 	}
 
+	// =====================
+	// Inflight Configuration Section
+	// =====================
+	input = os.Getenv(MAX_INFLIGHT)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, MAX_INFLIGHT)
+	} else if maxInflight, err := ParseInt(input); err != nil || maxInflight <= 0 {
+		log.Printf(InvalidEnvVarErr, MAX_INFLIGHT)
+	} else {
+		config.MaxInflight = uint(maxInflight)
+	}
+
+	// =====================
+	// Request Timeout Configuration Section
+	// =====================
+	input = os.Getenv(MAX_REQUEST_TIMEOUT_MS)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, MAX_REQUEST_TIMEOUT_MS)
+	} else if ms, err := ParseInt(input); err != nil || ms <= 0 {
+		log.Printf(InvalidEnvVarErr, MAX_REQUEST_TIMEOUT_MS)
+	} else {
+		config.MaxRequestTimeout = time.Duration(ms) * time.Millisecond
+	}
+
+	// =====================
+	// Profiling Configuration Section
+	// =====================
+	// Always on in development, elsewhere it needs both the flag and a bearer token
+	config.EnablePprof = os.Getenv(ENABLE_PPROF) == "true"
+	config.PprofToken = os.Getenv(PPROF_TOKEN)
+	if config.EnablePprof && config.PprofToken == "" && config.Environment != ENV_DEVELOPMENT {
+		log.Printf(MissingRequiredEnvVarErr, PPROF_TOKEN)
+	}
+
+	// =====================
+	// Allowed Hosts Configuration Section
+	// =====================
+	// Optional, ports are ignored when matching so only hostnames are listed
+	input = os.Getenv(ALLOWED_HOSTS)
+	if input != "" {
+		for _, host := range strings.Split(input, ",") {
+			host = strings.ToLower(strings.TrimSpace(host))
+			if host == "" || strings.ContainsAny(host, "/ ") {
+				log.Printf(InvalidEnvVarErr+": %q", ALLOWED_HOSTS, host)
+				continue
+			}
+			config.AllowedHosts = append(config.AllowedHosts, host)
+		}
+	}
+
+	// =====================
+	// Region Inference Configuration Section
+	// =====================
+	// Off by default, requests without a region use MAP_COUNTRY
+	config.RegionFromLanguage = os.Getenv(REGION_FROM_LANGUAGE) == "true"
+
+	// =====================
+	// Debug Response Configuration Section
+	// =====================
+	// Optional, raw provider responses are only returned to requests bearing this token
+	config.DebugToken = os.Getenv(DEBUG_TOKEN)
+
+	// =====================
+	// TLS Configuration Section
+	// =====================
+	// Either the server terminates TLS with a certificate or a proxy in front of it does
+	config.TLSCertFile = os.Getenv(TLS_CERT_FILE)
+	config.TLSKeyFile = os.Getenv(TLS_KEY_FILE)
+	config.BehindTLSProxy = os.Getenv(BEHIND_TLS_PROXY) == "true"
+
+	input = os.Getenv(TLS_MIN_VERSION)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, TLS_MIN_VERSION)
+	} else {
+		switch input {
+		case "1.2":
+			config.TLSMinVersion = tls.VersionTLS12
+		case "1.3":
+			config.TLSMinVersion = tls.VersionTLS13
+		default:
+			log.Printf(InvalidEnvVarErr+": %q", TLS_MIN_VERSION, input)
+		}
+	}
+
+	// =====================
+	// HSTS Configuration Section
+	// =====================
+	// Only sent over HTTPS, browsers ignore it on plain HTTP
+	input = os.Getenv(HSTS_MAX_AGE_SECONDS)
+	if input == "" {
+		log.Printf(MissingEnvVarWarning, HSTS_MAX_AGE_SECONDS)
+	} else if seconds, err := ParseInt(input); err != nil || seconds < 0 {
+		log.Printf(InvalidEnvVarErr, HSTS_MAX_AGE_SECONDS)
+	} else {
+		config.HSTSMaxAge = time.Duration(seconds) * time.Second
+	}
+	config.HSTSIncludeSubdomains = os.Getenv(HSTS_SUBDOMAINS) == "true"
+
+	// =====================
+	// Startup Configuration Section
+	// =====================
+	// Confirms the provider answers before traffic is routed to the instance. Each attempt is a billed call
+	if input := strings.TrimSpace(os.Getenv(WARMUP_ADDRESS)); input != "" {
+		config.WarmupAddress = input
+	}
+
+	// =====================
+	// Panic Recovery Configuration Section
+	// =====================
+	// Lets a debugger or the race detector see the panic, production always answers with a 500
+	config.PanicReraise = os.Getenv(PANIC_RERAISE) == "true"
+	if config.PanicReraise && config.Environment != ENV_DEVELOPMENT {
+		log.Printf("%s is ignored outside %s", PANIC_RERAISE, ENV_DEVELOPMENT.ToString())
+	}
+
 	return config
 }
+
+// TLSConfig returns the TLS settings of a server terminating TLS itself
+func (c InfraConfig) TLSConfig() *tls.Config {
+	return &tls.Config{MinVersion: c.TLSMinVersion}
+}
+
+// ValidateTLS checks the server can receive HTTPS requests at all when HTTPS is required.
+// Without a certificate or a terminating proxy every request would be rejected.
+func (c InfraConfig) ValidateTLS() error {
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if c.IsHttpSecure && c.TLSCertFile == "" && !c.BehindTLSProxy {
+		return fmt.Errorf("REQUIRE_HTTPS is on but neither TLS_CERT_FILE nor BEHIND_TLS_PROXY is set, every request would be rejected")
+	}
+	return nil
+}
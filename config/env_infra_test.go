@@ -2,8 +2,11 @@ package config_test
 
 import (
 	"address-validator/config"
+	"crypto/tls"
+	"net/netip"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestEnvironment_ToString(t *testing.T) {
@@ -26,9 +29,23 @@ func TestEnvironment_ToString(t *testing.T) {
 
 func TestConfig_NewInfraConfig(t *testing.T) {
 	const (
-		PORT          = "PORT"
-		ENVIRONMENT   = "ENVIRONMENT"
-		REQUIRE_HTTPS = "REQUIRE_HTTPS"
+		PORT                   = "PORT"
+		ENVIRONMENT            = "ENVIRONMENT"
+		REQUIRE_HTTPS          = "REQUIRE_HTTPS"
+		CLIENT_IP_HEADER       = "CLIENT_IP_HEADER"
+		CLIENT_IP_HEADERS      = "CLIENT_IP_HEADERS"
+		TRUSTED_PROXIES        = "TRUSTED_PROXIES"
+		JSON_FIELD_CASE        = "JSON_FIELD_CASE"
+		MAX_INFLIGHT           = "MAX_INFLIGHT"
+		ENABLE_PPROF           = "ENABLE_PPROF"
+		PPROF_TOKEN            = "PPROF_TOKEN"
+		MAX_REQUEST_TIMEOUT_MS = "MAX_REQUEST_TIMEOUT_MS"
+		TLS_MIN_VERSION        = "TLS_MIN_VERSION"
+		HSTS_MAX_AGE_SECONDS   = "HSTS_MAX_AGE_SECONDS"
+		HSTS_SUBDOMAINS        = "HSTS_INCLUDE_SUBDOMAINS"
+		PANIC_RERAISE          = "PANIC_RERAISE"
+		TENANTS                = "TENANTS"
+		TENANT_MAX_DISTINCT    = "TENANT_MAX_DISTINCT"
 	)
 
 	tests := []struct {
@@ -39,90 +56,536 @@ func TestConfig_NewInfraConfig(t *testing.T) {
 		{
 			name: "Test Empty Environment Variables Returns Default Config",
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Reserved Port at 0 Returns 8080",
 			env:  [][2]string{{PORT, "0"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Blocked Port at 65535 Returns 8080",
 			env:  [][2]string{{PORT, "65535"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Priviledged Port (1-1023) Returns 8080",
 			env:  [][2]string{{PORT, "1023"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Invalid Uint16 Returns Default",
 			env:  [][2]string{{PORT, "add_port"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Allowed Port Returns Port",
 			env:  [][2]string{{PORT, "3000"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         3000,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              3000,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Not HttpSecure Returns False",
 			env:  [][2]string{{REQUIRE_HTTPS, "false"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: false,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      false,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Invalid HttpSecure Returns True",
 			env:  [][2]string{{REQUIRE_HTTPS, "FALSE"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test Invalid Environment Returns PRODUCTION",
 			env:  [][2]string{{ENVIRONMENT, "UAT"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_PRODUCTION,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 		{
 			name: "Test DEVELOPMENT Returns ENV_DEVELOPMENT",
 			env:  [][2]string{{ENVIRONMENT, "DEVELOPMENT"}},
 			want: config.InfraConfig{
-				Environment:  config.ENV_DEVELOPMENT,
-				Port:         8080,
-				IsHttpSecure: true,
+				Environment:       config.ENV_DEVELOPMENT,
+				Port:              8080,
+				IsHttpSecure:      false,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test PRODUCTION Requires HTTPS By Default",
+			env:  [][2]string{{ENVIRONMENT, "PRODUCTION"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test PRODUCTION With REQUIRE_HTTPS false Returns Insecure",
+			env:  [][2]string{{ENVIRONMENT, "PRODUCTION"}, {REQUIRE_HTTPS, "false"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      false,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test DEVELOPMENT With REQUIRE_HTTPS true Returns Secure",
+			env:  [][2]string{{ENVIRONMENT, "DEVELOPMENT"}, {REQUIRE_HTTPS, "true"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_DEVELOPMENT,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Custom Client IP Header Returns Header",
+			env:  [][2]string{{CLIENT_IP_HEADER, "CF-Connecting-IP"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"CF-Connecting-IP"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Client IP Headers Returns Ordered Headers",
+			env:  [][2]string{{CLIENT_IP_HEADERS, "CF-Connecting-IP, X-Real-IP,,X-Forwarded-For"}, {CLIENT_IP_HEADER, "True-Client-IP"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"CF-Connecting-IP", "X-Real-IP", "X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Trusted Proxies Returns CIDRs And Single IPs",
+			env:  [][2]string{{TRUSTED_PROXIES, "10.0.0.0/8, 192.168.1.1,invalid"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				TrustedProxies: []netip.Prefix{
+					netip.MustParsePrefix("10.0.0.0/8"),
+					netip.MustParsePrefix("192.168.1.1/32"),
+				},
+				MaxTenants:    100,
+				WarmupAddress: config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Snake Case Returns Snake Case",
+			env:  [][2]string{{JSON_FIELD_CASE, "snake"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_SNAKE,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Invalid Field Case Returns Camel Case",
+			env:  [][2]string{{JSON_FIELD_CASE, "kebab"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Max Inflight Returns Max Inflight",
+			env:  [][2]string{{MAX_INFLIGHT, "8"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       8,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Zero Max Inflight Returns Default",
+			env:  [][2]string{{MAX_INFLIGHT, "0"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Pprof Returns Flag And Token",
+			env:  [][2]string{{ENABLE_PPROF, "true"}, {PPROF_TOKEN, "secret"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				EnablePprof:       true,
+				PprofToken:        "secret",
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Max Request Timeout Returns Timeout",
+			env:  [][2]string{{MAX_REQUEST_TIMEOUT_MS, "2500"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 2500 * time.Millisecond,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Invalid Max Request Timeout Returns Default",
+			env:  [][2]string{{MAX_REQUEST_TIMEOUT_MS, "-5"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Max Request Timeout Returns Timeout",
+			env:  [][2]string{{MAX_REQUEST_TIMEOUT_MS, "2500"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 2500 * time.Millisecond,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Invalid Max Request Timeout Returns Default",
+			env:  [][2]string{{MAX_REQUEST_TIMEOUT_MS, "-5"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test TLS 1.3 Returns Minimum Version",
+			env:  [][2]string{{TLS_MIN_VERSION, "1.3"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS13,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Invalid TLS Version Returns Default",
+			env:  [][2]string{{TLS_MIN_VERSION, "1.0"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test HSTS Returns Max Age And Subdomains",
+			env:  [][2]string{{HSTS_MAX_AGE_SECONDS, "600"}, {HSTS_SUBDOMAINS, "true"}},
+			want: config.InfraConfig{
+				Environment:           config.ENV_PRODUCTION,
+				Port:                  8080,
+				IsHttpSecure:          true,
+				ClientIPHeaders:       []string{"X-Forwarded-For"},
+				JSONFieldCase:         config.JSON_CASE_CAMEL,
+				MaxInflight:           100,
+				MaxRequestTimeout:     10 * time.Second,
+				TLSMinVersion:         tls.VersionTLS12,
+				HSTSMaxAge:            10 * time.Minute,
+				HSTSIncludeSubdomains: true,
+				MaxTenants:            100,
+				WarmupAddress:         config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Panic Reraise Is Read In Development",
+			env:  [][2]string{{ENVIRONMENT, "DEVELOPMENT"}, {PANIC_RERAISE, "true"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_DEVELOPMENT,
+				Port:              8080,
+				IsHttpSecure:      false,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				PanicReraise:      true,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Tenants Are Trimmed And Split",
+			env:  [][2]string{{TENANTS, " acme, ,globex "}, {TENANT_MAX_DISTINCT, "10"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				Tenants:           []string{"acme", "globex"},
+				MaxTenants:        10,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
+			},
+		},
+		{
+			name: "Test Invalid Tenant Max Distinct Keeps Default",
+			env:  [][2]string{{TENANT_MAX_DISTINCT, "many"}},
+			want: config.InfraConfig{
+				Environment:       config.ENV_PRODUCTION,
+				Port:              8080,
+				IsHttpSecure:      true,
+				ClientIPHeaders:   []string{"X-Forwarded-For"},
+				JSONFieldCase:     config.JSON_CASE_CAMEL,
+				MaxInflight:       100,
+				MaxRequestTimeout: 10 * time.Second,
+				TLSMinVersion:     tls.VersionTLS12,
+				HSTSMaxAge:        365 * 24 * time.Hour,
+				MaxTenants:        100,
+				WarmupAddress:     config.DEFAULT_WARMUP_ADDRESS,
 			},
 		},
 	}
@@ -139,3 +602,41 @@ func TestConfig_NewInfraConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestInfraConfig_ValidateTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  config.InfraConfig
+		wantErr bool
+	}{
+		{
+			name:    "Test HTTPS Required Without TLS Is Contradictory",
+			config:  config.InfraConfig{IsHttpSecure: true},
+			wantErr: true,
+		},
+		{
+			name:   "Test HTTPS Required With Certificate Is Valid",
+			config: config.InfraConfig{IsHttpSecure: true, TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"},
+		},
+		{
+			name:   "Test HTTPS Required Behind TLS Proxy Is Valid",
+			config: config.InfraConfig{IsHttpSecure: true, BehindTLSProxy: true},
+		},
+		{
+			name:   "Test HTTPS Not Required Without TLS Is Valid",
+			config: config.InfraConfig{},
+		},
+		{
+			name:    "Test Certificate Without Key Is Invalid",
+			config:  config.InfraConfig{TLSCertFile: "cert.pem"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.ValidateTLS(); (err != nil) != tt.wantErr {
+				t.Errorf("InfraConfig.ValidateTLS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -13,6 +14,13 @@ func (c Config) NewLoggerConfig(environment Environment) LoggerConfig {
 		ENCODING    = "ENCODING"
 		OUTPUT_PATH = "OUTPUT_PATH"
 		ERROR_PATH  = "ERROR_PATH"
+
+		LOG_MAX_SIZE_MB  = "LOG_MAX_SIZE_MB"
+		LOG_MAX_BACKUPS  = "LOG_MAX_BACKUPS"
+		LOG_MAX_AGE_DAYS = "LOG_MAX_AGE_DAYS"
+		LOG_COMPRESS     = "LOG_COMPRESS"
+
+		DEBUG_SAMPLE_RATE = "DEBUG_SAMPLE_RATE"
 	)
 
 	config := LoggerConfig{
@@ -71,6 +79,37 @@ func (c Config) NewLoggerConfig(environment Environment) LoggerConfig {
 	setPath(&config.OutputPath, OUTPUT_PATH)
 	setPath(&config.ErrorPath, ERROR_PATH)
 
+	// Rotation settings are optional and only apply to file outputs
+	setCount := func(count *int, ENV_VAR string) {
+		input := os.Getenv(ENV_VAR)
+		if input == "" {
+			return
+		}
+
+		num, err := strconv.Atoi(input)
+		if err != nil || num < 0 {
+			log.Printf(InvalidEnvVarErr, ENV_VAR)
+			return
+		}
+		*count = num
+	}
+
+	setCount(&config.MaxSizeMB, LOG_MAX_SIZE_MB)
+	setCount(&config.MaxBackups, LOG_MAX_BACKUPS)
+	setCount(&config.MaxAgeDays, LOG_MAX_AGE_DAYS)
+	config.Compress = os.Getenv(LOG_COMPRESS) == "true"
+
+	// Optional, the fraction of requests logged at debug level regardless of LEVEL
+	input = os.Getenv(DEBUG_SAMPLE_RATE)
+	if input != "" {
+		rate, err := strconv.ParseFloat(input, 64)
+		if err != nil || !(rate >= 0 && rate <= 1) {
+			log.Printf(InvalidEnvVarErr, DEBUG_SAMPLE_RATE)
+		} else {
+			config.DebugSampleRate = rate
+		}
+	}
+
 	if environment != ENV_PRODUCTION {
 		config.IsDevelopment = true
 	}
@@ -12,6 +12,11 @@ func TestConfig_NewLoggerConfig(t *testing.T) {
 		ENCODING    = "ENCODING"
 		OUTPUT_PATH = "OUTPUT_PATH"
 		ERROR_PATH  = "ERROR_PATH"
+
+		LOG_MAX_SIZE_MB  = "LOG_MAX_SIZE_MB"
+		LOG_MAX_BACKUPS  = "LOG_MAX_BACKUPS"
+		LOG_MAX_AGE_DAYS = "LOG_MAX_AGE_DAYS"
+		LOG_COMPRESS     = "LOG_COMPRESS"
 	)
 
 	type args struct {
@@ -165,6 +170,32 @@ func TestConfig_NewLoggerConfig(t *testing.T) {
 				IsDevelopment: false,
 			},
 		},
+		{
+			name: "Test Rotation Settings Returns Values",
+			env:  [][2]string{{LOG_MAX_SIZE_MB, "100"}, {LOG_MAX_BACKUPS, "5"}, {LOG_MAX_AGE_DAYS, "30"}, {LOG_COMPRESS, "true"}},
+			want: config.LoggerConfig{
+				Level:         "info",
+				Encoding:      "json",
+				OutputPath:    "stdout",
+				ErrorPath:     "stderr",
+				IsDevelopment: false,
+				MaxSizeMB:     100,
+				MaxBackups:    5,
+				MaxAgeDays:    30,
+				Compress:      true,
+			},
+		},
+		{
+			name: "Test Invalid Rotation Settings Returns Default",
+			env:  [][2]string{{LOG_MAX_SIZE_MB, "-1"}, {LOG_MAX_BACKUPS, "five"}, {LOG_MAX_AGE_DAYS, "1.5"}},
+			want: config.LoggerConfig{
+				Level:         "info",
+				Encoding:      "json",
+				OutputPath:    "stdout",
+				ErrorPath:     "stderr",
+				IsDevelopment: false,
+			},
+		},
 		{
 			name: "Test Development Returns True",
 			args: args{environment: config.ENV_DEVELOPMENT},
@@ -2,52 +2,234 @@ package config
 
 import (
 	"address-validator/ports"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 type MapConfig struct {
+	Provider         string
+	MockFixturesPath string
 	GoogleMapsAPIKey string
-	MaxDistance      float64
-	DistanceUnit     string
-	CenterLat        float64
-	CenterLng        float64
-	Country          string
-	Locality         string
+	SmartyAuthID     string
+	SmartyAuthToken  string
+	// GoogleMapsAPIKeys are rotated per request, GoogleMapsAPIKey is the first of them
+	GoogleMapsAPIKeys []string
+	APIKeyCooldown    time.Duration
+	MaxDistance       float64
+	// GeofenceInclusive treats an address exactly at MaxDistance as in range
+	GeofenceInclusive  bool
+	DistanceUnit       string
+	CenterLat          float64
+	CenterLng          float64
+	Country            string
+	Locality           string
+	DailyGeocodeBudget uint
+	EnableUSPSCass     bool
+	// GeocodeResultTypes and GeocodeLocationTypes restrict reverse geocoding results, empty allows any
+	GeocodeResultTypes   []string
+	GeocodeLocationTypes []string
+	// ProviderTimeout bounds each upstream HTTP call, ProviderMaxRetries repeats timed out or 5xx calls
+	ProviderTimeout    time.Duration
+	ProviderMaxRetries int
+	// GeofenceUncertainty is the distance from MaxDistance within which an approximate geocode is ambiguous, 0 disables
+	GeofenceUncertainty float64
+	// MaxDriveTime replaces the straight-line geofence with a drive-time one from the center, 0 disables
+	MaxDriveTime time.Duration
+	// MaxElevation is the highest elevation in range in meters, 0 disables the elevation lookup
+	MaxElevation float64
+	// PremiumZoneDistance is the radius in DistanceUnit of the advisory premium zone around its center,
+	// reported apart from the in-range verdict, 0 disables the zone
+	PremiumZoneDistance float64
+	PremiumZoneLat      float64
+	PremiumZoneLng      float64
+	// GeofenceHardBias rejects geocoding results outside the geofence bounds instead of only preferring those inside
+	GeofenceHardBias bool
 }
 
+// NewMapConfig parses the map configuration, exiting when a required variable is missing or invalid.
+// LoadAll reports the same problems as errors instead.
 func (c Config) NewMapConfig(logger *zap.Logger) MapConfig {
+	config, errs := c.parseMapConfig(logger)
+	for _, err := range errs {
+		logger.Error(err.Error())
+	}
+	if len(errs) > 0 {
+		logger.Fatal("invalid map configuration", zap.Int("errors", len(errs)))
+	}
+	return config
+}
+
+// ReloadMapConfig re-reads the .env file and CONFIG_FILE and parses the map configuration again
+// for a geofence reload, returning the problems instead of exiting. As at startup the environment
+// takes precedence, the files only replace the values they supplied before.
+func (c Config) ReloadMapConfig(logger *zap.Logger) (MapConfig, []error) {
+	const CONFIG_FILE = "CONFIG_FILE"
+
+	var errs []error
+	clearFileValues()
+	// The .env file is optional here, the service may be configured by the environment alone
+	if err := loadDotEnv(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		errs = append(errs, fmt.Errorf("failed to reload .env file: %w", err))
+	}
+	if path := os.Getenv(CONFIG_FILE); path != "" {
+		if err := LoadConfigFile(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	config, mapErrs := c.parseMapConfig(logger)
+	return config, append(errs, mapErrs...)
+}
+
+// ValidateGeofence checks the geofence fields for values no reload or startup can fall back from
+func (m MapConfig) ValidateGeofence() []error {
+	var errs []error
+
+	if m.CenterLat < -90 || m.CenterLat > 90 {
+		errs = append(errs, fmt.Errorf("MAP_CENTER_LAT %v is outside -90 to 90", m.CenterLat))
+	}
+	if m.CenterLng < -180 || m.CenterLng > 180 {
+		errs = append(errs, fmt.Errorf("MAP_CENTER_LNG %v is outside -180 to 180", m.CenterLng))
+	}
+	if m.MaxDistance <= 0 {
+		errs = append(errs, fmt.Errorf(NegativeValueErr, "MAP_MAX_DISTANCE"))
+	}
+	if m.PremiumZoneDistance > 0 && (m.PremiumZoneLat < -90 || m.PremiumZoneLat > 90) {
+		errs = append(errs, fmt.Errorf("PREMIUM_ZONE_CENTER_LAT %v is outside -90 to 90", m.PremiumZoneLat))
+	}
+	if m.PremiumZoneDistance > 0 && (m.PremiumZoneLng < -180 || m.PremiumZoneLng > 180) {
+		errs = append(errs, fmt.Errorf("PREMIUM_ZONE_CENTER_LNG %v is outside -180 to 180", m.PremiumZoneLng))
+	}
+	if m.DistanceUnit != ports.DISTANCE_KILOMETER && m.DistanceUnit != ports.DISTANCE_MILES {
+		errs = append(errs, fmt.Errorf("MAP_DISTANCE_UNIT %q must be %s or %s", m.DistanceUnit, ports.DISTANCE_KILOMETER, ports.DISTANCE_MILES))
+	}
+
+	return errs
+}
+
+// parseMapConfig parses the map configuration, returning every required variable that is missing or invalid
+func (c Config) parseMapConfig(logger *zap.Logger) (MapConfig, []error) {
+	var errs []error
+
 	const (
-		GOOGLE_MAPS_API_KEY = "GOOGLE_MAPS_API_KEY"
-		MAPS_MAX_DISTANCE   = "MAP_MAX_DISTANCE"
-		MAPS_DISTANCE_UNIT  = "MAP_DISTANCE_UNIT"
-		MAPS_CENTER_LAT     = "MAP_CENTER_LAT"
-		MAPS_CENTER_LNG     = "MAP_CENTER_LNG"
-		MAPS_COUNTRY        = "MAP_COUNTRY"
-		MAPS_LOCALITY       = "MAP_LOCALITY"
+		PROVIDER             = "PROVIDER"
+		MOCK_FIXTURES_PATH   = "MOCK_FIXTURES_PATH"
+		GOOGLE_MAPS_API_KEY  = "GOOGLE_MAPS_API_KEY"
+		GOOGLE_MAPS_API_KEYS = "GOOGLE_MAPS_API_KEYS"
+		SMARTY_AUTH_ID       = "SMARTY_AUTH_ID"
+		SMARTY_AUTH_TOKEN    = "SMARTY_AUTH_TOKEN"
+		API_KEY_COOLDOWN     = "GOOGLE_MAPS_KEY_COOLDOWN_SECONDS"
+		MAPS_MAX_DISTANCE    = "MAP_MAX_DISTANCE"
+		MAPS_DISTANCE_UNIT   = "MAP_DISTANCE_UNIT"
+		MAPS_CENTER_LAT      = "MAP_CENTER_LAT"
+		MAPS_CENTER_LNG      = "MAP_CENTER_LNG"
+		MAPS_COUNTRY         = "MAP_COUNTRY"
+		MAPS_LOCALITY        = "MAP_LOCALITY"
+		DAILY_GEOCODE_BUDGET = "DAILY_GEOCODE_BUDGET"
+		ENABLE_USPS_CASS     = "ENABLE_USPS_CASS"
+		GEOFENCE_INCLUSIVE   = "GEOFENCE_BOUNDARY_INCLUSIVE"
+		GEOFENCE_UNCERTAINTY = "GEOFENCE_UNCERTAINTY_MARGIN"
+		GEOFENCE_HARD_BIAS   = "GEOFENCE_HARD_BIAS"
+		GEOCODE_RESULT_TYPES = "GEOCODE_RESULT_TYPES"
+		GEOCODE_LOCATIONS    = "GEOCODE_LOCATION_TYPES"
+		PROVIDER_TIMEOUT_MS  = "PROVIDER_TIMEOUT_MS"
+		PROVIDER_MAX_RETRIES = "PROVIDER_MAX_RETRIES"
+		MAX_DRIVE_MINUTES    = "MAX_DRIVE_MINUTES"
+		MAX_ELEVATION        = "MAX_ELEVATION"
+		PREMIUM_DISTANCE     = "PREMIUM_ZONE_MAX_DISTANCE"
+		PREMIUM_LAT          = "PREMIUM_ZONE_CENTER_LAT"
+		PREMIUM_LNG          = "PREMIUM_ZONE_CENTER_LNG"
 	)
 
 	config := MapConfig{
-		MaxDistance:  2,
-		DistanceUnit: ports.DISTANCE_MILES,
-		Country:      "us",
-		Locality:     "Bronx",
+		Provider:          ports.PROVIDER_GOOGLE,
+		MaxDistance:       2,
+		GeofenceInclusive: true,
+		DistanceUnit:      ports.DISTANCE_MILES,
+		Country:           "us",
+		// Long enough for a per-minute quota window to reset
+		APIKeyCooldown:  60 * time.Second,
+		ProviderTimeout: 10 * time.Second,
+	}
+
+	// =====================
+	// Provider Section
+	// =====================
+	input := os.Getenv(PROVIDER)
+	if input == "" {
+		message := fmt.Sprintf(MissingEnvVarWarning, PROVIDER)
+		logger.Warn(message)
+	} else {
+		switch input {
+		case ports.PROVIDER_GOOGLE, ports.PROVIDER_GEOCODING, ports.PROVIDER_MOCK, ports.PROVIDER_SMARTY:
+			config.Provider = input
+		default:
+			errs = append(errs, fmt.Errorf(InvalidEnvVarErr+": %q", PROVIDER, input))
+		}
 	}
 
 	// =====================
 	// Google Maps API Key Section
 	// =====================
-	config.GoogleMapsAPIKey = os.Getenv(GOOGLE_MAPS_API_KEY)
-	if config.GoogleMapsAPIKey == "" {
-		message := fmt.Sprintf(MissingRequiredEnvVarErr, GOOGLE_MAPS_API_KEY)
-		logger.Fatal(message)
+	// The other providers need no Google key, so it is only required for Google
+	// Several keys spread quota across projects, a single key is still accepted on its own
+	for _, key := range strings.Split(os.Getenv(GOOGLE_MAPS_API_KEYS), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			config.GoogleMapsAPIKeys = append(config.GoogleMapsAPIKeys, key)
+		}
+	}
+	if len(config.GoogleMapsAPIKeys) == 0 {
+		if key := os.Getenv(GOOGLE_MAPS_API_KEY); key != "" {
+			config.GoogleMapsAPIKeys = []string{key}
+		}
+	}
+	if len(config.GoogleMapsAPIKeys) > 0 {
+		config.GoogleMapsAPIKey = config.GoogleMapsAPIKeys[0]
+	}
+	if config.GoogleMapsAPIKey == "" && (config.Provider == ports.PROVIDER_GOOGLE || config.Provider == ports.PROVIDER_GEOCODING) {
+		errs = append(errs, fmt.Errorf(MissingRequiredEnvVarErr, GOOGLE_MAPS_API_KEY))
+	}
+
+	// How long a rate-limited key is skipped before it is tried again
+	input = os.Getenv(API_KEY_COOLDOWN)
+	if input != "" {
+		if seconds, err := strconv.Atoi(input); err == nil && seconds >= 0 {
+			config.APIKeyCooldown = time.Duration(seconds) * time.Second
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, API_KEY_COOLDOWN)
+			logger.Warn(message, zap.String("input", input))
+		}
+	}
+
+	// =====================
+	// SmartyStreets Credentials Section
+	// =====================
+	config.SmartyAuthID = os.Getenv(SMARTY_AUTH_ID)
+	config.SmartyAuthToken = os.Getenv(SMARTY_AUTH_TOKEN)
+	if config.Provider == ports.PROVIDER_SMARTY {
+		if config.SmartyAuthID == "" {
+			errs = append(errs, fmt.Errorf(MissingRequiredEnvVarErr, SMARTY_AUTH_ID))
+		}
+		if config.SmartyAuthToken == "" {
+			errs = append(errs, fmt.Errorf(MissingRequiredEnvVarErr, SMARTY_AUTH_TOKEN))
+		}
+	}
+
+	config.MockFixturesPath = os.Getenv(MOCK_FIXTURES_PATH)
+	if config.MockFixturesPath == "" && config.Provider == ports.PROVIDER_MOCK {
+		message := fmt.Sprintf(MissingEnvVarWarning, MOCK_FIXTURES_PATH)
+		logger.Warn(message)
 	}
 
 	// Get geofencing configuration or use defaults
-	input := os.Getenv(MAPS_MAX_DISTANCE)
+	input = os.Getenv(MAPS_MAX_DISTANCE)
 	if input == "" {
 		message := fmt.Sprintf(MissingEnvVarWarning, MAPS_MAX_DISTANCE)
 		logger.Error(message)
@@ -55,10 +237,50 @@ func (c Config) NewMapConfig(logger *zap.Logger) MapConfig {
 		config.MaxDistance = maxDistance
 	}
 
+	// On unless explicitly disabled, false makes the boundary itself out of range
+	config.GeofenceInclusive = os.Getenv(GEOFENCE_INCLUSIVE) != "false"
+
+	// Geocoding requests always prefer results within the geofence, this also drops the others
+	config.GeofenceHardBias = os.Getenv(GEOFENCE_HARD_BIAS) == "true"
+
+	// In MAP_DISTANCE_UNIT, an approximate geocode this close to the boundary cannot be trusted either way
+	input = os.Getenv(GEOFENCE_UNCERTAINTY)
+	if input != "" {
+		if margin, err := strconv.ParseFloat(input, 64); err == nil && margin >= 0 {
+			config.GeofenceUncertainty = margin
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, GEOFENCE_UNCERTAINTY)
+			logger.Warn(message, zap.String("input", input))
+		}
+	}
+
+	// Drive time needs the Distance Matrix API, straight-line distance is used whenever it is unavailable
+	input = os.Getenv(MAX_DRIVE_MINUTES)
+	if input != "" {
+		if minutes, err := strconv.ParseFloat(input, 64); err == nil && minutes > 0 {
+			config.MaxDriveTime = time.Duration(minutes * float64(time.Minute))
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, MAX_DRIVE_MINUTES)
+			logger.Warn(message, zap.String("input", input))
+		}
+	}
+
+	// In meters, each in range address then costs an Elevation API call
+	input = os.Getenv(MAX_ELEVATION)
+	if input != "" {
+		if meters, err := strconv.ParseFloat(input, 64); err == nil && meters > 0 {
+			config.MaxElevation = meters
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, MAX_ELEVATION)
+			logger.Warn(message, zap.String("input", input))
+		}
+	}
+
+	// MAP_MAX_DISTANCE and the uncertainty margin are read in this unit, so name the default when it is used
 	input = os.Getenv(MAPS_DISTANCE_UNIT)
 	if input == "" {
-		message := fmt.Sprintf(MissingEnvVarWarning, MAPS_DISTANCE_UNIT)
-		logger.Warn(message)
+		message := fmt.Sprintf(DefaultedEnvVarWarning, MAPS_DISTANCE_UNIT, config.DistanceUnit)
+		logger.Warn(message, zap.String("unit", config.DistanceUnit))
 	} else {
 		switch input {
 		case ports.DISTANCE_KILOMETER:
@@ -67,37 +289,131 @@ func (c Config) NewMapConfig(logger *zap.Logger) MapConfig {
 			config.DistanceUnit = input
 		default:
 			message := fmt.Sprintf(InvalidEnvVarErr, MAPS_DISTANCE_UNIT)
-			logger.Warn(message)
+			logger.Warn(message, zap.String("input", input), zap.String("unit", config.DistanceUnit))
 		}
 	}
 
 	input = os.Getenv(MAPS_CENTER_LAT)
 	if input == "" {
-		message := fmt.Sprintf(MissingRequiredEnvVarErr, MAPS_CENTER_LAT)
-		logger.Fatal(message)
-	}
-
-	if val, err := strconv.ParseFloat(input, 64); err == nil {
+		errs = append(errs, fmt.Errorf(MissingRequiredEnvVarErr, MAPS_CENTER_LAT))
+	} else if val, err := strconv.ParseFloat(input, 64); err == nil {
 		config.CenterLat = val
 	} else {
-		message := fmt.Sprintf(InvalidEnvVarErr, MAPS_CENTER_LAT)
-		logger.Fatal(message, zap.Error(err))
+		errs = append(errs, fmt.Errorf(InvalidEnvVarErr+": %w", MAPS_CENTER_LAT, err))
 	}
 
 	input = os.Getenv(MAPS_CENTER_LNG)
 	if input == "" {
-		message := fmt.Sprintf(MissingRequiredEnvVarErr, MAPS_CENTER_LNG)
-		logger.Fatal(message)
+		errs = append(errs, fmt.Errorf(MissingRequiredEnvVarErr, MAPS_CENTER_LNG))
+	} else if val, err := strconv.ParseFloat(input, 64); err == nil {
+		config.CenterLng = val
+	} else {
+		errs = append(errs, fmt.Errorf(InvalidEnvVarErr+": %w", MAPS_CENTER_LNG, err))
 	}
 
-	if val, err := strconv.ParseFloat(input, 64); err == nil {
-		config.CenterLng = val
+	// =====================
+	// Premium Zone Section
+	// =====================
+	// Optional and advisory, it never changes the in-range verdict. Without its own center the zone
+	// shares the geofence center, e.g. a smaller radius around the same store.
+	input = os.Getenv(PREMIUM_DISTANCE)
+	if input != "" {
+		if distance, err := strconv.ParseFloat(input, 64); err == nil && distance > 0 {
+			config.PremiumZoneDistance = distance
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, PREMIUM_DISTANCE)
+			logger.Warn(message, zap.String("input", input))
+		}
+	}
+	config.PremiumZoneLat = config.CenterLat
+	config.PremiumZoneLng = config.CenterLng
+	input = os.Getenv(PREMIUM_LAT)
+	if input != "" {
+		if val, err := strconv.ParseFloat(input, 64); err == nil {
+			config.PremiumZoneLat = val
+		} else {
+			errs = append(errs, fmt.Errorf(InvalidEnvVarErr+": %w", PREMIUM_LAT, err))
+		}
+	}
+
+	input = os.Getenv(PREMIUM_LNG)
+	if input != "" {
+		if val, err := strconv.ParseFloat(input, 64); err == nil {
+			config.PremiumZoneLng = val
+		} else {
+			errs = append(errs, fmt.Errorf(InvalidEnvVarErr+": %w", PREMIUM_LNG, err))
+		}
+	}
+
+	input = os.Getenv(DAILY_GEOCODE_BUDGET)
+	if input == "" {
+		message := fmt.Sprintf(MissingEnvVarWarning, DAILY_GEOCODE_BUDGET)
+		logger.Warn(message)
+	} else if budget, err := strconv.Atoi(input); err == nil && budget >= 0 {
+		config.DailyGeocodeBudget = uint(budget)
 	} else {
-		message := fmt.Sprintf(InvalidEnvVarErr, MAPS_CENTER_LNG)
-		logger.Fatal(message, zap.Error(err))
+		message := fmt.Sprintf(InvalidEnvVarErr, DAILY_GEOCODE_BUDGET)
+		logger.Warn(message, zap.String("input", input))
 	}
 
-	logger.Debug("Defined Map Configuration", zap.Any("config", config))
+	// Optional, the locality sent with addresses that do not name one. Unset sends none
+	config.Locality = strings.TrimSpace(os.Getenv(MAPS_LOCALITY))
 
-	return config
+	// Only applied when the country is US
+	config.EnableUSPSCass = os.Getenv(ENABLE_USPS_CASS) == "true"
+
+	// =====================
+	// Geocoding Filter Section
+	// =====================
+	// Optional, e.g. street_address,premise to refuse locality or region level matches
+	for _, resultType := range strings.Split(os.Getenv(GEOCODE_RESULT_TYPES), ",") {
+		if resultType = strings.ToLower(strings.TrimSpace(resultType)); resultType != "" {
+			config.GeocodeResultTypes = append(config.GeocodeResultTypes, resultType)
+		}
+	}
+
+	for _, locationType := range strings.Split(os.Getenv(GEOCODE_LOCATIONS), ",") {
+		locationType = strings.ToUpper(strings.TrimSpace(locationType))
+		switch locationType {
+		case "":
+		case ports.LOCATION_TYPE_ROOFTOP, ports.LOCATION_TYPE_RANGE_INTERPOLATED,
+			ports.LOCATION_TYPE_GEOMETRIC_CENTER, ports.LOCATION_TYPE_APPROXIMATE:
+			config.GeocodeLocationTypes = append(config.GeocodeLocationTypes, locationType)
+		default:
+			message := fmt.Sprintf(InvalidEnvVarErr, GEOCODE_LOCATIONS)
+			logger.Warn(message, zap.String("locationType", locationType))
+		}
+	}
+
+	// =====================
+	// Provider Client Section
+	// =====================
+	input = os.Getenv(PROVIDER_TIMEOUT_MS)
+	if input != "" {
+		if ms, err := strconv.Atoi(input); err == nil && ms > 0 {
+			config.ProviderTimeout = time.Duration(ms) * time.Millisecond
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, PROVIDER_TIMEOUT_MS)
+			logger.Warn(message, zap.String("input", input))
+		}
+	}
+
+	// Off by default, a retried call can be billed twice
+	input = os.Getenv(PROVIDER_MAX_RETRIES)
+	if input != "" {
+		if retries, err := strconv.Atoi(input); err == nil && retries >= 0 {
+			config.ProviderMaxRetries = retries
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, PROVIDER_MAX_RETRIES)
+			logger.Warn(message, zap.String("input", input))
+		}
+	}
+
+	// The provider credentials stay out of the logs
+	logged := config
+	logged.GoogleMapsAPIKey, logged.GoogleMapsAPIKeys = "", nil
+	logged.SmartyAuthID, logged.SmartyAuthToken = "", ""
+	logger.Debug("Defined Map Configuration", zap.Any("config", logged))
+
+	return config, errs
 }
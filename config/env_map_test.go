@@ -0,0 +1,280 @@
+package config_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestConfig_NewMapConfig_DistanceUnitWarning(t *testing.T) {
+	const MAP_DISTANCE_UNIT = "MAP_DISTANCE_UNIT"
+
+	tests := []struct {
+		name        string
+		unit        string
+		wantUnit    string
+		wantWarning string
+	}{
+		{
+			name:        "Test Missing Unit Warns With The Default",
+			wantUnit:    ports.DISTANCE_MILES,
+			wantWarning: `MAP_DISTANCE_UNIT environment variable is missing, defaulting to "mi"`,
+		},
+		{
+			name:     "Test Kilometers Is Silent",
+			unit:     ports.DISTANCE_KILOMETER,
+			wantUnit: ports.DISTANCE_KILOMETER,
+		},
+		{
+			name:     "Test Miles Is Silent",
+			unit:     ports.DISTANCE_MILES,
+			wantUnit: ports.DISTANCE_MILES,
+		},
+		{
+			name:        "Test Invalid Unit Warns",
+			unit:        "furlongs",
+			wantUnit:    ports.DISTANCE_MILES,
+			wantWarning: "MAP_DISTANCE_UNIT environment variable is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PROVIDER", ports.PROVIDER_MOCK)
+			t.Setenv("MAP_CENTER_LAT", "40.8313747")
+			t.Setenv("MAP_CENTER_LNG", "-73.8272283")
+			t.Setenv(MAP_DISTANCE_UNIT, tt.unit)
+
+			core, logs := observer.New(zap.WarnLevel)
+			got := config.Config{}.NewMapConfig(zap.New(core))
+
+			if got.DistanceUnit != tt.wantUnit {
+				t.Errorf("Config.NewMapConfig() DistanceUnit = %q, want %q", got.DistanceUnit, tt.wantUnit)
+			}
+			var gotWarning string
+			for _, entry := range logs.All() {
+				for _, field := range entry.Context {
+					if field.Key == "unit" {
+						gotWarning = entry.Message
+					}
+				}
+			}
+			if gotWarning != tt.wantWarning {
+				t.Errorf("Config.NewMapConfig() unit warning = %q, want %q", gotWarning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestConfig_NewMapConfig_PremiumZone(t *testing.T) {
+	tests := []struct {
+		name         string
+		distance     string
+		lat          string
+		lng          string
+		wantDistance float64
+		wantLat      float64
+		wantLng      float64
+	}{
+		{
+			name:    "Test Unset Premium Zone Is Disabled",
+			wantLat: 40.8313747,
+			wantLng: -73.8272283,
+		},
+		{
+			name:         "Test Premium Zone Defaults To The Geofence Center",
+			distance:     "0.5",
+			wantDistance: 0.5,
+			wantLat:      40.8313747,
+			wantLng:      -73.8272283,
+		},
+		{
+			name:         "Test Premium Zone Has Its Own Center",
+			distance:     "0.5",
+			lat:          "40.85",
+			lng:          "-73.86",
+			wantDistance: 0.5,
+			wantLat:      40.85,
+			wantLng:      -73.86,
+		},
+		{
+			name:     "Test Invalid Distance Disables Premium Zone",
+			distance: "-1",
+			wantLat:  40.8313747,
+			wantLng:  -73.8272283,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PROVIDER", ports.PROVIDER_MOCK)
+			t.Setenv("MAP_CENTER_LAT", "40.8313747")
+			t.Setenv("MAP_CENTER_LNG", "-73.8272283")
+			t.Setenv("PREMIUM_ZONE_MAX_DISTANCE", tt.distance)
+			t.Setenv("PREMIUM_ZONE_CENTER_LAT", tt.lat)
+			t.Setenv("PREMIUM_ZONE_CENTER_LNG", tt.lng)
+
+			got := config.Config{}.NewMapConfig(zap.NewNop())
+
+			if got.PremiumZoneDistance != tt.wantDistance || got.PremiumZoneLat != tt.wantLat || got.PremiumZoneLng != tt.wantLng {
+				t.Errorf("Config.NewMapConfig() premium zone = %v at %v,%v, want %v at %v,%v",
+					got.PremiumZoneDistance, got.PremiumZoneLat, got.PremiumZoneLng, tt.wantDistance, tt.wantLat, tt.wantLng)
+			}
+		})
+	}
+}
+
+func TestConfig_NewMapConfig_Locality(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "Test Unset Locality Is Empty", input: "", want: ""},
+		{name: "Test Locality Is Trimmed", input: " Bronx ", want: "Bronx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PROVIDER", ports.PROVIDER_MOCK)
+			t.Setenv("MAP_CENTER_LAT", "40.8313747")
+			t.Setenv("MAP_CENTER_LNG", "-73.8272283")
+			t.Setenv("MAP_LOCALITY", tt.input)
+
+			got := config.Config{}.NewMapConfig(zap.NewNop())
+
+			if got.Locality != tt.want {
+				t.Errorf("Config.NewMapConfig() locality = %q, want %q", got.Locality, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_ReloadMapConfig_SmartyStreets(t *testing.T) {
+	tests := []struct {
+		name       string
+		authID     string
+		authToken  string
+		wantErrors int
+	}{
+		{
+			name:      "Test Credentials Are Read",
+			authID:    "test-id",
+			authToken: "test-token",
+		},
+		{
+			name:       "Test Missing Token Is Required",
+			authID:     "test-id",
+			wantErrors: 1,
+		},
+		{
+			name:       "Test Missing Credentials Are Both Required",
+			wantErrors: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PROVIDER", ports.PROVIDER_SMARTY)
+			t.Setenv("MAP_MAX_DISTANCE", "2")
+			t.Setenv("MAP_CENTER_LAT", "40.8313747")
+			t.Setenv("MAP_CENTER_LNG", "-73.8272283")
+			t.Setenv("SMARTY_AUTH_ID", tt.authID)
+			t.Setenv("SMARTY_AUTH_TOKEN", tt.authToken)
+
+			got, errs := config.Config{}.ReloadMapConfig(zap.NewNop())
+
+			if len(errs) != tt.wantErrors {
+				t.Fatalf("Config.ReloadMapConfig() errors = %v, want %d", errs, tt.wantErrors)
+			}
+			if got.Provider != ports.PROVIDER_SMARTY || got.SmartyAuthID != tt.authID || got.SmartyAuthToken != tt.authToken {
+				t.Errorf("Config.ReloadMapConfig() provider = %q with %q/%q, want %q with %q/%q",
+					got.Provider, got.SmartyAuthID, got.SmartyAuthToken, ports.PROVIDER_SMARTY, tt.authID, tt.authToken)
+			}
+		})
+	}
+}
+
+func TestConfig_ReloadMapConfig_GeocodingProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiKey       string
+		hardBias     string
+		wantHardBias bool
+		wantErrors   int
+	}{
+		{
+			name:   "Test Soft Bias By Default",
+			apiKey: "test-key",
+		},
+		{
+			name:         "Test Hard Bias Is Read",
+			apiKey:       "test-key",
+			hardBias:     "true",
+			wantHardBias: true,
+		},
+		{
+			name:       "Test Missing API Key Is Required",
+			wantErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PROVIDER", ports.PROVIDER_GEOCODING)
+			t.Setenv("MAP_MAX_DISTANCE", "2")
+			t.Setenv("MAP_CENTER_LAT", "40.8313747")
+			t.Setenv("MAP_CENTER_LNG", "-73.8272283")
+			t.Setenv("GOOGLE_MAPS_API_KEY", tt.apiKey)
+			t.Setenv("GOOGLE_MAPS_API_KEYS", "")
+			t.Setenv("GEOFENCE_HARD_BIAS", tt.hardBias)
+
+			got, errs := config.Config{}.ReloadMapConfig(zap.NewNop())
+
+			if len(errs) != tt.wantErrors {
+				t.Fatalf("Config.ReloadMapConfig() errors = %v, want %d", errs, tt.wantErrors)
+			}
+			if got.Provider != ports.PROVIDER_GEOCODING || got.GeofenceHardBias != tt.wantHardBias {
+				t.Errorf("Config.ReloadMapConfig() provider = %q with hard bias %v, want %q with %v",
+					got.Provider, got.GeofenceHardBias, ports.PROVIDER_GEOCODING, tt.wantHardBias)
+			}
+		})
+	}
+}
+
+func TestConfig_ReloadMapConfig_EnvironmentPrecedence(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig(`{"MAP_CENTER_LAT": 40.5, "MAP_MAX_DISTANCE": 5}`)
+
+	t.Setenv("PROVIDER", ports.PROVIDER_MOCK)
+	t.Setenv("MAP_CENTER_LAT", "40.8313747")
+	t.Setenv("MAP_CENTER_LNG", "-73.8272283")
+	t.Setenv("MAP_MAX_DISTANCE", "")
+	t.Setenv("CONFIG_FILE", configFile)
+	if err := config.LoadConfigFile(configFile); err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+
+	// The file changes the distance it supplied, the environment keeps its center
+	writeConfig(`{"MAP_CENTER_LAT": 40.5, "MAP_MAX_DISTANCE": 8}`)
+	got, errs := config.Config{}.ReloadMapConfig(zap.NewNop())
+	if len(errs) > 0 {
+		t.Fatalf("Config.ReloadMapConfig() errors = %v", errs)
+	}
+	if got.CenterLat != 40.8313747 || got.MaxDistance != 8 {
+		t.Errorf("Config.ReloadMapConfig() center lat, distance = %v, %v, want 40.8313747, 8", got.CenterLat, got.MaxDistance)
+	}
+
+	// A value removed from the file does not linger
+	writeConfig(`{"MAP_CENTER_LAT": 40.5}`)
+	got, _ = config.Config{}.ReloadMapConfig(zap.NewNop())
+	if got.MaxDistance == 8 {
+		t.Errorf("Config.ReloadMapConfig() distance = %v after it was removed from the file", got.MaxDistance)
+	}
+}
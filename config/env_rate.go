@@ -9,10 +9,23 @@ import (
 	"go.uber.org/zap"
 )
 
+// Rate limiting algorithms
+const (
+	RATE_LIMIT_SLIDING_LOG     = "sliding_log"
+	RATE_LIMIT_SLIDING_COUNTER = "sliding_counter"
+)
+
 // RateLimitConfig holds rate limiting configuration
 type RateLimitConfig struct {
+	Algorithm   string
 	MaxRequests uint
 	TimeWindow  time.Duration
+	// GraceBurst requests from a newly seen client are always allowed within its first TimeWindow, 0 disables
+	GraceBurst uint
+	// DailyQuota caps the requests of a client per day on top of the window, 0 disables it.
+	// The day ends at midnight in DailyQuotaLocation, UTC when nil.
+	DailyQuota         uint
+	DailyQuotaLocation *time.Location
 }
 
 func (c Config) NewRateLimitConfig(logger *zap.Logger) RateLimitConfig {
@@ -20,10 +33,15 @@ func (c Config) NewRateLimitConfig(logger *zap.Logger) RateLimitConfig {
 	const (
 		RATE_LIMIT_MAX_REQUESTS = "RATE_LIMIT_MAX_REQUESTS"
 		RATE_LIMIT_TIME_WINDOW  = "RATE_LIMIT_TIME_WINDOW_SECONDS"
+		RATE_LIMIT_ALGORITHM    = "RATE_LIMIT_ALGORITHM"
+		RATE_LIMIT_GRACE_BURST  = "RATE_LIMIT_GRACE_BURST"
+		RATE_LIMIT_DAILY_QUOTA  = "RATE_LIMIT_DAILY_QUOTA"
+		RATE_LIMIT_DAILY_TZ     = "RATE_LIMIT_DAILY_TIMEZONE"
 		INPUT                   = "input"
 	)
 
 	config := RateLimitConfig{
+		Algorithm:   RATE_LIMIT_SLIDING_LOG,
 		MaxRequests: 10,
 		TimeWindow:  60 * time.Second,
 	}
@@ -49,5 +67,65 @@ func (c Config) NewRateLimitConfig(logger *zap.Logger) RateLimitConfig {
 		logger.Error(message, zap.Error(err))
 	}
 
+	input = os.Getenv(RATE_LIMIT_TIME_WINDOW)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(MissingEnvVarWarning, RATE_LIMIT_TIME_WINDOW))
+	} else if seconds, err := strconv.Atoi(input); err != nil {
+		message := fmt.Sprintf(InvalidEnvVarErr, RATE_LIMIT_TIME_WINDOW)
+		logger.Error(message, zap.String(INPUT, input), zap.Error(err))
+	} else if seconds <= 0 {
+		err := fmt.Errorf(NegativeValueErr, input)
+		message := fmt.Sprintf(InvalidEnvVarErr, RATE_LIMIT_TIME_WINDOW)
+		logger.Error(message, zap.Error(err))
+	} else {
+		config.TimeWindow = time.Duration(seconds) * time.Second
+	}
+
+	input = os.Getenv(RATE_LIMIT_ALGORITHM)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(MissingEnvVarWarning, RATE_LIMIT_ALGORITHM))
+	} else {
+		switch input {
+		case RATE_LIMIT_SLIDING_LOG, RATE_LIMIT_SLIDING_COUNTER:
+			config.Algorithm = input
+		default:
+			message := fmt.Sprintf(InvalidEnvVarErr, RATE_LIMIT_ALGORITHM)
+			logger.Warn(message, zap.String(INPUT, input))
+		}
+	}
+
+	// Optional, off unless set
+	input = os.Getenv(RATE_LIMIT_GRACE_BURST)
+	if input != "" {
+		if burst, err := strconv.Atoi(input); err == nil && burst >= 0 {
+			config.GraceBurst = uint(burst)
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, RATE_LIMIT_GRACE_BURST)
+			logger.Warn(message, zap.String(INPUT, input))
+		}
+	}
+
+	// Optional, off unless set
+	input = os.Getenv(RATE_LIMIT_DAILY_QUOTA)
+	if input != "" {
+		if quota, err := strconv.Atoi(input); err == nil && quota >= 0 {
+			config.DailyQuota = uint(quota)
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, RATE_LIMIT_DAILY_QUOTA)
+			logger.Warn(message, zap.String(INPUT, input))
+		}
+	}
+
+	// An IANA name such as America/New_York, the quota resets at its midnight
+	input = os.Getenv(RATE_LIMIT_DAILY_TZ)
+	if input != "" {
+		if location, err := time.LoadLocation(input); err == nil {
+			config.DailyQuotaLocation = location
+		} else {
+			message := fmt.Sprintf(InvalidEnvVarErr, RATE_LIMIT_DAILY_TZ)
+			logger.Warn(message, zap.String(INPUT, input), zap.Error(err))
+		}
+	}
+
 	return config
 }
@@ -0,0 +1,134 @@
+package config_test
+
+import (
+	"address-validator/config"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestConfig_NewRateLimitConfig(t *testing.T) {
+	const (
+		RATE_LIMIT_MAX_REQUESTS = "RATE_LIMIT_MAX_REQUESTS"
+		RATE_LIMIT_TIME_WINDOW  = "RATE_LIMIT_TIME_WINDOW_SECONDS"
+		RATE_LIMIT_ALGORITHM    = "RATE_LIMIT_ALGORITHM"
+		RATE_LIMIT_GRACE_BURST  = "RATE_LIMIT_GRACE_BURST"
+		RATE_LIMIT_DAILY_QUOTA  = "RATE_LIMIT_DAILY_QUOTA"
+		RATE_LIMIT_DAILY_TZ     = "RATE_LIMIT_DAILY_TIMEZONE"
+	)
+
+	tests := []struct {
+		name string
+		env  [][2]string
+		want config.RateLimitConfig
+	}{
+		{
+			name: "Test Empty Environment Variables Returns Default Config",
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+			},
+		},
+		{
+			name: "Test Custom Time Window Returns Window",
+			env:  [][2]string{{RATE_LIMIT_TIME_WINDOW, "15"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  15 * time.Second,
+			},
+		},
+		{
+			name: "Test Zero Time Window Returns Default",
+			env:  [][2]string{{RATE_LIMIT_TIME_WINDOW, "0"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+			},
+		},
+		{
+			name: "Test Negative Time Window Returns Default",
+			env:  [][2]string{{RATE_LIMIT_TIME_WINDOW, "-30"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+			},
+		},
+		{
+			name: "Test Non Integer Time Window Returns Default",
+			env:  [][2]string{{RATE_LIMIT_TIME_WINDOW, "1m"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+			},
+		},
+		{
+			name: "Test Max Requests And Algorithm Returns Values",
+			env:  [][2]string{{RATE_LIMIT_MAX_REQUESTS, "100"}, {RATE_LIMIT_ALGORITHM, "sliding_counter"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_COUNTER,
+				MaxRequests: 100,
+				TimeWindow:  60 * time.Second,
+			},
+		},
+		{
+			name: "Test Grace Burst Returns Burst",
+			env:  [][2]string{{RATE_LIMIT_GRACE_BURST, "5"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+				GraceBurst:  5,
+			},
+		},
+		{
+			name: "Test Daily Quota Returns Quota And Time Zone",
+			env:  [][2]string{{RATE_LIMIT_DAILY_QUOTA, "1000"}, {RATE_LIMIT_DAILY_TZ, "UTC"}},
+			want: config.RateLimitConfig{
+				Algorithm:          config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests:        10,
+				TimeWindow:         60 * time.Second,
+				DailyQuota:         1000,
+				DailyQuotaLocation: time.UTC,
+			},
+		},
+		{
+			name: "Test Unknown Daily Time Zone Is Ignored",
+			env:  [][2]string{{RATE_LIMIT_DAILY_QUOTA, "1000"}, {RATE_LIMIT_DAILY_TZ, "Mars/Olympus_Mons"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+				DailyQuota:  1000,
+			},
+		},
+		{
+			name: "Test Invalid Grace Burst Returns Disabled",
+			env:  [][2]string{{RATE_LIMIT_GRACE_BURST, "-1"}},
+			want: config.RateLimitConfig{
+				Algorithm:   config.RATE_LIMIT_SLIDING_LOG,
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Environment variables automatically cleans up after each test
+			for _, pair := range tt.env {
+				t.Setenv(pair[0], pair[1])
+			}
+
+			c := config.Config{}
+			if got := c.NewRateLimitConfig(zap.NewNop()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Config.NewRateLimitConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
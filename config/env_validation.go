@@ -0,0 +1,211 @@
+package config
+
+import (
+	"address-validator/ports"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// Low confidence policies
+const (
+	LOW_CONFIDENCE_OFF    = "off"
+	LOW_CONFIDENCE_FLAG   = "flag"
+	LOW_CONFIDENCE_REJECT = "reject"
+)
+
+// DEFAULT_MAX_ADDRESS_LENGTH is the longest address accepted unless configured, well past any real address
+const DEFAULT_MAX_ADDRESS_LENGTH = 500
+
+// ValidationConfig holds the address validation policy applied by the service
+type ValidationConfig struct {
+	AllowedScripts  []string
+	IncludePlusCode bool
+	IncludeUTM      bool
+	IncludeTimeZone bool
+	NormalizeUS     bool
+	BlockedRegions  []string
+	DebugFields     bool
+	// LowConfidencePolicy decides what happens to approximate matches without a street component
+	LowConfidencePolicy string
+	// AllowedAddressTypes are the address types accepted, empty accepts any
+	AllowedAddressTypes []string
+	// RequiredComponents are the component types a valid address must have, e.g. street_number
+	RequiredComponents []string
+	// RequireDeliverable rejects valid addresses the provider reports as not deliverable
+	RequireDeliverable bool
+	// LocalityAliasesPath is a JSON file of canonical locality names to their aliases, empty disables aliasing
+	LocalityAliasesPath string
+	// ProfilesPath is a JSON file of named validation profiles requests can select, empty disables profiles
+	ProfilesPath string
+	// DefaultProfile is the profile applied to requests naming none, empty applies the policies above
+	DefaultProfile string
+	// MaxAddressLength is the longest address in characters sent to the provider, 0 allows any length
+	MaxAddressLength uint
+	// NormalizeTitleCase and NormalizeTrimPunctuation refine NormalizeUS, disable the former where casing is semantic
+	NormalizeTitleCase       bool
+	NormalizeTrimPunctuation bool
+}
+
+func (c Config) NewValidationConfig(logger *zap.Logger) ValidationConfig {
+	const (
+		MAX_LENGTH        = "MAX_ADDRESS_LENGTH"
+		ALLOWED_SCRIPTS   = "ALLOWED_SCRIPTS"
+		INCLUDE_PLUS_CODE = "INCLUDE_PLUS_CODE"
+		INCLUDE_UTM       = "INCLUDE_UTM"
+		INCLUDE_TIME_ZONE = "INCLUDE_TIME_ZONE"
+		NORMALIZE_US      = "NORMALIZE_US"
+		NORMALIZE_CASE    = "NORMALIZE_TITLE_CASE"
+		NORMALIZE_PERIODS = "NORMALIZE_TRIM_PUNCTUATION"
+		BLOCKED_REGIONS   = "BLOCKED_REGIONS"
+		DEBUG_FIELDS      = "DEBUG_RESPONSE_FIELDS"
+		LOW_CONFIDENCE    = "LOW_CONFIDENCE_POLICY"
+		ADDRESS_TYPES     = "ALLOWED_ADDRESS_TYPES"
+		REQUIRED          = "REQUIRED_COMPONENTS"
+		DELIVERABLE       = "REQUIRE_DELIVERABLE"
+		PROFILES          = "VALIDATION_PROFILES_PATH"
+		DEFAULT_PROFILE   = "VALIDATION_DEFAULT_PROFILE"
+		LOCALITY_ALIASES  = "LOCALITY_ALIASES_PATH"
+	)
+
+	config := ValidationConfig{
+		MaxAddressLength:    DEFAULT_MAX_ADDRESS_LENGTH,
+		LowConfidencePolicy: LOW_CONFIDENCE_FLAG,
+	}
+
+	// =====================
+	// Address Length Section
+	// =====================
+	// Longer input is no address, it is refused before it costs an upstream call
+	input := os.Getenv(MAX_LENGTH)
+	if input == "" {
+		logger.Warn(fmt.Sprintf(DefaultedEnvVarWarning, MAX_LENGTH, strconv.Itoa(DEFAULT_MAX_ADDRESS_LENGTH)))
+	} else if num, err := strconv.Atoi(input); err != nil || num <= 0 {
+		message := fmt.Sprintf(InvalidEnvVarErr, MAX_LENGTH)
+		logger.Warn(message, zap.String("input", input))
+	} else {
+		config.MaxAddressLength = uint(num)
+	}
+
+	// =====================
+	// Allowed Scripts Section
+	// =====================
+	// Optional, an empty value disables the script check
+	input = os.Getenv(ALLOWED_SCRIPTS)
+	if input != "" {
+		for _, name := range strings.Split(input, ",") {
+			name = strings.TrimSpace(name)
+			if _, ok := unicode.Scripts[name]; !ok {
+				message := fmt.Sprintf(InvalidEnvVarErr, ALLOWED_SCRIPTS)
+				logger.Warn(message, zap.String("script", name))
+				continue
+			}
+			config.AllowedScripts = append(config.AllowedScripts, name)
+		}
+	}
+
+	// =====================
+	// Location Encoding Section
+	// =====================
+	config.IncludePlusCode = os.Getenv(INCLUDE_PLUS_CODE) == "true"
+	config.IncludeUTM = os.Getenv(INCLUDE_UTM) == "true"
+	// Off by default, each valid result not yet cached costs a Time Zone API call
+	config.IncludeTimeZone = os.Getenv(INCLUDE_TIME_ZONE) == "true"
+
+	// =====================
+	// Normalization Section
+	// =====================
+	config.NormalizeUS = os.Getenv(NORMALIZE_US) == "true"
+	// On unless disabled, both only apply while NORMALIZE_US is on
+	config.NormalizeTitleCase = os.Getenv(NORMALIZE_CASE) != "false"
+	config.NormalizeTrimPunctuation = os.Getenv(NORMALIZE_PERIODS) != "false"
+	// Optional, applied to addresses of any region
+	config.LocalityAliasesPath = os.Getenv(LOCALITY_ALIASES)
+
+	// =====================
+	// Blocked Regions Section
+	// =====================
+	// Optional, CLDR region codes (e.g. KP,IR) that are refused even when the address is valid
+	input = os.Getenv(BLOCKED_REGIONS)
+	if input != "" {
+		for _, region := range strings.Split(input, ",") {
+			region = strings.ToUpper(strings.TrimSpace(region))
+			if len(region) != 2 {
+				message := fmt.Sprintf(InvalidEnvVarErr, BLOCKED_REGIONS)
+				logger.Warn(message, zap.String("region", region))
+				continue
+			}
+			config.BlockedRegions = append(config.BlockedRegions, region)
+		}
+	}
+
+	// =====================
+	// Debug Fields Section
+	// =====================
+	// Adds the answering provider and upstream latency to results, off to keep responses lean
+	config.DebugFields = os.Getenv(DEBUG_FIELDS) == "true"
+
+	// =====================
+	// Low Confidence Section
+	// =====================
+	// Nonsense input can still geocode to the middle of a region, flagged by default
+	input = os.Getenv(LOW_CONFIDENCE)
+	switch input {
+	case "":
+	case LOW_CONFIDENCE_OFF, LOW_CONFIDENCE_FLAG, LOW_CONFIDENCE_REJECT:
+		config.LowConfidencePolicy = input
+	default:
+		message := fmt.Sprintf(InvalidEnvVarErr, LOW_CONFIDENCE)
+		logger.Warn(message, zap.String("input", input))
+	}
+
+	// =====================
+	// Address Types Section
+	// =====================
+	// Optional, e.g. COMMERCIAL,RESIDENTIAL for a no PO box shipping policy
+	input = os.Getenv(ADDRESS_TYPES)
+	if input != "" {
+		for _, addressType := range strings.Split(input, ",") {
+			addressType = strings.ToUpper(strings.TrimSpace(addressType))
+			switch addressType {
+			case ports.ADDRESS_TYPE_PO_BOX, ports.ADDRESS_TYPE_COMMERCIAL, ports.ADDRESS_TYPE_RESIDENTIAL:
+				config.AllowedAddressTypes = append(config.AllowedAddressTypes, addressType)
+			default:
+				message := fmt.Sprintf(InvalidEnvVarErr, ADDRESS_TYPES)
+				logger.Warn(message, zap.String("addressType", addressType))
+			}
+		}
+	}
+
+	// =====================
+	// Required Components Section
+	// =====================
+	// Optional, e.g. street_number,postal_code for shipping
+	input = os.Getenv(REQUIRED)
+	if input != "" {
+		for _, componentType := range strings.Split(input, ",") {
+			componentType = strings.ToLower(strings.TrimSpace(componentType))
+			if componentType != "" {
+				config.RequiredComponents = append(config.RequiredComponents, componentType)
+			}
+		}
+	}
+
+	// Off by default, addresses whose deliverability is unknown are accepted either way
+	config.RequireDeliverable = os.Getenv(DELIVERABLE) == "true"
+
+	// =====================
+	// Validation Profiles Section
+	// =====================
+	// Optional, the default must name a profile in the file
+	config.ProfilesPath = os.Getenv(PROFILES)
+	config.DefaultProfile = os.Getenv(DEFAULT_PROFILE)
+
+	logger.Debug("Defined Validation Configuration", zap.Any("config", config))
+
+	return config
+}
@@ -4,3 +4,4 @@ const MissingRequiredEnvVarErr = "%s environment variable is required"
 const InvalidEnvVarErr = "%s environment variable is invalid"
 const NegativeValueErr = "%s must be positive"
 const MissingEnvVarWarning = "%s environment variable is missing"
+const DefaultedEnvVarWarning = "%s environment variable is missing, defaulting to %q"
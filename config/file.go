@@ -0,0 +1,150 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fileValues are the variables set from the .env file or CONFIG_FILE rather than by the environment,
+// with the value set
+var (
+	fileValues   = map[string]string{}
+	fileValuesMu sync.Mutex
+)
+
+// LoadConfigFile reads a flat JSON object or YAML mapping of environment variable names to values,
+// e.g. {"MAP_CENTER_LAT": 40.83} or MAP_CENTER_LAT: 40.83, and applies each value whose variable
+// is unset or empty. Environment variables therefore take precedence over the file, and the
+// values go through the same parsing and validation as the environment.
+func LoadConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var values map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		values, err = parseJSONConfig(data)
+	case ".yaml", ".yml":
+		values, err = parseYAMLConfig(data)
+	default:
+		return fmt.Errorf("config file %q must be .json, .yaml or .yml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %q: %w", path, err)
+	}
+	return applyFileValues(values)
+}
+
+// applyFileValues sets each value whose variable is unset or empty, remembering it came from a file
+func applyFileValues(values map[string]string) error {
+	fileValuesMu.Lock()
+	defer fileValuesMu.Unlock()
+
+	for name, value := range values {
+		if os.Getenv(name) != "" {
+			continue
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return fmt.Errorf("failed to apply config file value %s: %w", name, err)
+		}
+		fileValues[name] = value
+	}
+	return nil
+}
+
+// clearFileValues unsets the variables set from files, leaving the environment the process started
+// with. Files are then applied again as at startup, so the environment keeps its precedence and a
+// value removed from a file does not linger.
+func clearFileValues() {
+	fileValuesMu.Lock()
+	defer fileValuesMu.Unlock()
+
+	for name, value := range fileValues {
+		// A variable changed since was not set by the file anymore
+		if os.Getenv(name) == value {
+			os.Unsetenv(name)
+		}
+		delete(fileValues, name)
+	}
+}
+
+// parseJSONConfig accepts string, number and boolean values in a flat object
+func parseJSONConfig(data []byte) (map[string]string, error) {
+	var raw map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(raw))
+	for name, value := range raw {
+		switch v := value.(type) {
+		case string:
+			values[name] = v
+		case json.Number:
+			values[name] = v.String()
+		case bool:
+			values[name] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("%s must be a string, number or boolean", name)
+		}
+	}
+	return values, nil
+}
+
+// parseYAMLConfig accepts the flat subset of YAML the configuration needs: one KEY: value
+// per line, optionally quoted, with # comments. Nested mappings and lists are rejected.
+func parseYAMLConfig(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' || line[0] == '-' {
+			return nil, fmt.Errorf("line %d: only flat KEY: value mappings are supported", lineNumber)
+		}
+
+		name, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY: value", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0]:
+			if value[0] == '"' {
+				unquoted, err := strconv.Unquote(value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+				}
+				value = unquoted
+			} else {
+				value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+			}
+		default:
+			if comment := strings.Index(value, " #"); comment >= 0 {
+				value = strings.TrimSpace(value[:comment])
+			}
+		}
+
+		values[strings.TrimSpace(name)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"address-validator/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfig_LoadAll_ConfigFile(t *testing.T) {
+	const jsonFile = `{
+		"PROVIDER": "mock",
+		"MAP_CENTER_LAT": 40.8313747,
+		"MAP_CENTER_LNG": -73.8272283,
+		"RATE_LIMIT_MAX_REQUESTS": 20,
+		"RATE_LIMIT_TIME_WINDOW_SECONDS": "30",
+		"INCLUDE_PLUS_CODE": true
+	}`
+	const yamlFile = `# Address validator configuration
+PROVIDER: mock
+MAP_CENTER_LAT: 40.8313747
+MAP_CENTER_LNG: "-73.8272283"
+RATE_LIMIT_MAX_REQUESTS: 20 # per window
+RATE_LIMIT_TIME_WINDOW_SECONDS: '30'
+INCLUDE_PLUS_CODE: true
+`
+	fileKeys := []string{"PROVIDER", "MAP_CENTER_LAT", "MAP_CENTER_LNG", "RATE_LIMIT_MAX_REQUESTS", "RATE_LIMIT_TIME_WINDOW_SECONDS", "INCLUDE_PLUS_CODE"}
+
+	tests := []struct {
+		name            string
+		fileName        string
+		content         string
+		env             [][2]string
+		wantMaxRequests uint
+	}{
+		{name: "Test JSON File Populates Config", fileName: "config.json", content: jsonFile, wantMaxRequests: 20},
+		{name: "Test YAML File Populates Config", fileName: "config.yaml", content: yamlFile, wantMaxRequests: 20},
+		{
+			name:            "Test Environment Overrides File Value",
+			fileName:        "config.yaml",
+			content:         yamlFile,
+			env:             [][2]string{{"RATE_LIMIT_MAX_REQUESTS", "50"}},
+			wantMaxRequests: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Registers every file key for restoration, the loader sets them with os.Setenv
+			for _, name := range fileKeys {
+				t.Setenv(name, "")
+			}
+			t.Setenv("OUTPUT_PATH", "stdout")
+			t.Setenv("ERROR_PATH", "stderr")
+			t.Setenv("REQUIRE_HTTPS", "false")
+			t.Setenv("CONFIG_FILE", path)
+			for _, env := range tt.env {
+				t.Setenv(env[0], env[1])
+			}
+
+			got, errs := config.Config{}.LoadAll()
+			if len(errs) > 0 {
+				t.Fatalf("Config.LoadAll() errors = %v", errs)
+			}
+			if got.Map.CenterLat != 40.8313747 || got.Map.CenterLng != -73.8272283 {
+				t.Errorf("Config.LoadAll() center = %v, %v, want 40.8313747, -73.8272283", got.Map.CenterLat, got.Map.CenterLng)
+			}
+			if got.RateLimit.MaxRequests != tt.wantMaxRequests {
+				t.Errorf("Config.LoadAll() MaxRequests = %d, want %d", got.RateLimit.MaxRequests, tt.wantMaxRequests)
+			}
+			if got.RateLimit.TimeWindow != 30*time.Second {
+				t.Errorf("Config.LoadAll() TimeWindow = %v, want %v", got.RateLimit.TimeWindow, 30*time.Second)
+			}
+			if !got.Validation.IncludePlusCode {
+				t.Error("Config.LoadAll() IncludePlusCode = false, want true")
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{name: "Test Nested YAML Is Rejected", fileName: "config.yaml", content: "map:\n  centerLat: 40.8\n"},
+		{name: "Test Nested JSON Is Rejected", fileName: "config.json", content: `{"map": {"centerLat": 40.8}}`},
+		{name: "Test Unknown Extension Is Rejected", fileName: "config.toml", content: `PROVIDER = "mock"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := config.LoadConfigFile(path); err == nil {
+				t.Error("LoadConfigFile() error = nil, want error")
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -13,13 +17,37 @@ type LoggerConfig struct {
 	OutputPath    string `json:"outputPath" yaml:"outputPath"` // stdout, stderr, or file path
 	ErrorPath     string `json:"errorPath" yaml:"errorPath"`   // separate path for error logs
 	IsDevelopment bool   `json:"development" yaml:"development"`
+
+	// Rotation applies to file outputs only, a MaxSizeMB of 0 disables it
+	MaxSizeMB  int  `json:"maxSizeMB" yaml:"maxSizeMB"`
+	MaxBackups int  `json:"maxBackups" yaml:"maxBackups"`
+	MaxAgeDays int  `json:"maxAgeDays" yaml:"maxAgeDays"`
+	Compress   bool `json:"compress" yaml:"compress"`
+
+	// DebugSampleRate is the fraction of requests, 0.0 to 1.0, logged at debug level
+	DebugSampleRate float64 `json:"debugSampleRate" yaml:"debugSampleRate"`
 }
 
 func NewLogger(config LoggerConfig) (*zap.Logger, error) {
+	logger, _, err := NewSamplingLoggers(config)
+	return logger, err
+}
+
+// NewSamplingLoggers creates the application logger and, when DebugSampleRate is set, a logger
+// sharing its outputs that also writes debug entries for the sampled requests.
+// The debug logger is nil when sampling is disabled.
+func NewSamplingLoggers(config LoggerConfig) (*zap.Logger, *zap.Logger, error) {
 	// Set log level
 	var level zapcore.Level
 	if err := level.UnmarshalText([]byte(config.Level)); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Sampling needs debug entries to reach the cores, the application logger raises its own level back
+	sampling := config.DebugSampleRate > 0 && level > zapcore.DebugLevel
+	coreLevel := level
+	if sampling {
+		coreLevel = zapcore.DebugLevel
 	}
 
 	// Configure encoder
@@ -49,11 +77,11 @@ func NewLogger(config LoggerConfig) (*zap.Logger, error) {
 	case "stderr":
 		outputSyncer = zapcore.AddSync(os.Stderr)
 	default:
-		file, err := os.OpenFile(config.OutputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		syncer, err := openLogFile("OUTPUT_PATH", config.OutputPath, config)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		outputSyncer = zapcore.AddSync(file)
+		outputSyncer = syncer
 	}
 
 	// Create error syncer (defaults to outputSyncer if not specified)
@@ -65,11 +93,11 @@ func NewLogger(config LoggerConfig) (*zap.Logger, error) {
 		case "stderr":
 			errorSyncer = zapcore.AddSync(os.Stderr)
 		default:
-			file, err := os.OpenFile(config.ErrorPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			syncer, err := openLogFile("ERROR_PATH", config.ErrorPath, config)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
-			errorSyncer = zapcore.AddSync(file)
+			errorSyncer = syncer
 		}
 	}
 
@@ -79,7 +107,7 @@ func NewLogger(config LoggerConfig) (*zap.Logger, error) {
 			encoder,
 			outputSyncer,
 			zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-				return lvl >= level && lvl < zapcore.ErrorLevel
+				return lvl >= coreLevel && lvl < zapcore.ErrorLevel
 			}),
 		),
 		zapcore.NewCore(
@@ -101,7 +129,78 @@ func NewLogger(config LoggerConfig) (*zap.Logger, error) {
 		options = append(options, zap.Development())
 	}
 
-	return zap.New(core, options...), nil
+	logger := zap.New(core, options...)
+	if !sampling {
+		return logger, nil, nil
+	}
+	return logger.WithOptions(zap.IncreaseLevel(level)), logger.With(zap.Bool("debugSampled", true)), nil
+}
+
+// NewAuditLogger creates the info level JSON logger for the audit trail. Its encoding does not
+// follow the application logger settings so the record schema stays the same in every environment.
+func NewAuditLogger(config AuditConfig) (*zap.Logger, error) {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoderConfig.CallerKey = zapcore.OmitKey
+	encoderConfig.StacktraceKey = zapcore.OmitKey
+
+	var syncer zapcore.WriteSyncer
+	switch config.OutputPath {
+	case "", "stdout":
+		syncer = zapcore.AddSync(os.Stdout)
+	case "stderr":
+		syncer = zapcore.AddSync(os.Stderr)
+	default:
+		file, err := openLogFile("AUDIT_OUTPUT_PATH", config.OutputPath, LoggerConfig{})
+		if err != nil {
+			return nil, err
+		}
+		syncer = file
+	}
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), syncer, zapcore.InfoLevel)
+	return zap.New(core), nil
+}
+
+// openLogFile opens path for appending, verifying up front that the file is creatable
+// and writable so a bad path fails at startup instead of on the first log write.
+// URL scheme paths are opened as before without the extra checks.
+// File paths are wrapped in a RotatingFile when rotation is configured.
+func openLogFile(name string, path string, config LoggerConfig) (zapcore.WriteSyncer, error) {
+	if !strings.Contains(path, "://") {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return nil, fmt.Errorf("log %s %q is a directory", name, path)
+		}
+
+		dir := filepath.Dir(path)
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("log %s directory %q is not accessible: %w", name, dir, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("log %s parent %q is not a directory", name, dir)
+		}
+	}
+
+	if config.MaxSizeMB > 0 && !strings.Contains(path, "://") {
+		file, err := NewRotatingFile(
+			path,
+			int64(config.MaxSizeMB)*1024*1024,
+			config.MaxBackups,
+			time.Duration(config.MaxAgeDays)*24*time.Hour,
+			config.Compress,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("log %s %q is not writable: %w", name, path, err)
+		}
+		return file, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("log %s %q is not writable: %w", name, path, err)
+	}
+	return zapcore.AddSync(file), nil
 }
 
 func SugarLogger(cfg LoggerConfig) (*zap.SugaredLogger, error) {
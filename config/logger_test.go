@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"address-validator/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewLogger_Paths(t *testing.T) {
+	dir := t.TempDir()
+	readOnly := filepath.Join(dir, "readonly")
+	if err := os.Mkdir(readOnly, 0555); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		outputPath string
+		errorPath  string
+		wantErr    bool
+		skipRoot   bool
+	}{
+		{
+			name:       "Test Standard Streams Succeed",
+			outputPath: "stdout",
+			errorPath:  "stderr",
+		},
+		{
+			name:       "Test Writable Files Succeed",
+			outputPath: filepath.Join(dir, "app.log"),
+			errorPath:  filepath.Join(dir, "errors.log"),
+		},
+		{
+			name:       "Test Missing Output Directory Returns Error",
+			outputPath: filepath.Join(dir, "missing", "app.log"),
+			errorPath:  "stderr",
+			wantErr:    true,
+		},
+		{
+			name:       "Test Missing Error Directory Returns Error",
+			outputPath: "stdout",
+			errorPath:  filepath.Join(dir, "missing", "errors.log"),
+			wantErr:    true,
+		},
+		{
+			name:       "Test Directory As Output Returns Error",
+			outputPath: dir,
+			errorPath:  "stderr",
+			wantErr:    true,
+		},
+		{
+			name:       "Test Unwritable Directory Returns Error",
+			outputPath: filepath.Join(readOnly, "app.log"),
+			errorPath:  "stderr",
+			wantErr:    true,
+			skipRoot:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.skipRoot && os.Geteuid() == 0 {
+				t.Skip("permission bits are not enforced for root")
+			}
+
+			cfg := config.DefaultLoggerConfig()
+			cfg.OutputPath = tt.outputPath
+			cfg.ErrorPath = tt.errorPath
+
+			logger, err := config.NewLogger(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if logger == nil && !tt.wantErr {
+				t.Errorf("NewLogger() returned nil logger")
+			}
+		})
+	}
+}
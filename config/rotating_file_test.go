@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"address-validator/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile_Write(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxSize     int64
+		maxBackups  int
+		compress    bool
+		writes      int
+		wantBackups int
+		wantSuffix  string
+	}{
+		{
+			name:        "Test Writes Under Limit Do Not Rotate",
+			maxSize:     1024,
+			writes:      3,
+			wantBackups: 0,
+		},
+		{
+			name:        "Test Write Past Limit Creates Rotated File",
+			maxSize:     25,
+			writes:      2,
+			wantBackups: 1,
+			wantSuffix:  ".log",
+		},
+		{
+			name:        "Test Max Backups Prunes Oldest",
+			maxSize:     25,
+			maxBackups:  2,
+			writes:      6,
+			wantBackups: 2,
+			wantSuffix:  ".log",
+		},
+		{
+			name:        "Test Compress Gzips Rotated File",
+			maxSize:     25,
+			compress:    true,
+			writes:      2,
+			wantBackups: 1,
+			wantSuffix:  ".log.gz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "app.log")
+
+			r, err := config.NewRotatingFile(path, tt.maxSize, tt.maxBackups, 0, tt.compress)
+			if err != nil {
+				t.Fatalf("NewRotatingFile() error = %v", err)
+			}
+			defer r.Close()
+
+			line := []byte("twenty byte log line\n")
+			for i := 0; i < tt.writes; i++ {
+				if _, err := r.Write(line); err != nil {
+					t.Fatalf("RotatingFile.Write() error = %v", err)
+				}
+			}
+
+			backups, err := filepath.Glob(filepath.Join(dir, "app-*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(backups) != tt.wantBackups {
+				t.Fatalf("rotated files = %v, want %d", backups, tt.wantBackups)
+			}
+			for _, backup := range backups {
+				if !strings.HasSuffix(backup, tt.wantSuffix) {
+					t.Errorf("rotated file = %s, want suffix %s", backup, tt.wantSuffix)
+				}
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() > tt.maxSize {
+				t.Errorf("current file size = %d, want <= %d", info.Size(), tt.maxSize)
+			}
+		})
+	}
+}
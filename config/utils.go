@@ -3,7 +3,9 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/netip"
 	"strconv"
+	"strings"
 )
 
 func ParseStringToUint16(s string) (uint16, error) {
@@ -12,7 +14,7 @@ func ParseStringToUint16(s string) (uint16, error) {
 	if err != nil {
 		return 0, err
 	}
-	
+
 	// Check if it fits in uint16 range
 	if num < 0 || num > 65535 {
 		return 0, errors.New("port out of range (0-65535)")
@@ -21,7 +23,6 @@ func ParseStringToUint16(s string) (uint16, error) {
 	return uint16(num), nil
 }
 
-
 func ParseStringToUint8(s string) (uint8, error) {
 	// First convert to int to catch negative numbers
 	num, err := ParseInt(s)
@@ -43,3 +44,20 @@ func ParseInt(s string) (num int, err error) {
 	}
 	return
 }
+
+// ParsePrefix parses a CIDR, or a single IP as a full-length prefix
+func ParsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
@@ -0,0 +1,45 @@
+package geo
+
+import "math"
+
+// Circle approximates the circle of radiusMeters around lat, lng as a closed ring of segments
+// points in [lng, lat] order, counterclockwise as GeoJSON expects of an exterior ring.
+// Each point is the spherical destination from the center, so it is exactly radiusMeters away.
+func Circle(lat, lng, radiusMeters float64, segments int) [][2]float64 {
+	latRad := lat * degreesToRadians
+	lngRad := lng * degreesToRadians
+	angular := radiusMeters / EarthRadiusMeters
+	sinLat, cosLat := math.Sin(latRad), math.Cos(latRad)
+	sinAngular, cosAngular := math.Sin(angular), math.Cos(angular)
+
+	ring := make([][2]float64, 0, segments+1)
+	for i := 0; i < segments; i++ {
+		// Bearings run clockwise from north, stepping them backwards walks the ring counterclockwise
+		bearing := -2 * math.Pi * float64(i) / float64(segments)
+		pointLat := math.Asin(sinLat*cosAngular + cosLat*sinAngular*math.Cos(bearing))
+		pointLng := lngRad + math.Atan2(math.Sin(bearing)*sinAngular*cosLat, cosAngular-sinLat*math.Sin(pointLat))
+		// Normalize the longitude to [-180, 180)
+		pointLng = math.Mod(pointLng+3*math.Pi, 2*math.Pi) - math.Pi
+		ring = append(ring, [2]float64{pointLng / degreesToRadians, pointLat / degreesToRadians})
+	}
+	return append(ring, ring[0])
+}
+
+// BoundingBox returns the smallest latitude and longitude box containing the circle of radiusMeters
+// around lat, lng. Longitudes are normalized to [-180, 180), so west is greater than east when the box
+// crosses the antimeridian, and a circle reaching a pole spans every longitude.
+func BoundingBox(lat, lng, radiusMeters float64) (south, west, north, east float64) {
+	angular := radiusMeters / EarthRadiusMeters
+	latRad := lat * degreesToRadians
+	south = math.Max(latRad-angular, -math.Pi/2) / degreesToRadians
+	north = math.Min(latRad+angular, math.Pi/2) / degreesToRadians
+	if latRad+angular >= math.Pi/2 || latRad-angular <= -math.Pi/2 || angular >= math.Pi/2 {
+		return south, -180, north, 180
+	}
+
+	// The meridians tangent to the circle bound its longitudes
+	deltaLng := math.Asin(math.Sin(angular)/math.Cos(latRad)) / degreesToRadians
+	west = math.Mod(lng-deltaLng+540, 360) - 180
+	east = math.Mod(lng+deltaLng+540, 360) - 180
+	return south, west, north, east
+}
@@ -0,0 +1,186 @@
+package geo_test
+
+import (
+	"address-validator/geo"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPlusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		lat  float64
+		lng  float64
+		want string
+	}{
+		{name: "Test Reference Cell Returns Code", lat: 20.3700625, lng: 2.7821875, want: "7FG49QCJ+2V"},
+		{name: "Test Switzerland Returns Code", lat: 47.0000625, lng: 8.0000625, want: "8FVC2222+22"},
+		{name: "Test Southern Hemisphere Returns Code", lat: -41.2730625, lng: 174.7859375, want: "4VCPPQGP+Q9"},
+		{name: "Test South West Corner Returns Code", lat: -89.9999375, lng: -179.9999375, want: "22222222+22"},
+		{name: "Test North Pole Is Clipped", lat: 90, lng: 1, want: "CFX3X2X2+X2"},
+		{name: "Test Longitude Wraps Around", lat: 47.0000625, lng: 368.0000625, want: "8FVC2222+22"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := geo.PlusCode(tt.lat, tt.lng); got != tt.want {
+				t.Errorf("PlusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToUTM(t *testing.T) {
+	tests := []struct {
+		name    string
+		lat     float64
+		lng     float64
+		want    geo.UTM
+		wantErr error
+	}{
+		{
+			name: "Test Equator On Central Meridian Returns False Easting",
+			lat:  0, lng: 3,
+			want: geo.UTM{Zone: 31, Band: "N", Easting: 500000, Northing: 0},
+		},
+		{
+			name: "Test CN Tower Returns Known Grid Position",
+			lat:  43.6425667, lng: -79.3871389,
+			want: geo.UTM{Zone: 17, Band: "T", Easting: 630084, Northing: 4833439},
+		},
+		{
+			// Mirroring the CN Tower across the equator leaves the easting and reflects the northing
+			name: "Test Southern Hemisphere Adds False Northing",
+			lat:  -43.6425667, lng: -79.3871389,
+			want: geo.UTM{Zone: 17, Band: "G", Easting: 630084, Northing: 10000000 - 4833438.55},
+		},
+		{
+			name: "Test Polar Latitude Returns Error",
+			lat:  85, lng: 0,
+			wantErr: geo.ErrOutsideUTM,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geo.ToUTM(tt.lat, tt.lng)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ToUTM() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.Zone != tt.want.Zone || got.Band != tt.want.Band {
+				t.Errorf("ToUTM() zone = %d%s, want %d%s", got.Zone, got.Band, tt.want.Zone, tt.want.Band)
+			}
+			// Grid positions are compared to the metre
+			if math.Abs(got.Easting-tt.want.Easting) > 1 || math.Abs(got.Northing-tt.want.Northing) > 1 {
+				t.Errorf("ToUTM() = %.0fE %.0fN, want %.0fE %.0fN", got.Easting, got.Northing, tt.want.Easting, tt.want.Northing)
+			}
+		})
+	}
+}
+
+func TestToUTM_Zone(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat      float64
+		lng      float64
+		wantZone int
+		wantBand string
+	}{
+		{name: "Test Bronx Returns 18T", lat: 40.8313747, lng: -73.8272283, wantZone: 18, wantBand: "T"},
+		{name: "Test Norway Exception Returns Zone 32", lat: 60, lng: 5, wantZone: 32, wantBand: "V"},
+		{name: "Test Svalbard Exception Returns Zone 33", lat: 78, lng: 15, wantZone: 33, wantBand: "X"},
+		{name: "Test Band X Extends To 84N", lat: 83.9, lng: -40, wantZone: 24, wantBand: "X"},
+		{name: "Test Southern Limit Returns Band C", lat: -80, lng: 0, wantZone: 31, wantBand: "C"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geo.ToUTM(tt.lat, tt.lng)
+			if err != nil {
+				t.Fatalf("ToUTM() error = %v", err)
+			}
+			if got.Zone != tt.wantZone || got.Band != tt.wantBand {
+				t.Errorf("ToUTM() zone = %d%s, want %d%s", got.Zone, got.Band, tt.wantZone, tt.wantBand)
+			}
+		})
+	}
+}
+
+func TestCircle(t *testing.T) {
+	tests := []struct {
+		name         string
+		lat          float64
+		lng          float64
+		radiusMeters float64
+		segments     int
+	}{
+		{name: "Test Bronx Two Mile Circle", lat: 40.8313747, lng: -73.8272283, radiusMeters: 2 * geo.MetersPerMile, segments: 64},
+		{name: "Test Equator Small Circle", lat: 0, lng: 0, radiusMeters: 500, segments: 8},
+		{name: "Test Circle Across Antimeridian", lat: -17.7, lng: 179.99, radiusMeters: 5000, segments: 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := geo.Circle(tt.lat, tt.lng, tt.radiusMeters, tt.segments)
+
+			if len(ring) != tt.segments+1 {
+				t.Fatalf("Circle() has %d points, want %d", len(ring), tt.segments+1)
+			}
+			if ring[0] != ring[len(ring)-1] {
+				t.Errorf("Circle() ring is not closed: first %v, last %v", ring[0], ring[len(ring)-1])
+			}
+			for _, point := range ring {
+				if point[0] < -180 || point[0] >= 180 {
+					t.Errorf("Circle() longitude %v out of [-180, 180)", point[0])
+				}
+				if got := geo.HaversineMeters(point[1], point[0], tt.lat, tt.lng); math.Abs(got-tt.radiusMeters) > 1e-6*tt.radiusMeters {
+					t.Errorf("Circle() point %v is %v m from center, want %v", point, got, tt.radiusMeters)
+				}
+			}
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name         string
+		lat          float64
+		lng          float64
+		radiusMeters float64
+		wantWrap     bool
+		wantPolar    bool
+	}{
+		{name: "Test Bronx Two Mile Circle", lat: 40.8313747, lng: -73.8272283, radiusMeters: 2 * geo.MetersPerMile},
+		{name: "Test Equator Small Circle", lat: 0, lng: 0, radiusMeters: 500},
+		{name: "Test Box Across Antimeridian", lat: -17.7, lng: 179.99, radiusMeters: 5000, wantWrap: true},
+		{name: "Test Circle Around Pole Spans Every Longitude", lat: 89.99, lng: 10, radiusMeters: 5000, wantPolar: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			south, west, north, east := geo.BoundingBox(tt.lat, tt.lng, tt.radiusMeters)
+
+			if tt.wantPolar {
+				if west != -180 || east != 180 || north != 90 {
+					t.Errorf("BoundingBox() = %v, %v, %v, %v, want every longitude up to the pole", south, west, north, east)
+				}
+				return
+			}
+			if (west > east) != tt.wantWrap {
+				t.Errorf("BoundingBox() west %v, east %v, want wrapping %v", west, east, tt.wantWrap)
+			}
+			// Every point of the circle is inside the box and the box touches the circle on each side
+			minLat, maxLat, minLngOffset, maxLngOffset := 90.0, -90.0, 180.0, -180.0
+			for _, point := range geo.Circle(tt.lat, tt.lng, tt.radiusMeters, 3600) {
+				minLat, maxLat = math.Min(minLat, point[1]), math.Max(maxLat, point[1])
+				offset := math.Mod(point[0]-tt.lng+540, 360) - 180
+				minLngOffset, maxLngOffset = math.Min(minLngOffset, offset), math.Max(maxLngOffset, offset)
+			}
+			westOffset := math.Mod(west-tt.lng+540, 360) - 180
+			eastOffset := math.Mod(east-tt.lng+540, 360) - 180
+			const tolerance = 1e-4
+			if math.Abs(south-minLat) > tolerance || math.Abs(north-maxLat) > tolerance {
+				t.Errorf("BoundingBox() latitudes %v to %v, want %v to %v", south, north, minLat, maxLat)
+			}
+			if math.Abs(westOffset-minLngOffset) > tolerance || math.Abs(eastOffset-maxLngOffset) > tolerance {
+				t.Errorf("BoundingBox() longitude offsets %v to %v, want %v to %v", westOffset, eastOffset, minLngOffset, maxLngOffset)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package geo
+
+import (
+	"math"
+	"strings"
+)
+
+// plusCodeAlphabet is the Open Location Code digit set, chosen to avoid spelling words
+const plusCodeAlphabet = "23456789CFGHJMPQRVWX"
+
+const (
+	plusCodeBase      = 20
+	plusCodePairs     = 5
+	plusCodeSeparator = "+"
+	// plusCodePrecision is the number of 10-digit code cells per degree (1/0.000125)
+	plusCodePrecision = 8000
+)
+
+// PlusCode encodes a coordinate as a 10-digit global Open Location Code, roughly a 14m square
+func PlusCode(lat, lng float64) string {
+	// Clip latitude and normalize longitude into the encodable range
+	lat = math.Max(-90, math.Min(90, lat))
+	for lng < -180 {
+		lng += 360
+	}
+	for lng >= 180 {
+		lng -= 360
+	}
+
+	// Work in integer cells to avoid floating point drift between digits.
+	// Rounding to 1e-6 of a cell absorbs representation error like 20.3700625*8000.
+	latVal := int64(math.Floor(math.Round((lat+90)*plusCodePrecision*1e6) / 1e6))
+	lngVal := int64(math.Floor(math.Round((lng+180)*plusCodePrecision*1e6) / 1e6))
+
+	// The north pole belongs to the cell just below it
+	if maxLat := int64(180 * plusCodePrecision); latVal >= maxLat {
+		latVal = maxLat - 1
+	}
+
+	code := make([]byte, plusCodePairs*2)
+	for i := plusCodePairs - 1; i >= 0; i-- {
+		code[i*2] = plusCodeAlphabet[latVal%plusCodeBase]
+		code[i*2+1] = plusCodeAlphabet[lngVal%plusCodeBase]
+		latVal /= plusCodeBase
+		lngVal /= plusCodeBase
+	}
+
+	var sb strings.Builder
+	sb.Write(code[:8])
+	sb.WriteString(plusCodeSeparator)
+	sb.Write(code[8:])
+	return sb.String()
+}
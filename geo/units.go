@@ -0,0 +1,102 @@
+package geo
+
+import (
+	"math"
+	"strings"
+)
+
+// Distance units
+const (
+	UnitKilometers = "km"
+	UnitMiles      = "mi"
+	UnitMeters     = "m"
+)
+
+const (
+	MetersPerKilometer = 1000.0
+	MetersPerMile      = 1609.344
+	// EarthRadiusMeters is the mean radius of the Earth
+	EarthRadiusMeters = 6371000.0
+)
+
+// KmToMiles converts kilometers to miles
+func KmToMiles(km float64) float64 {
+	return km * MetersPerKilometer / MetersPerMile
+}
+
+// MilesToKm converts miles to kilometers
+func MilesToKm(mi float64) float64 {
+	return mi * MetersPerMile / MetersPerKilometer
+}
+
+// ToMeters converts a distance in unit to meters, unknown units are treated as kilometers
+func ToMeters(value float64, unit string) float64 {
+	switch strings.ToLower(unit) {
+	case UnitMeters:
+		return value
+	case UnitMiles:
+		return value * MetersPerMile
+	default:
+		return value * MetersPerKilometer
+	}
+}
+
+// FromMeters converts meters to a distance in unit, unknown units are treated as kilometers
+func FromMeters(meters float64, unit string) float64 {
+	switch strings.ToLower(unit) {
+	case UnitMeters:
+		return meters
+	case UnitMiles:
+		return meters / MetersPerMile
+	default:
+		return meters / MetersPerKilometer
+	}
+}
+
+// degreesToRadians is the factor converting degrees to radians
+const degreesToRadians = math.Pi / 180.0
+
+// HaversineMeters returns the great-circle distance in meters between two coordinates
+func HaversineMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	// Convert latitude and longitude from degrees to radians
+	lat1Rad := lat1 * degreesToRadians
+	lng1Rad := lng1 * degreesToRadians
+	lat2Rad := lat2 * degreesToRadians
+	lng2Rad := lng2 * degreesToRadians
+
+	return haversine(lat1Rad, lng1Rad, math.Cos(lat1Rad), lat2Rad, lng2Rad, math.Cos(lat2Rad))
+}
+
+// Center is a fixed point with its radians and latitude cosine computed once,
+// for measuring many coordinates against the same geofence center
+type Center struct {
+	latRad float64
+	lngRad float64
+	cosLat float64
+}
+
+// NewCenter precomputes the center at lat, lng in degrees
+func NewCenter(lat, lng float64) Center {
+	latRad := lat * degreesToRadians
+	return Center{
+		latRad: latRad,
+		lngRad: lng * degreesToRadians,
+		cosLat: math.Cos(latRad),
+	}
+}
+
+// MetersFrom returns the same distance as HaversineMeters(lat, lng, centerLat, centerLng)
+func (c Center) MetersFrom(lat, lng float64) float64 {
+	latRad := lat * degreesToRadians
+	return haversine(latRad, lng*degreesToRadians, math.Cos(latRad), c.latRad, c.lngRad, c.cosLat)
+}
+
+// haversine is the Haversine formula over coordinates already in radians
+func haversine(lat1Rad, lng1Rad, cosLat1, lat2Rad, lng2Rad, cosLat2 float64) float64 {
+	dLat := lat2Rad - lat1Rad
+	dLng := lng2Rad - lng1Rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + cosLat1*cosLat2*math.Sin(dLng/2)*math.Sin(dLng/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return EarthRadiusMeters * c
+}
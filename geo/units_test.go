@@ -0,0 +1,106 @@
+package geo_test
+
+import (
+	"address-validator/geo"
+	"math"
+	"testing"
+)
+
+// almostEqual compares floats to within a micrometer
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{name: "Test KmToMiles Converts One Mile", got: geo.KmToMiles(1.609344), want: 1},
+		{name: "Test MilesToKm Converts One Mile", got: geo.MilesToKm(1), want: 1.609344},
+		{name: "Test ToMeters Converts Kilometers", got: geo.ToMeters(2, geo.UnitKilometers), want: 2000},
+		{name: "Test ToMeters Converts Miles", got: geo.ToMeters(2, geo.UnitMiles), want: 3218.688},
+		{name: "Test ToMeters Keeps Meters", got: geo.ToMeters(2, geo.UnitMeters), want: 2},
+		{name: "Test ToMeters Is Case Insensitive", got: geo.ToMeters(2, "MI"), want: 3218.688},
+		{name: "Test ToMeters Defaults To Kilometers", got: geo.ToMeters(2, "furlongs"), want: 2000},
+		{name: "Test FromMeters Converts Kilometers", got: geo.FromMeters(2000, geo.UnitKilometers), want: 2},
+		{name: "Test FromMeters Converts Miles", got: geo.FromMeters(3218.688, geo.UnitMiles), want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !almostEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConversions_RoundTrip(t *testing.T) {
+	for _, value := range []float64{0, 0.5, 1, 2, 123.456, 40075} {
+		if got := geo.MilesToKm(geo.KmToMiles(value)); !almostEqual(got, value) {
+			t.Errorf("MilesToKm(KmToMiles(%v)) = %v", value, got)
+		}
+		for _, unit := range []string{geo.UnitKilometers, geo.UnitMiles, geo.UnitMeters} {
+			if got := geo.FromMeters(geo.ToMeters(value, unit), unit); !almostEqual(got, value) {
+				t.Errorf("FromMeters(ToMeters(%v, %s)) = %v", value, unit, got)
+			}
+		}
+	}
+}
+
+func TestHaversineMeters(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+	}{
+		{name: "Test Same Point Returns Zero", lat1: 40.8, lng1: -73.8, lat2: 40.8, lng2: -73.8, want: 0},
+		{name: "Test One Degree Of Latitude", lat1: 0, lng1: 0, lat2: 1, lng2: 0, want: 111194.93},
+		{name: "Test Quarter Of Equator", lat1: 0, lng1: 0, lat2: 0, lng2: 90, want: math.Pi / 2 * geo.EarthRadiusMeters},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := geo.HaversineMeters(tt.lat1, tt.lng1, tt.lat2, tt.lng2); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("HaversineMeters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCenter_MetersFrom(t *testing.T) {
+	const centerLat, centerLng = 40.8313747, -73.8272283
+
+	tests := []struct {
+		name     string
+		lat, lng float64
+	}{
+		{name: "Test Center Itself Matches", lat: centerLat, lng: centerLng},
+		{name: "Test Nearby Point Matches", lat: 40.8448, lng: -73.8648},
+		{name: "Test Distant Point Matches", lat: 42.3601, lng: -71.0589},
+		{name: "Test Opposite Hemisphere Matches", lat: -33.8688, lng: 151.2093},
+	}
+	center := geo.NewCenter(centerLat, centerLng)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Precomputing the center must not change a single bit of the result
+			want := geo.HaversineMeters(tt.lat, tt.lng, centerLat, centerLng)
+			if got := center.MetersFrom(tt.lat, tt.lng); got != want {
+				t.Errorf("Center.MetersFrom() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkHaversineMeters(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		geo.HaversineMeters(40.8448, -73.8648, 40.8313747, -73.8272283)
+	}
+}
+
+func BenchmarkCenter_MetersFrom(b *testing.B) {
+	center := geo.NewCenter(40.8313747, -73.8272283)
+	for i := 0; i < b.N; i++ {
+		center.MetersFrom(40.8448, -73.8648)
+	}
+}
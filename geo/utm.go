@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrOutsideUTM is returned for latitudes outside the UTM system's 80°S to 84°N coverage
+var ErrOutsideUTM = errors.New("latitude outside UTM coverage")
+
+// WGS84 ellipsoid parameters used by UTM
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+	utmScaleFactor     = 0.9996
+	utmFalseEasting    = 500000.0
+	utmFalseNorthing   = 10000000.0
+)
+
+// utmBands are the latitude band letters from 80°S northwards, X covers 72°N to 84°N
+const utmBands = "CDEFGHJKLMNPQRSTUVWXX"
+
+// UTM is a Universal Transverse Mercator grid position
+type UTM struct {
+	Zone     int     `json:"zone"`
+	Band     string  `json:"band"`
+	Easting  float64 `json:"easting"`
+	Northing float64 `json:"northing"`
+}
+
+// ToUTM converts a WGS84 coordinate to UTM using the Snyder series expansion
+func ToUTM(lat, lng float64) (UTM, error) {
+	if lat < -80 || lat > 84 {
+		return UTM{}, ErrOutsideUTM
+	}
+
+	zone := utmZone(lat, lng)
+	centralMeridian := float64((zone-1)*6-180+3) * math.Pi / 180
+
+	phi := lat * math.Pi / 180
+	lambda := lng * math.Pi / 180
+
+	e2 := wgs84Flattening * (2 - wgs84Flattening)
+	e4 := e2 * e2
+	e6 := e4 * e2
+	ep2 := e2 / (1 - e2)
+
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	n := wgs84SemiMajorAxis / math.Sqrt(1-e2*sinPhi*sinPhi)
+	t := math.Tan(phi) * math.Tan(phi)
+	c := ep2 * cosPhi * cosPhi
+	a := cosPhi * (lambda - centralMeridian)
+
+	// Meridional arc length from the equator
+	m := wgs84SemiMajorAxis * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+
+	easting := utmScaleFactor*n*(a+
+		(1-t+c)*math.Pow(a, 3)/6+
+		(5-18*t+t*t+72*c-58*ep2)*math.Pow(a, 5)/120) + utmFalseEasting
+
+	northing := utmScaleFactor * (m + n*math.Tan(phi)*(a*a/2+
+		(5-t+9*c+4*c*c)*math.Pow(a, 4)/24+
+		(61-58*t+t*t+600*c-330*ep2)*math.Pow(a, 6)/720))
+	if lat < 0 {
+		northing += utmFalseNorthing
+	}
+
+	return UTM{
+		Zone:     zone,
+		Band:     string(utmBands[int(math.Floor((lat+80)/8))]),
+		Easting:  easting,
+		Northing: northing,
+	}, nil
+}
+
+// utmZone returns the UTM zone including the Norway and Svalbard exceptions
+func utmZone(lat, lng float64) int {
+	if lng >= 180 {
+		lng -= 360
+	}
+
+	if lat >= 56 && lat < 64 && lng >= 3 && lng < 12 {
+		return 32
+	}
+
+	if lat >= 72 && lat <= 84 && lng >= 0 && lng < 42 {
+		switch {
+		case lng < 9:
+			return 31
+		case lng < 21:
+			return 33
+		case lng < 33:
+			return 35
+		default:
+			return 37
+		}
+	}
+
+	return int(math.Floor((lng+180)/6)) + 1
+}
@@ -1,11 +1,19 @@
 package handlers
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"address-validator/config"
+	"address-validator/ports"
 	"address-validator/services"
+	"address-validator/timing"
 
 	"go.uber.org/zap"
 )
@@ -13,79 +21,345 @@ import (
 // AddressRequest represents the incoming request for address validation
 type AddressRequest struct {
 	Address string `json:"address"`
+	// Street, City, State and PostalCode submit the address as components instead of a single line
+	Street     string `json:"street,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postalCode,omitempty"`
+	// Latitude and Longitude submit already-geocoded coordinates instead of an address
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	// CheckGeofence defaults to true when omitted
+	CheckGeofence *bool `json:"checkGeofence,omitempty"`
+	// RegionCode resolves the address in another region than the configured country
+	RegionCode string `json:"regionCode,omitempty"`
+	// Locality resolves the address in another locality than the configured one. A City component is
+	// already part of the address line and does not replace it
+	Locality string `json:"locality,omitempty"`
+	// Profile selects a configured validation profile, empty applies the default one
+	Profile string `json:"profile,omitempty"`
+	// Fields is a comma-separated list of the result fields to respond with, empty responds with all
+	Fields string `json:"fields,omitempty"`
+}
+
+// ErrMixedSubmission is returned when a request has both an address and coordinates, or half of the coordinates
+var ErrMixedSubmission = errors.New("submit either an address or both latitude and longitude")
+
+// ErrMixedAddress is returned when a request has both an address line and address components
+var ErrMixedAddress = errors.New("submit either an address or its street, city, state and postalCode")
+
+// joinComponents fills the address line from the submitted components in the order of a US address line,
+// "street, city, state postalCode", so they validate and cache like the same address sent as a line
+func (req *AddressRequest) joinComponents() error {
+	if req.Street == "" && req.City == "" && req.State == "" && req.PostalCode == "" {
+		return nil
+	}
+	if req.Address != "" {
+		return ErrMixedAddress
+	}
+
+	region := strings.TrimSpace(strings.TrimSpace(req.State) + " " + strings.TrimSpace(req.PostalCode))
+	var parts []string
+	for _, part := range []string{req.Street, req.City, region} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	req.Address = strings.Join(parts, ", ")
+	return nil
+}
+
+// hasCoordinates reports whether the request submits coordinates, checking the address and
+// coordinates are mutually exclusive
+func (req AddressRequest) hasCoordinates() (bool, error) {
+	if req.Latitude == nil && req.Longitude == nil {
+		return false, nil
+	}
+	if req.Latitude == nil || req.Longitude == nil || req.Address != "" {
+		return false, ErrMixedSubmission
+	}
+	return true, nil
+}
+
+// options converts the request flags to service validation options
+func (req AddressRequest) options() services.ValidationOptions {
+	return services.ValidationOptions{
+		SkipGeofence: req.CheckGeofence != nil && !*req.CheckGeofence,
+		Profile:      req.Profile,
+	}
 }
 
 // AddressHandler handles HTTP requests for address validation
 type AddressHandler struct {
 	service     *services.AddressService
-	rateLimiter *RateLimiter
+	rateLimiter Limiter
+	dailyQuota  *DailyQuota
+	clientIP    *ClientIPResolver
 	logger      *zap.Logger
 	config      config.InfraConfig
 }
 
 // NewAddressHandler creates a new address handler
-func NewAddressHandler(service *services.AddressService, rateLimiter *RateLimiter, config config.InfraConfig, logger *zap.Logger) *AddressHandler {
+func NewAddressHandler(service *services.AddressService, rateLimiter Limiter, config config.InfraConfig, logger *zap.Logger) *AddressHandler {
 
 	return &AddressHandler{
 		service:     service,
 		rateLimiter: rateLimiter,
+		clientIP:    NewClientIPResolver(config),
 		logger:      logger,
 		config:      config,
 	}
 }
 
+// SetDailyQuota sets the per-client daily cap checked after the rate limit, nil disables it
+func (h *AddressHandler) SetDailyQuota(dailyQuota *DailyQuota) {
+	h.dailyQuota = dailyQuota
+}
+
 // ValidateAddress handles the address validation endpoint
 func (h *AddressHandler) ValidateAddress(w http.ResponseWriter, r *http.Request) {
 	// Set content type
 	w.Header().Set("Content-Type", "application/json")
 
-	// Only allow POST requests for edge-cases where a user can add special characters like # for apts
-	if r.Method != http.MethodPost {
+	// POST is preferred for edge-cases where a user can add special characters like # for apts.
+	// GET with an encoded address query parameter lets caches issue conditional requests.
+	if r.Method != http.MethodPost && r.Method != http.MethodGet {
 		h.logger.Warn("method not allowed", zap.String("method", r.Method))
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
 	// Only allow HTTPS
-	if h.config.IsHttpSecure && r.TLS == nil {
+	if h.config.IsHttpSecure && !isHTTPS(r, h.config) {
 		h.logger.Warn("HTTPS required")
 		http.Error(w, "HTTPS required", http.StatusBadRequest)
 		return
 	}
 
-	// Get client IP for rate limiting
-	clientIP := r.RemoteAddr
-	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
-		clientIP = forwardedFor
+	// Collect phase durations for the Server-Timing header
+	ctx := r.Context()
+	var timings *timing.Timings
+	if h.config.ServerTiming {
+		ctx, timings = timing.NewContext(ctx)
 	}
 
+	// Stop work at the client's deadline, bounded by the server maximum
+	if timeout, ok := h.requestTimeout(r); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Get client IP for rate limiting, known tenants are limited on top of it
+	clientIP := h.clientIP.ClientIP(r)
+	limitKeys := rateLimitKeys(r, clientIP, h.config.Tenants)
+
 	// Check rate limit
-	if !h.rateLimiter.Allow(clientIP) {
-		h.logger.Warn("rate limit exceeded", zap.String("ip", clientIP))
+	stopRateLimit := timing.Track(ctx, "ratelimit")
+	allowed := allowKeys(h.rateLimiter, limitKeys)
+	stopRateLimit()
+	if !allowed {
+		h.logger.Warn("rate limit exceeded", zap.String("ip", clientIP), zap.String("tenant", ports.Tenant(ctx)))
 		http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
 		return
 	}
+	if h.dailyQuota != nil {
+		if allowed, retryAfter := h.dailyQuota.AllowN(limitKeys, 1); !allowed {
+			h.logger.Warn("daily quota exceeded", zap.String("ip", clientIP), zap.String("tenant", ports.Tenant(ctx)))
+			writeDailyQuotaExceeded(w, retryAfter, h.config.JSONFieldCase)
+			return
+		}
+	}
 
-	// Parse request body
+	// Parse request body, or the query string for GET
 	var req AddressRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Address = query.Get("address")
+		req.Street = query.Get("street")
+		req.City = query.Get("city")
+		req.State = query.Get("state")
+		req.PostalCode = query.Get("postalCode")
+		if latitude, err := strconv.ParseFloat(query.Get("latitude"), 64); err == nil {
+			req.Latitude = &latitude
+		}
+		if longitude, err := strconv.ParseFloat(query.Get("longitude"), 64); err == nil {
+			req.Longitude = &longitude
+		}
+		if checkGeofence, err := strconv.ParseBool(query.Get("checkGeofence")); err == nil {
+			req.CheckGeofence = &checkGeofence
+		}
+		req.RegionCode = query.Get("regionCode")
+		req.Locality = query.Get("locality")
+		req.Profile = query.Get("profile")
+		req.Fields = query.Get("fields")
+	} else if err := decodeRequest(r.Body, &req); err != nil {
 		h.logger.Warn("invalid request body", zap.Error(err))
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeBadRequest(w, err, h.config.JSONFieldCase)
 		return
 	}
 
-	// Validate address using the service
-	result, err := h.service.ValidateAddress(r.Context(), req.Address)
+	if err := req.joinComponents(); err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		http.Error(w, "Invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	// Return response with appropriate status code
+	isCoordinates, err := req.hasCoordinates()
+	if err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		http.Error(w, "Invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fields, err := parseFields(req.Fields)
+	if err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		http.Error(w, "Invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	regionCode, err := h.requestRegionCode(r, req)
 	if err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		http.Error(w, "Invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if regionCode != "" {
+		ctx = ports.WithRegionCode(ctx, regionCode)
+	}
+	if locality := strings.TrimSpace(req.Locality); locality != "" {
+		ctx = ports.WithLocality(ctx, locality)
+	}
+
+	// Support engineers can ask for the untouched provider payload
+	var capture *ports.RawCapture
+	if h.rawRequested(r) {
+		capture = &ports.RawCapture{}
+		ctx = ports.WithRawCapture(ctx, capture)
+	}
+
+	// Validate the address, or resolve the submitted coordinates, using the service
+	var result ports.AddressValidationResult
+	if isCoordinates {
+		result, err = h.service.ValidateCoordinates(ctx, *req.Latitude, *req.Longitude, req.options())
+	} else {
+		result, err = h.service.ValidateAddress(ctx, req.Address, req.options())
+	}
+
+	if timings != nil {
+		w.Header().Set("Server-Timing", timings.Header())
+	}
+
+	// Return response with appropriate status code
+	status := http.StatusOK
+	if errors.Is(err, ports.ErrProviderTimeout) {
+		h.logger.Warn("address validation provider timed out", zap.Error(err))
+		status = http.StatusGatewayTimeout
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		h.logger.Warn("address validation timed out", zap.Error(err))
+		result.Error = "Request timed out."
+		result.ErrorCode = ports.ERROR_CODE_REQUEST_TIMEOUT
+		status = http.StatusGatewayTimeout
+	} else if errors.Is(err, ports.ErrQuotaExhausted) || errors.Is(err, ports.ErrUpstreamUnavailable) || errors.Is(err, ports.ErrProviderQuota) {
+		h.logger.Warn("address validation unavailable", zap.Error(err))
+		status = http.StatusServiceUnavailable
+	} else if errors.Is(err, ports.ErrProviderDenied) {
+		h.logger.Error("address validation denied by provider", zap.Error(err))
+		status = http.StatusBadGateway
+	} else if errors.Is(err, services.ErrEmptyAddress) {
+		h.logger.Warn("address validation refused empty address")
+		status = http.StatusUnprocessableEntity
+	} else if err != nil {
 		h.logger.Warn("address validation failed", zap.Error(err))
-		w.WriteHeader(http.StatusBadRequest)
+		status = http.StatusBadRequest
 	}
-	// Encode response
-	if err := json.NewEncoder(w).Encode(result); err != nil {
+	// Encode response, cache hits never reached the provider so they carry no raw response
+	var body bytes.Buffer
+	raw := capture.Raw()
+	if raw != nil {
+		err = encodeJSONWithRaw(&body, projectResult(result, fields), h.config.JSONFieldCase, raw)
+	} else {
+		err = encodeJSON(&body, projectResult(result, fields), h.config.JSONFieldCase)
+	}
+	if err != nil {
 		h.logger.Error("failed to encode response", zap.Error(err))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+
+	// Successful results are tagged by the bytes sent, so any field, projection or casing change retags them
+	if status == http.StatusOK {
+		etag := bodyETag(body.Bytes())
+		// Debug fields differ on every request, the tag then covers the result without them and is weak
+		if result.ElapsedMs != 0 || raw != nil {
+			stable := result
+			stable.ElapsedMs = 0
+			var tagged bytes.Buffer
+			if err := encodeJSON(&tagged, projectResult(stable, fields), h.config.JSONFieldCase); err == nil {
+				etag = "W/" + bodyETag(tagged.Bytes())
+			}
+		}
+		w.Header().Set("ETag", etag)
+
+		if r.Method == http.MethodGet && etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.WriteHeader(status)
+	w.Write(body.Bytes())
+}
+
+// isHTTPS reports whether the request reached the service over TLS, either directly or through
+// the terminating proxy. Only the proxy can reach a server behind one, so its header is trusted.
+func isHTTPS(r *http.Request, config config.InfraConfig) bool {
+	if r.TLS != nil {
+		return true
+	}
+	return config.BehindTLSProxy && strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// RAW_RESPONSE_HEADER asks for the raw provider response under "_raw", with the debug bearer token
+const RAW_RESPONSE_HEADER = "X-Debug-Raw"
+
+// rawRequested reports whether the request asks for the raw provider response and is allowed to.
+// Without a configured debug token the header is always ignored.
+func (h *AddressHandler) rawRequested(r *http.Request) bool {
+	if r.Header.Get(RAW_RESPONSE_HEADER) != "true" {
+		return false
+	}
+	if h.config.DebugToken == "" {
+		return false
+	}
+	want := []byte("Bearer " + h.config.DebugToken)
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+		h.logger.Warn("raw response requested without a valid debug token")
+		return false
+	}
+	return true
+}
+
+// REQUEST_TIMEOUT_HEADER carries the client deadline in milliseconds
+const REQUEST_TIMEOUT_HEADER = "X-Request-Timeout"
+
+// requestTimeout reads the client deadline, capped at the configured maximum.
+// A missing or invalid header leaves the request without a deadline of its own.
+func (h *AddressHandler) requestTimeout(r *http.Request) (time.Duration, bool) {
+	input := r.Header.Get(REQUEST_TIMEOUT_HEADER)
+	if input == "" {
+		return 0, false
+	}
+
+	ms, err := strconv.Atoi(input)
+	if err != nil || ms <= 0 {
+		h.logger.Debug("ignoring invalid request timeout", zap.String("input", input))
+		return 0, false
+	}
+
+	timeout := time.Duration(ms) * time.Millisecond
+	if h.config.MaxRequestTimeout > 0 && timeout > h.config.MaxRequestTimeout {
+		timeout = h.config.MaxRequestTimeout
+	}
+	return timeout, true
 }
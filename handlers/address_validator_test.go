@@ -0,0 +1,667 @@
+package handlers_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// stubValidator returns a fixed result for every address
+type stubValidator struct {
+	result ports.AddressValidationResult
+}
+
+func (s stubValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	return s.result, nil
+}
+
+func newTestHandler(result ports.AddressValidationResult) *handlers.AddressHandler {
+	return newTestHandlerWithConfig(result, config.InfraConfig{})
+}
+
+func newTestHandlerWithConfig(result ports.AddressValidationResult, infraConfig config.InfraConfig) *handlers.AddressHandler {
+	logger := zap.NewNop()
+	service := services.NewAddressService(stubValidator{result: result}, logger, config.MapConfig{}, config.ValidationConfig{})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	return handlers.NewAddressHandler(service, rateLimiter, infraConfig, logger)
+}
+
+func TestAddressHandler_ValidateAddress_ETag(t *testing.T) {
+	h := newTestHandler(ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+		Latitude:         40.8448,
+		Longitude:        -73.8648,
+	})
+	target := "/validate?address=" + url.QueryEscape("123 Main St, Bronx, NY")
+
+	// First request returns the result with an ETag
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	h.ValidateAddress(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("first request status = %v, want %v", w.Code, http.StatusOK)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("first request returned no ETag")
+	}
+	if w.Body.Len() == 0 {
+		t.Error("first request returned an empty body")
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "Test Matching ETag Returns 304", ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "Test Weak Matching ETag Returns 304", ifNoneMatch: "W/" + etag, wantStatus: http.StatusNotModified},
+		{name: "Test ETag In List Returns 304", ifNoneMatch: `"stale", ` + etag, wantStatus: http.StatusNotModified},
+		{name: "Test Gzip ETag Returns 304", ifNoneMatch: "W/" + strings.TrimSuffix(etag, `"`) + `-gzip"`, wantStatus: http.StatusNotModified},
+		{name: "Test Stale ETag Returns 200", ifNoneMatch: `"stale"`, wantStatus: http.StatusOK},
+		{name: "Test Missing If-None-Match Returns 200", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, target, nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("ETag"); got != etag {
+				t.Errorf("AddressHandler.ValidateAddress() ETag = %v, want %v", got, etag)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("AddressHandler.ValidateAddress() 304 body = %q, want empty", w.Body.String())
+			}
+		})
+	}
+
+	// A projection changes the body, so it must change the tag too
+	r = httptest.NewRequest(http.MethodGet, target+"&fields=formattedAddress", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ValidateAddress(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("projected request status = %v, want %v", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("ETag"); got == "" || got == etag {
+		t.Errorf("projected request ETag = %v, want one differing from %v", got, etag)
+	}
+}
+
+// slowingValidator takes longer on every call, so each result reports a different elapsed time
+type slowingValidator struct {
+	calls *atomic.Int32
+}
+
+func (s slowingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	time.Sleep(time.Duration(s.calls.Add(1)) * 5 * time.Millisecond)
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St, Bronx, NY 10456, USA", Latitude: 40.8448, Longitude: -73.8648}, nil
+}
+
+func TestAddressHandler_ValidateAddress_ETagIgnoresDebugFields(t *testing.T) {
+	logger := zap.NewNop()
+	service := services.NewAddressService(slowingValidator{calls: &atomic.Int32{}}, logger, config.MapConfig{}, config.ValidationConfig{DebugFields: true})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+	target := "/validate?address=" + url.QueryEscape("123 Main St, Bronx, NY")
+
+	w := httptest.NewRecorder()
+	h.ValidateAddress(w, httptest.NewRequest(http.MethodGet, target, nil))
+	etag := w.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("AddressHandler.ValidateAddress() ETag = %q with debug fields, want a weak tag", etag)
+	}
+
+	// The elapsed time differs, the result does not
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ValidateAddress(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, http.StatusNotModified)
+	}
+	if got := w.Header().Get("ETag"); got != etag {
+		t.Errorf("AddressHandler.ValidateAddress() ETag = %v, want %v", got, etag)
+	}
+}
+
+func TestAddressHandler_ValidateAddress_ServerTiming(t *testing.T) {
+	entryPattern := regexp.MustCompile(`^([a-z]+);dur=(\d+\.\d+)$`)
+
+	tests := []struct {
+		name       string
+		config     config.InfraConfig
+		wantPhases []string
+	}{
+		{
+			name: "Test Disabled Omits Header",
+		},
+		{
+			name:       "Test Enabled Returns Parsable Phases",
+			config:     config.InfraConfig{ServerTiming: true},
+			wantPhases: []string{"ratelimit", "sanitize", "geocode"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandlerWithConfig(ports.AddressValidationResult{IsValid: true}, tt.config)
+			r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"address": "123 Main St"}`))
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			header := w.Header().Get("Server-Timing")
+			if len(tt.wantPhases) == 0 {
+				if header != "" {
+					t.Errorf("Server-Timing = %q, want no header", header)
+				}
+				return
+			}
+
+			var gotPhases []string
+			for _, entry := range strings.Split(header, ", ") {
+				match := entryPattern.FindStringSubmatch(entry)
+				if match == nil {
+					t.Fatalf("Server-Timing entry %q does not parse", entry)
+				}
+				if _, err := strconv.ParseFloat(match[2], 64); err != nil {
+					t.Errorf("Server-Timing entry %q duration: %v", entry, err)
+				}
+				gotPhases = append(gotPhases, match[1])
+			}
+			if !reflect.DeepEqual(gotPhases, tt.wantPhases) {
+				t.Errorf("Server-Timing phases = %v, want %v", gotPhases, tt.wantPhases)
+			}
+		})
+	}
+}
+
+func TestAddressHandler_ValidateAddress_JSONFieldCase(t *testing.T) {
+	result := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+		Latitude:         40.84,
+		Longitude:        -73.84,
+		ErrorCode:        "EXAMPLE_CODE",
+	}
+
+	tests := []struct {
+		name      string
+		fieldCase string
+		want      string
+	}{
+		{
+			name:      "Test Camel Case Returns Camel Case Keys",
+			fieldCase: config.JSON_CASE_CAMEL,
+			want:      `{"isValid":true,"formattedAddress":"123 Main St, Bronx, NY 10456, USA","latitude":40.84,"longitude":-73.84,"geofenceStatus":"NOT_EVALUATED","error":"","errorCode":"EXAMPLE_CODE"}`,
+		},
+		{
+			name:      "Test Snake Case Returns Snake Case Keys",
+			fieldCase: config.JSON_CASE_SNAKE,
+			want:      `{"is_valid":true,"formatted_address":"123 Main St, Bronx, NY 10456, USA","latitude":40.84,"longitude":-73.84,"geofence_status":"NOT_EVALUATED","error":"","error_code":"EXAMPLE_CODE"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandlerWithConfig(result, config.InfraConfig{JSONFieldCase: tt.fieldCase})
+			r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"address": "123 Main St", "checkGeofence": false}`))
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("AddressHandler.ValidateAddress() body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// failingValidator returns err for every address
+type failingValidator struct {
+	err error
+}
+
+func (f failingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	return ports.AddressValidationResult{IsValid: false, Error: f.err.Error()}, f.err
+}
+
+func TestAddressHandler_ValidateAddress_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "Test Not Found Returns 400", err: ports.ErrAddressNotFound, wantStatus: http.StatusBadRequest},
+		{name: "Test Budget Exhausted Returns 503", err: ports.ErrQuotaExhausted, wantStatus: http.StatusServiceUnavailable},
+		{name: "Test Upstream Unavailable Returns 503", err: ports.ErrUpstreamUnavailable, wantStatus: http.StatusServiceUnavailable},
+		{name: "Test Provider Quota Returns 503", err: fmt.Errorf("address validation error: %w", ports.ErrProviderQuota), wantStatus: http.StatusServiceUnavailable},
+		{name: "Test Provider Denied Returns 502", err: fmt.Errorf("address validation error: %w", ports.ErrProviderDenied), wantStatus: http.StatusBadGateway},
+		{name: "Test Provider Timeout Returns 504", err: fmt.Errorf("address validation error: %w", ports.ErrProviderTimeout), wantStatus: http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(failingValidator{err: tt.err}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+
+			r := httptest.NewRequest(http.MethodGet, "/validate?address="+url.QueryEscape("1 Main St"), nil)
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAddressHandler_ValidateAddress_Profile(t *testing.T) {
+	tests := []struct {
+		name       string
+		profile    string
+		wantStatus int
+		wantValid  bool
+		wantCode   string
+	}{
+		{name: "Test Lenient Profile Accepts Route Level Address", profile: "signup", wantStatus: http.StatusOK, wantValid: true},
+		{name: "Test Strict Profile Rejects Route Level Address", profile: "checkout", wantStatus: http.StatusOK, wantCode: ports.ERROR_CODE_INSUFFICIENT_GRANULARITY},
+		{name: "Test Unknown Profile Returns 400", profile: "wholesale", wantStatus: http.StatusBadRequest, wantCode: ports.ERROR_CODE_UNKNOWN_PROFILE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			result := ports.AddressValidationResult{IsValid: true, FormattedAddress: "Main St, Bronx, NY", Granularity: ports.GRANULARITY_ROUTE}
+			service := services.NewAddressService(stubValidator{result: result}, logger, config.MapConfig{}, config.ValidationConfig{})
+			profiles := map[string]services.ValidationProfile{
+				"signup":   {MinGranularity: ports.GRANULARITY_ROUTE},
+				"checkout": {MinGranularity: ports.GRANULARITY_PREMISE},
+			}
+			if err := service.SetProfiles(profiles, ""); err != nil {
+				t.Fatalf("AddressService.SetProfiles() error = %v", err)
+			}
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+
+			body := `{"address": "Main St, Bronx, NY", "checkGeofence": false, "profile": "` + tt.profile + `"}`
+			r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			var got ports.AddressValidationResult
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.IsValid != tt.wantValid || got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressHandler.ValidateAddress() = %v, %q, want %v, %q", got.IsValid, got.ErrorCode, tt.wantValid, tt.wantCode)
+			}
+		})
+	}
+}
+
+// slowValidator answers after delay unless ctx ends first
+type slowValidator struct {
+	delay time.Duration
+}
+
+func (s slowValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	select {
+	case <-ctx.Done():
+		return ports.AddressValidationResult{IsValid: false}, ctx.Err()
+	case <-time.After(s.delay):
+		return ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St"}, nil
+	}
+}
+
+func TestAddressHandler_ValidateAddress_RequestTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeout    string
+		maxTimeout time.Duration
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "Test Short Timeout Returns 504", timeout: "20", maxTimeout: 10 * time.Second, wantStatus: http.StatusGatewayTimeout, wantCode: ports.ERROR_CODE_REQUEST_TIMEOUT},
+		{name: "Test Generous Timeout Returns 200", timeout: "5000", maxTimeout: 10 * time.Second, wantStatus: http.StatusOK},
+		{name: "Test Timeout Is Capped At Server Max", timeout: "5000", maxTimeout: 20 * time.Millisecond, wantStatus: http.StatusGatewayTimeout, wantCode: ports.ERROR_CODE_REQUEST_TIMEOUT},
+		{name: "Test Invalid Timeout Is Ignored", timeout: "soon", maxTimeout: 10 * time.Second, wantStatus: http.StatusOK},
+		{name: "Test Missing Timeout Returns 200", maxTimeout: 10 * time.Second, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(slowValidator{delay: 200 * time.Millisecond}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{MaxRequestTimeout: tt.maxTimeout}, logger)
+
+			r := httptest.NewRequest(http.MethodGet, "/validate?address="+url.QueryEscape("123 Main St"), nil)
+			if tt.timeout != "" {
+				r.Header.Set(handlers.REQUEST_TIMEOUT_HEADER, tt.timeout)
+			}
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			var got ports.AddressValidationResult
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressHandler.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+// stubReverseGeocoder resolves any coordinates to a fixed address
+type stubReverseGeocoder struct{}
+
+func (stubReverseGeocoder) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA"}, nil
+}
+
+func TestAddressHandler_ValidateAddress_Coordinates(t *testing.T) {
+	mapConfig := config.MapConfig{
+		MaxDistance:  2,
+		DistanceUnit: ports.DISTANCE_MILES,
+		CenterLat:    40.8313747,
+		CenterLng:    -73.8272283,
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantInRange *bool
+	}{
+		{
+			name:        "Test In Range Coordinates Return 200",
+			body:        `{"latitude": 40.84, "longitude": -73.84}`,
+			wantStatus:  http.StatusOK,
+			wantInRange: boolPtr(true),
+		},
+		{
+			name:        "Test Out Of Range Coordinates Return 200 Not In Range",
+			body:        `{"latitude": 40.7128, "longitude": -74.0060}`,
+			wantStatus:  http.StatusOK,
+			wantInRange: boolPtr(false),
+		},
+		{
+			name:       "Test Invalid Coordinates Return 400",
+			body:       `{"latitude": 140.84, "longitude": -73.84}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Test Address And Coordinates Return 400",
+			body:       `{"address": "123 Main St", "latitude": 40.84, "longitude": -73.84}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Test Latitude Only Returns 400",
+			body:       `{"latitude": 40.84}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(stubValidator{}, logger, mapConfig, config.ValidationConfig{})
+			service.SetReverseGeocoder(stubReverseGeocoder{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+
+			r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if tt.wantInRange == nil {
+				return
+			}
+
+			var got ports.AddressValidationResult
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.InRange == nil || *got.InRange != *tt.wantInRange {
+				t.Errorf("AddressHandler.ValidateAddress() InRange = %v, want %v", got.InRange, *tt.wantInRange)
+			}
+			if got.FormattedAddress == "" {
+				t.Error("AddressHandler.ValidateAddress() FormattedAddress is empty")
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestAddressHandler_ValidateAddress_RequireHTTPS(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     config.InfraConfig
+		proto      string
+		wantStatus int
+	}{
+		{name: "Test Plain Request Is Rejected", config: config.InfraConfig{IsHttpSecure: true}, wantStatus: http.StatusBadRequest},
+		{name: "Test Forwarded Proto Ignored Without Proxy", config: config.InfraConfig{IsHttpSecure: true}, proto: "https", wantStatus: http.StatusBadRequest},
+		{name: "Test Forwarded HTTPS Behind Proxy Is Accepted", config: config.InfraConfig{IsHttpSecure: true, BehindTLSProxy: true}, proto: "https", wantStatus: http.StatusOK},
+		{name: "Test Forwarded HTTP Behind Proxy Is Rejected", config: config.InfraConfig{IsHttpSecure: true, BehindTLSProxy: true}, proto: "http", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandlerWithConfig(ports.AddressValidationResult{IsValid: true}, tt.config)
+
+			r := httptest.NewRequest(http.MethodGet, "/validate?address="+url.QueryEscape("123 Main St"), nil)
+			if tt.proto != "" {
+				r.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// countingValidator counts its calls, returning a fixed valid result
+type countingValidator struct {
+	calls *int
+}
+
+func (c countingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	*c.calls++
+	return ports.AddressValidationResult{IsValid: true}, nil
+}
+
+func TestAddressHandler_ValidateAddress_EmptyAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+	}{
+		{name: "Test Whitespace Only Is Unprocessable", address: "    "},
+		{name: "Test Tabs And Newlines Only Is Unprocessable", address: "\t\n \r\n"},
+		{name: "Test Punctuation Only Is Unprocessable", address: "..., #-"},
+		{name: "Test Missing Address Is Unprocessable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			var calls int
+			service := services.NewAddressService(countingValidator{calls: &calls}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+
+			body, err := json.Marshal(handlers.AddressRequest{Address: tt.address})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(string(body)))
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, http.StatusUnprocessableEntity)
+			}
+			var got ports.AddressValidationResult
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if got.ErrorCode != ports.ERROR_CODE_EMPTY_ADDRESS {
+				t.Errorf("AddressHandler.ValidateAddress() errorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_EMPTY_ADDRESS)
+			}
+			if calls != 0 {
+				t.Errorf("validator called %d times, want none", calls)
+			}
+		})
+	}
+}
+
+func TestAddressHandler_ValidateAddress_StructuredAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		requests   []string
+		wantStatus int
+		wantCalls  int
+	}{
+		{
+			name:       "Test Structured And Single Line Share A Cache Entry",
+			requests:   []string{`{"street": "1 Main St", "city": "Bronx", "state": "NY", "postalCode": "10457"}`, `{"address": "1 Main St, Bronx, NY 10457"}`},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "Test Structured Without Postal Code Shares A Cache Entry",
+			requests:   []string{`{"address": " 1 main st ,bronx, ny"}`, `{"street": "1 Main St", "city": "Bronx", "state": "NY"}`},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "Test Address With Components Is Rejected",
+			requests:   []string{`{"address": "1 Main St", "city": "Bronx"}`},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			var calls int
+			cache := adapters.NewCachingValidator(countingValidator{calls: &calls}, config.CacheConfig{TTL: time.Minute}, logger)
+			service := services.NewAddressService(cache, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+
+			for _, body := range tt.requests {
+				r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body))
+				w := httptest.NewRecorder()
+				h.ValidateAddress(w, r)
+
+				if w.Code != tt.wantStatus {
+					t.Errorf("AddressHandler.ValidateAddress(%s) status = %v, want %v", body, w.Code, tt.wantStatus)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("validator called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// localityRecorder records the locality each call was asked to resolve in
+type localityRecorder struct {
+	locality *string
+}
+
+func (lr localityRecorder) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	*lr.locality = ports.Locality(ctx)
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: address}, nil
+}
+
+func TestAddressHandler_ValidateAddress_Locality(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		query  url.Values
+		want   string
+	}{
+		{
+			name:   "Test Missing Locality Uses The Configured One",
+			method: http.MethodPost,
+			body:   `{"address": "1600 Grand Concourse"}`,
+			want:   "",
+		},
+		{
+			name:   "Test Body Locality Is Requested",
+			method: http.MethodPost,
+			body:   `{"address": "1600 Grand Concourse", "locality": " Yonkers "}`,
+			want:   "Yonkers",
+		},
+		{
+			name:   "Test Query Locality Is Requested",
+			method: http.MethodGet,
+			query:  url.Values{"address": {"1600 Grand Concourse"}, "locality": {"Yonkers"}},
+			want:   "Yonkers",
+		},
+		{
+			name:   "Test Structured City Does Not Replace The Locality",
+			method: http.MethodPost,
+			body:   `{"street": "1600 Grand Concourse", "city": "Yonkers"}`,
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := "unset"
+			logger := zap.NewNop()
+			service := services.NewAddressService(localityRecorder{locality: &got}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+
+			r := httptest.NewRequest(tt.method, "/validate?"+tt.query.Encode(), strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, http.StatusOK)
+			}
+			if got != tt.want {
+				t.Errorf("validator locality = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
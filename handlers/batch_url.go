@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"address-validator/config"
+
+	"go.uber.org/zap"
+)
+
+// CSV_CONTENT_TYPE is the media type of a result CSV
+const CSV_CONTENT_TYPE = "text/csv"
+
+// DEFAULT_CSV_COLUMN is the header of the address column when the request names none
+const DEFAULT_CSV_COLUMN = "address"
+
+// MAX_FETCH_REDIRECTS caps the redirects followed while fetching a CSV, each one is checked again
+const MAX_FETCH_REDIRECTS = 5
+
+var (
+	// errURLNotAllowed is returned for a scheme or host outside the allowlist, including on redirect
+	errURLNotAllowed = errors.New("url not allowed")
+	// errBlockedAddress is returned when an allowed host resolves to an internal address
+	errBlockedAddress = errors.New("address is internal")
+	// errCSVTooLarge is returned when the CSV exceeds the configured size
+	errCSVTooLarge = errors.New("csv too large")
+)
+
+// blockedPrefixes are the ranges not covered by the netip helpers that must not be reached either
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // this network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+}
+
+// URLBatchRequest represents the incoming request for validating the addresses of a remote CSV
+type URLBatchRequest struct {
+	URL string `json:"url"`
+	// Column is the header of the address column, matched case-insensitively
+	Column        string `json:"column,omitempty"`
+	CheckGeofence *bool  `json:"checkGeofence,omitempty"`
+	Profile       string `json:"profile,omitempty"`
+}
+
+// resultColumns are appended to each CSV row in the result CSV
+var resultColumns = []string{"isValid", "formattedAddress", "latitude", "longitude", "inRange", "geofenceStatus", "errorCode", "error"}
+
+// ValidateURL handles the endpoint that validates the address column of a remote CSV.
+// It answers with a result CSV when the client accepts text/csv, otherwise with a batch response.
+func (h *BatchHandler) ValidateURL(w http.ResponseWriter, r *http.Request) {
+	// Set content type
+	w.Header().Set("Content-Type", "application/json")
+
+	if !h.admit(w, r) {
+		return
+	}
+
+	// Parse request body
+	var req URLBatchRequest
+	err := decodeRequest(r.Body, &req)
+	if err == nil && req.URL == "" {
+		err = errors.New(`field "url" is required`)
+	}
+	if err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		writeBadRequest(w, err, h.config.JSONFieldCase)
+		return
+	}
+	if req.Column == "" {
+		req.Column = DEFAULT_CSV_COLUMN
+	}
+	// Refused before the CSV is fetched, an unknown profile would fail every row
+	if err := h.service.CheckProfile(req.Profile); err != nil {
+		h.logger.Warn("unknown validation profile", zap.Error(err))
+		writeUnknownProfile(w, err, h.config.JSONFieldCase)
+		return
+	}
+
+	// The fetch alone may take URLTimeout, the response still needs the usual time after it
+	h.extendWriteDeadline(w, h.batch.URLTimeout+WRITE_TIMEOUT)
+	body, err := h.fetchCSV(r.Context(), req.URL)
+	if err != nil {
+		h.logger.Warn("failed to fetch CSV", zap.String("url", req.URL), zap.Error(err))
+		switch {
+		case errors.Is(err, errURLNotAllowed), errors.Is(err, errBlockedAddress):
+			http.Error(w, "URL not allowed", http.StatusForbidden)
+		case errors.Is(err, errCSVTooLarge):
+			http.Error(w, "CSV too large", http.StatusRequestEntityTooLarge)
+		default:
+			http.Error(w, "Failed to fetch CSV", http.StatusBadGateway)
+		}
+		return
+	}
+
+	header, rows, truncated, err := readCSV(body, h.batch.URLMaxRows)
+	if err != nil {
+		h.logger.Warn("invalid CSV", zap.Error(err))
+		http.Error(w, "Invalid CSV", http.StatusBadRequest)
+		return
+	}
+	column := slices.IndexFunc(header, func(name string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), req.Column)
+	})
+	if column < 0 {
+		h.logger.Warn("CSV column not found", zap.String("column", req.Column))
+		http.Error(w, fmt.Sprintf("Column %q not found", req.Column), http.StatusBadRequest)
+		return
+	}
+	if truncated {
+		h.logger.Warn("CSV truncated", zap.Uint("maxRows", h.batch.URLMaxRows))
+	}
+
+	addresses := make([]string, len(rows))
+	for i, row := range rows {
+		addresses[i] = row[column]
+	}
+	if !h.chargeQuota(w, r, len(addresses)) {
+		return
+	}
+
+	options := AddressRequest{CheckGeofence: req.CheckGeofence, Profile: req.Profile}.options()
+	results := make([]BatchItemResult, len(addresses))
+	response := BatchResponse{
+		Summary: BatchSummary{Total: len(results), Truncated: truncated},
+	}
+	for outcome := range h.validateAll(r, addresses, options) {
+		// Up to URLMaxRows validations follow, each completed one buys the rest more time
+		h.extendWriteDeadline(w, WRITE_TIMEOUT)
+		results[outcome.item.Index] = outcome.item
+		if outcome.failed {
+			response.Summary.Failed++
+		} else {
+			response.Summary.Succeeded++
+		}
+	}
+	response.Results = results
+
+	if accepts(r, CSV_CONTENT_TYPE) {
+		h.writeResultCSV(w, header, rows, response)
+		return
+	}
+
+	// Encode response
+	if err := encodeJSON(w, response, h.config.JSONFieldCase); err != nil {
+		h.logger.Error("failed to encode response", zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// fetchCSV downloads the CSV at rawURL within the configured time and size limits
+func (h *BatchHandler) fetchCSV(ctx context.Context, rawURL string) ([]byte, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errURLNotAllowed, err)
+	}
+	if err := checkFetchURL(target, h.batch); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.batch.URLTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", CSV_CONTENT_TYPE)
+
+	resp, err := h.fetcher.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > h.batch.URLMaxBytes {
+		return nil, errCSVTooLarge
+	}
+
+	// Read one byte past the limit to tell a CSV of exactly the limit from a larger one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, h.batch.URLMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > h.batch.URLMaxBytes {
+		return nil, errCSVTooLarge
+	}
+	return body, nil
+}
+
+// readCSV parses the header and up to maxRows data rows, reporting whether more rows followed
+func readCSV(body []byte, maxRows uint) (header []string, rows [][]string, truncated bool, err error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+
+	header, err = reader.Read()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	// Spreadsheet exports often start with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return header, rows, false, nil
+		}
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if uint(len(rows)) == maxRows {
+			return header, rows, true, nil
+		}
+		rows = append(rows, row)
+	}
+}
+
+// writeResultCSV writes the source rows in order, each followed by its validation result
+func (h *BatchHandler) writeResultCSV(w http.ResponseWriter, header []string, rows [][]string, response BatchResponse) {
+	w.Header().Set("Content-Type", CSV_CONTENT_TYPE)
+	if response.Summary.Truncated {
+		w.Header().Set("X-Batch-Truncated", "true")
+	}
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write(append(slices.Clone(header), resultColumns...))
+	for i, row := range rows {
+		result := response.Results[i].AddressValidationResult
+		var inRange string
+		if result.InRange != nil {
+			inRange = strconv.FormatBool(*result.InRange)
+		}
+		writer.Write(append(slices.Clone(row),
+			strconv.FormatBool(result.IsValid),
+			result.FormattedAddress,
+			strconv.FormatFloat(result.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(result.Longitude, 'f', -1, 64),
+			inRange,
+			result.GeofenceStatus,
+			result.ErrorCode,
+			result.Error,
+		))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		// The status is already sent, the client sees a cut off CSV
+		h.logger.Warn("failed to write result CSV", zap.Error(err))
+	}
+}
+
+// checkFetchURL rejects a URL whose scheme or host is not allowlisted, an empty host allowlist rejects all
+func checkFetchURL(target *url.URL, batchConfig config.BatchConfig) error {
+	if !slices.Contains(batchConfig.URLAllowedSchemes, strings.ToLower(target.Scheme)) {
+		return fmt.Errorf("%w: scheme %q", errURLNotAllowed, target.Scheme)
+	}
+	if !slices.Contains(batchConfig.URLAllowedHosts, strings.ToLower(target.Hostname())) {
+		return fmt.Errorf("%w: host %q", errURLNotAllowed, target.Hostname())
+	}
+	return nil
+}
+
+// newGuardedClient creates the client that fetches remote CSVs.
+// Redirects are checked against the allowlist, and unless private addresses are allowed the dialer
+// refuses internal ones after resolution so a host cannot be rebound to one between checks.
+func newGuardedClient(batchConfig config.BatchConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: batchConfig.URLTimeout}
+	if !batchConfig.URLAllowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			return checkDialAddress(address)
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would dial on our behalf and bypass the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   batchConfig.URLTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= MAX_FETCH_REDIRECTS {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+			return checkFetchURL(req.URL, batchConfig)
+		},
+	}
+}
+
+// checkDialAddress rejects a resolved ip:port that is loopback, private, link-local or otherwise internal
+func checkDialAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", errBlockedAddress, ip)
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return fmt.Errorf("%w: %s", errBlockedAddress, ip)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testCSV = "id,Address\n1,1 Main St\n2,1 Nowhere Rd\n3,\"2 Main St, Bronx\"\n"
+
+// newCSVServer serves body at /addresses.csv and redirects /moved to location, counting the requests it gets
+func newCSVServer(t *testing.T, body, location string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		switch r.URL.Path {
+		case "/addresses.csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte(body))
+		case "/moved":
+			http.Redirect(w, r, location, http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+// newURLBatchHandler creates a batch handler over the stub validator with the given remote CSV settings
+func newURLBatchHandler(batchConfig config.BatchConfig) *handlers.BatchHandler {
+	logger := zap.NewNop()
+	service := services.NewAddressService(batchStubValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	batchConfig.Concurrency = 2
+	if batchConfig.URLMaxBytes == 0 {
+		batchConfig.URLMaxBytes = 1 << 20
+	}
+	if batchConfig.URLMaxRows == 0 {
+		batchConfig.URLMaxRows = 100
+	}
+	batchConfig.URLTimeout = 5 * time.Second
+	return handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, batchConfig, logger)
+}
+
+func postURLBatch(h *handlers.BatchHandler, req handlers.URLBatchRequest, accept string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(req)
+	r := httptest.NewRequest(http.MethodPost, "/validate/url", strings.NewReader(string(body)))
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	h.ValidateURL(w, r)
+	return w
+}
+
+func TestBatchHandler_ValidateURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxRows     uint
+		column      string
+		wantSummary handlers.BatchSummary
+		wantValid   []bool
+	}{
+		{
+			name:        "Test Default Column Is Matched Case-Insensitively",
+			wantSummary: handlers.BatchSummary{Total: 3, Succeeded: 2, Failed: 1},
+			wantValid:   []bool{true, false, true},
+		},
+		{
+			name:        "Test Rows Past The Limit Are Truncated",
+			maxRows:     2,
+			wantSummary: handlers.BatchSummary{Total: 2, Succeeded: 1, Failed: 1, Truncated: true},
+			wantValid:   []bool{true, false},
+		},
+		{
+			name:        "Test Named Column Is Validated",
+			column:      "id",
+			wantSummary: handlers.BatchSummary{Total: 3, Succeeded: 3},
+			wantValid:   []bool{true, true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newCSVServer(t, testCSV, "")
+			serverURL, _ := url.Parse(server.URL)
+			h := newURLBatchHandler(config.BatchConfig{
+				URLAllowedHosts:   []string{serverURL.Hostname()},
+				URLAllowedSchemes: []string{"http"},
+				URLAllowPrivate:   true,
+				URLMaxRows:        tt.maxRows,
+			})
+
+			w := postURLBatch(h, handlers.URLBatchRequest{URL: server.URL + "/addresses.csv", Column: tt.column}, "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("BatchHandler.ValidateURL() status = %v, want %v, body %q", w.Code, http.StatusOK, w.Body.String())
+			}
+
+			var got handlers.BatchResponse
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Summary != tt.wantSummary {
+				t.Errorf("BatchHandler.ValidateURL() summary = %+v, want %+v", got.Summary, tt.wantSummary)
+			}
+			gotValid := make([]bool, len(got.Results))
+			for i, result := range got.Results {
+				gotValid[i] = result.IsValid
+			}
+			if !reflect.DeepEqual(gotValid, tt.wantValid) {
+				t.Errorf("BatchHandler.ValidateURL() valid = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestBatchHandler_ValidateURL_ResultCSV(t *testing.T) {
+	server, _ := newCSVServer(t, testCSV, "")
+	serverURL, _ := url.Parse(server.URL)
+	h := newURLBatchHandler(config.BatchConfig{
+		URLAllowedHosts:   []string{serverURL.Hostname()},
+		URLAllowedSchemes: []string{"http"},
+		URLAllowPrivate:   true,
+	})
+
+	w := postURLBatch(h, handlers.URLBatchRequest{URL: server.URL + "/addresses.csv"}, "text/csv")
+	if w.Code != http.StatusOK {
+		t.Fatalf("BatchHandler.ValidateURL() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Content-Type"); got != handlers.CSV_CONTENT_TYPE {
+		t.Errorf("BatchHandler.ValidateURL() Content-Type = %q, want %q", got, handlers.CSV_CONTENT_TYPE)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read result CSV: %v", err)
+	}
+	want := [][]string{
+		{"id", "Address", "isValid", "formattedAddress", "latitude", "longitude", "inRange", "geofenceStatus", "errorCode", "error"},
+		{"1", "1 Main St", "true", "1 Main St", "0", "0", "", ports.GEOFENCE_NOT_EVALUATED, "", ""},
+		{"2", "1 Nowhere Rd", "false", "", "0", "0", "", ports.GEOFENCE_NOT_EVALUATED, "", "No validation result found."},
+		{"3", "2 Main St, Bronx", "true", "2 Main St, Bronx", "0", "0", "", ports.GEOFENCE_NOT_EVALUATED, "", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("BatchHandler.ValidateURL() CSV = %v, want %v", records, want)
+	}
+}
+
+func TestBatchHandler_ValidateURL_SlowFetchOutlivesWriteTimeout(t *testing.T) {
+	csvServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte(testCSV))
+	}))
+	t.Cleanup(csvServer.Close)
+	csvURL, _ := url.Parse(csvServer.URL)
+	h := newURLBatchHandler(config.BatchConfig{
+		URLAllowedHosts:   []string{csvURL.Hostname()},
+		URLAllowedSchemes: []string{"http"},
+		URLAllowPrivate:   true,
+	})
+
+	// The fetch takes longer than the server's write deadline
+	server := httptest.NewUnstartedServer(http.HandlerFunc(h.ValidateURL))
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	t.Cleanup(server.Close)
+
+	body, _ := json.Marshal(handlers.URLBatchRequest{URL: csvServer.URL + "/addresses.csv"})
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatalf("POST /validate/url error = %v, want a response", err)
+	}
+	defer resp.Body.Close()
+
+	var got handlers.BatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || got.Summary.Total != 3 {
+		t.Errorf("POST /validate/url = %d, %+v, want %d with 3 results", resp.StatusCode, got.Summary, http.StatusOK)
+	}
+}
+
+func TestBatchHandler_ValidateURL_Rejected(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		rawURL       string
+		allowPrivate bool
+		maxBytes     int64
+		wantStatus   int
+		wantFetched  bool
+	}{
+		{
+			name:       "Test Internal Address Is Blocked After Resolution",
+			path:       "/addresses.csv",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Test Metadata Address Is Not Allowlisted",
+			rawURL:     "http://169.254.169.254/latest/meta-data/",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Test Disallowed Scheme Is Rejected",
+			rawURL:     "file:///etc/passwd",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:         "Test Redirect To A Disallowed Host Is Rejected",
+			path:         "/moved",
+			allowPrivate: true,
+			wantStatus:   http.StatusForbidden,
+			wantFetched:  true,
+		},
+		{
+			name:         "Test Oversized CSV Is Rejected",
+			path:         "/addresses.csv",
+			allowPrivate: true,
+			maxBytes:     16,
+			wantStatus:   http.StatusRequestEntityTooLarge,
+			wantFetched:  true,
+		},
+		{
+			name:         "Test Missing CSV Is A Bad Gateway",
+			path:         "/missing.csv",
+			allowPrivate: true,
+			wantStatus:   http.StatusBadGateway,
+			wantFetched:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, hits := newCSVServer(t, testCSV, "http://internal.example/addresses.csv")
+			serverURL, _ := url.Parse(server.URL)
+			h := newURLBatchHandler(config.BatchConfig{
+				URLAllowedHosts:   []string{serverURL.Hostname()},
+				URLAllowedSchemes: []string{"http"},
+				URLAllowPrivate:   tt.allowPrivate,
+				URLMaxBytes:       tt.maxBytes,
+			})
+
+			rawURL := tt.rawURL
+			if rawURL == "" {
+				rawURL = server.URL + tt.path
+			}
+			w := postURLBatch(h, handlers.URLBatchRequest{URL: rawURL}, "")
+			if w.Code != tt.wantStatus {
+				t.Errorf("BatchHandler.ValidateURL() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if fetched := hits.Load() > 0; fetched != tt.wantFetched {
+				t.Errorf("BatchHandler.ValidateURL() fetched = %v, want %v", fetched, tt.wantFetched)
+			}
+		})
+	}
+}
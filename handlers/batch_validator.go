@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+
+	"go.uber.org/zap"
+)
+
+// NDJSON_CONTENT_TYPE is the media type of a streamed batch response
+const NDJSON_CONTENT_TYPE = "application/x-ndjson"
+
+// WRITE_TIMEOUT is the server's write deadline. It is sized for a single validation, the batch routes
+// push it back as they make progress so a long batch is not cut off partway through.
+const WRITE_TIMEOUT = 10 * time.Second
+
+// BatchRequest represents the incoming request for batch address validation
+type BatchRequest struct {
+	Addresses []string `json:"addresses"`
+	// CheckGeofence defaults to true when omitted and applies to every address
+	CheckGeofence *bool `json:"checkGeofence,omitempty"`
+	// Profile selects a configured validation profile for every address
+	Profile string `json:"profile,omitempty"`
+}
+
+// BatchItemResult is the validation result of one address with its position in the request
+type BatchItemResult struct {
+	Index int `json:"index"`
+	ports.AddressValidationResult
+}
+
+// BatchSummary reports the outcome of a batch
+type BatchSummary struct {
+	Total     int  `json:"total"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+	Truncated bool `json:"truncated"`
+}
+
+// BatchResponse wraps the per-address results in request order with a summary
+type BatchResponse struct {
+	Results []BatchItemResult `json:"results"`
+	Summary BatchSummary      `json:"summary"`
+}
+
+// BatchHandler handles HTTP requests for batch address validation
+type BatchHandler struct {
+	service     *services.AddressService
+	rateLimiter Limiter
+	dailyQuota  *DailyQuota
+	clientIP    *ClientIPResolver
+	logger      *zap.Logger
+	config      config.InfraConfig
+	batch       config.BatchConfig
+	fetcher     *http.Client
+}
+
+// NewBatchHandler creates a new batch handler
+func NewBatchHandler(service *services.AddressService, rateLimiter Limiter, config config.InfraConfig, batchConfig config.BatchConfig, logger *zap.Logger) *BatchHandler {
+	// At least one worker is needed to drain the batch
+	if batchConfig.Concurrency == 0 {
+		batchConfig.Concurrency = 1
+	}
+
+	return &BatchHandler{
+		service:     service,
+		rateLimiter: rateLimiter,
+		clientIP:    NewClientIPResolver(config),
+		logger:      logger,
+		config:      config,
+		batch:       batchConfig,
+		fetcher:     newGuardedClient(batchConfig),
+	}
+}
+
+// SetDailyQuota sets the per-client daily cap checked after the rate limit, nil disables it.
+// Each address of a batch counts as one request, unlike for the rate limit.
+func (h *BatchHandler) SetDailyQuota(dailyQuota *DailyQuota) {
+	h.dailyQuota = dailyQuota
+}
+
+// ValidateBatch handles the batch address validation endpoint
+func (h *BatchHandler) ValidateBatch(w http.ResponseWriter, r *http.Request) {
+	// Set content type
+	w.Header().Set("Content-Type", "application/json")
+
+	if !h.admit(w, r) {
+		return
+	}
+
+	// Parse request body, only the first MaxSize addresses are processed
+	var req BatchRequest
+	var truncated bool
+	var err error
+	if h.batch.StreamDecode {
+		req, truncated, err = DecodeBatchRequest(r.Body, h.batch.MaxSize)
+	} else if err = decodeRequest(r.Body, &req); err == nil {
+		truncated = uint(len(req.Addresses)) > h.batch.MaxSize
+		if truncated {
+			req.Addresses = req.Addresses[:h.batch.MaxSize]
+		}
+	}
+	if err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		writeBadRequest(w, err, h.config.JSONFieldCase)
+		return
+	}
+
+	addresses := req.Addresses
+	if truncated {
+		h.logger.Warn("batch truncated", zap.Uint("maxSize", h.batch.MaxSize))
+	}
+
+	// An unknown profile would fail every address, so the batch is refused up front
+	if err := h.service.CheckProfile(req.Profile); err != nil {
+		h.logger.Warn("unknown validation profile", zap.Error(err))
+		writeUnknownProfile(w, err, h.config.JSONFieldCase)
+		return
+	}
+
+	if !h.chargeQuota(w, r, len(addresses)) {
+		return
+	}
+
+	options := AddressRequest{CheckGeofence: req.CheckGeofence, Profile: req.Profile}.options()
+	outcomes := h.validateAll(r, addresses, options)
+
+	if acceptsNDJSON(r) {
+		h.streamResults(w, outcomes, truncated)
+		return
+	}
+
+	// Place each result at its index so the response keeps request order
+	results := make([]BatchItemResult, len(addresses))
+	response := BatchResponse{
+		Summary: BatchSummary{Total: len(results), Truncated: truncated},
+	}
+	for outcome := range outcomes {
+		results[outcome.item.Index] = outcome.item
+		if outcome.failed {
+			response.Summary.Failed++
+		} else {
+			response.Summary.Succeeded++
+		}
+	}
+	response.Results = results
+
+	// Encode response
+	if err := encodeJSON(w, response, h.config.JSONFieldCase); err != nil {
+		h.logger.Error("failed to encode response", zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// admit checks the method, HTTPS and rate limit shared by the batch endpoints,
+// writing the rejection and returning false when the request may not proceed
+func (h *BatchHandler) admit(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		h.logger.Warn("method not allowed", zap.String("method", r.Method))
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	// Only allow HTTPS
+	if h.config.IsHttpSecure && !isHTTPS(r, h.config) {
+		h.logger.Warn("HTTPS required")
+		http.Error(w, "HTTPS required", http.StatusBadRequest)
+		return false
+	}
+
+	// Check rate limit, a batch counts as one request since its size is capped
+	clientIP := h.clientIP.ClientIP(r)
+	if !allowKeys(h.rateLimiter, rateLimitKeys(r, clientIP, h.config.Tenants)) {
+		h.logger.Warn("rate limit exceeded", zap.String("ip", clientIP), zap.String("tenant", ports.Tenant(r.Context())))
+		http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+		return false
+	}
+
+	return true
+}
+
+// chargeQuota counts each of the addresses of a batch against the daily quota once they are known,
+// writing the rejection and returning false when fewer are left. A rejected batch is not charged.
+func (h *BatchHandler) chargeQuota(w http.ResponseWriter, r *http.Request, addresses int) bool {
+	if h.dailyQuota == nil {
+		return true
+	}
+
+	clientIP := h.clientIP.ClientIP(r)
+	if allowed, retryAfter := h.dailyQuota.AllowN(rateLimitKeys(r, clientIP, h.config.Tenants), uint(addresses)); !allowed {
+		h.logger.Warn("daily quota exceeded", zap.String("ip", clientIP), zap.String("tenant", ports.Tenant(r.Context())), zap.Int("addresses", addresses))
+		writeDailyQuotaExceeded(w, retryAfter, h.config.JSONFieldCase)
+		return false
+	}
+	return true
+}
+
+// writeUnknownProfile rejects a batch naming a profile that is not configured
+func writeUnknownProfile(w http.ResponseWriter, err error, fieldCase string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	encodeJSON(w, ports.AddressValidationResult{
+		Error:     err.Error(),
+		ErrorCode: ports.ERROR_CODE_UNKNOWN_PROFILE,
+	}, fieldCase)
+}
+
+// DecodeBatchRequest decodes a batch request one address at a time, keeping at most maxSize of them.
+// Addresses past the limit are read and discarded so peak memory does not grow with the batch.
+// Errors describe the problem in terms of the request, like those of the other endpoints.
+func DecodeBatchRequest(body io.Reader, maxSize uint) (req BatchRequest, truncated bool, err error) {
+	req, truncated, err = decodeBatchStream(json.NewDecoder(body), maxSize)
+	if err != nil {
+		return req, false, decodeError(err)
+	}
+	return req, truncated, nil
+}
+
+// decodeBatchStream reads the batch request object token by token, rejecting unknown fields
+func decodeBatchStream(decoder *json.Decoder, maxSize uint) (req BatchRequest, truncated bool, err error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return req, false, err
+	}
+	if token != json.Delim('{') {
+		return req, false, fmt.Errorf("request body must be an object, got %s", tokenKind(token))
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return req, false, err
+		}
+
+		switch token {
+		case "addresses":
+			if truncated, err = decodeAddresses(decoder, &req, maxSize); err != nil {
+				return req, false, err
+			}
+		case "checkGeofence":
+			if err := decodeField(decoder, "checkGeofence", &req.CheckGeofence); err != nil {
+				return req, false, err
+			}
+		case "profile":
+			if err := decodeField(decoder, "profile", &req.Profile); err != nil {
+				return req, false, err
+			}
+		default:
+			return req, false, fmt.Errorf("unknown field %q", token)
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return req, false, err
+	}
+	// Anything but whitespace after the object is a second value or garbage
+	if decoder.More() {
+		return req, false, errors.New("request body must contain a single JSON object")
+	}
+	return req, truncated, nil
+}
+
+// decodeField decodes the value of the named field into v. The decoder only knows the value,
+// so a type error is given the field name to read like one from a whole-body decode.
+func decodeField(decoder *json.Decoder, name string, v any) error {
+	err := decoder.Decode(v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field == "" {
+		typeErr.Field = name
+	}
+	return err
+}
+
+// decodeAddresses reads the addresses array, appending up to maxSize of them to req
+func decodeAddresses(decoder *json.Decoder, req *BatchRequest, maxSize uint) (truncated bool, err error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return false, err
+	}
+	// A null array is accepted like an omitted one
+	if token == nil {
+		return false, nil
+	}
+	if token != json.Delim('[') {
+		return false, fmt.Errorf(`field "addresses" must be an array, got %s`, tokenKind(token))
+	}
+
+	var address string
+	for decoder.More() {
+		if err := decoder.Decode(&address); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				return false, fmt.Errorf(`field "addresses" must contain strings, got %s`, typeErr.Value)
+			}
+			return false, err
+		}
+		if uint(len(req.Addresses)) < maxSize {
+			req.Addresses = append(req.Addresses, address)
+		} else {
+			truncated = true
+		}
+	}
+
+	return truncated, expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token, failing unless it is delim
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
+
+// tokenKind names the JSON value a token starts, as decodeError names them
+func tokenKind(token json.Token) string {
+	switch token.(type) {
+	case json.Delim:
+		if token == json.Delim('[') {
+			return "array"
+		}
+		return "object"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case nil:
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// batchOutcome is one completed batch item
+type batchOutcome struct {
+	item   BatchItemResult
+	failed bool
+}
+
+// validateAll validates the addresses concurrently and sends each outcome in completion order.
+// The channel is closed once every address has been validated.
+func (h *BatchHandler) validateAll(r *http.Request, addresses []string, options services.ValidationOptions) <-chan batchOutcome {
+	outcomes := make(chan batchOutcome)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for worker := uint(0); worker < h.batch.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result, err := h.service.ValidateAddress(r.Context(), addresses[i], options)
+				if err != nil {
+					h.logger.Debug("batch item validation failed", zap.Int("index", i), zap.Error(err))
+				}
+				outcomes <- batchOutcome{
+					item:   BatchItemResult{Index: i, AddressValidationResult: result},
+					failed: err != nil,
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for i := range addresses {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	return outcomes
+}
+
+// streamResults writes each result as one NDJSON line as soon as it completes.
+// Lines arrive in completion order, the index field maps them back to the request.
+func (h *BatchHandler) streamResults(w http.ResponseWriter, outcomes <-chan batchOutcome, truncated bool) {
+	w.Header().Set("Content-Type", NDJSON_CONTENT_TYPE)
+	if truncated {
+		w.Header().Set("X-Batch-Truncated", "true")
+	}
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	for outcome := range outcomes {
+		// Flushing does not reset the server's write deadline, each line buys the stream more time
+		h.extendWriteDeadline(w, WRITE_TIMEOUT)
+		if err := encodeJSON(w, outcome.item, h.config.JSONFieldCase); err != nil {
+			// The status is already sent, keep draining so the workers can finish
+			h.logger.Warn("failed to stream batch result", zap.Int("index", outcome.item.Index), zap.Error(err))
+			continue
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// extendWriteDeadline gives the response d more time to be written from now, overriding the server's
+// WRITE_TIMEOUT. Writers that cannot move the deadline, such as a test recorder, are left as they are.
+func (h *BatchHandler) extendWriteDeadline(w http.ResponseWriter, d time.Duration) {
+	err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(d))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Debug("failed to extend the write deadline", zap.Error(err))
+	}
+}
+
+// acceptsNDJSON reports whether the client asked for a streamed batch response
+func acceptsNDJSON(r *http.Request) bool {
+	return accepts(r, NDJSON_CONTENT_TYPE)
+}
+
+// accepts reports whether mediaType is listed in the Accept header
+func accepts(r *http.Request, mediaType string) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		accepted, _, _ := strings.Cut(accept, ";")
+		if strings.TrimSpace(accepted) == mediaType {
+			return true
+		}
+	}
+	return false
+}
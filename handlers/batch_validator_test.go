@@ -0,0 +1,383 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// batchStubValidator accepts every address except those naming Nowhere
+type batchStubValidator struct{}
+
+func (batchStubValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	if strings.Contains(address, "Nowhere") {
+		return ports.AddressValidationResult{IsValid: false, Error: "No validation result found."}, errors.New("no validation result found")
+	}
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: address}, nil
+}
+
+func TestBatchHandler_ValidateBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxSize     uint
+		addresses   []string
+		wantSummary handlers.BatchSummary
+		wantValid   []bool
+	}{
+		{
+			name:        "Test Mixed Batch Counts Successes And Failures",
+			maxSize:     10,
+			addresses:   []string{"1 Main St", "1 Nowhere Rd", "2 Main St", "", "3 Main St"},
+			wantSummary: handlers.BatchSummary{Total: 5, Succeeded: 3, Failed: 2},
+			wantValid:   []bool{true, false, true, false, true},
+		},
+		{
+			name:        "Test Oversized Batch Is Truncated",
+			maxSize:     2,
+			addresses:   []string{"1 Nowhere Rd", "2 Main St", "3 Main St"},
+			wantSummary: handlers.BatchSummary{Total: 2, Succeeded: 1, Failed: 1, Truncated: true},
+			wantValid:   []bool{false, true},
+		},
+		{
+			name:        "Test Empty Batch Returns Empty Summary",
+			maxSize:     10,
+			wantSummary: handlers.BatchSummary{},
+			wantValid:   []bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(batchStubValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, config.BatchConfig{MaxSize: tt.maxSize, Concurrency: 3}, logger)
+
+			body, _ := json.Marshal(handlers.BatchRequest{Addresses: tt.addresses})
+			r := httptest.NewRequest(http.MethodPost, "/validate/batch", strings.NewReader(string(body)))
+			w := httptest.NewRecorder()
+			h.ValidateBatch(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("BatchHandler.ValidateBatch() status = %v, want %v", w.Code, http.StatusOK)
+			}
+
+			var got handlers.BatchResponse
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !reflect.DeepEqual(got.Summary, tt.wantSummary) {
+				t.Errorf("BatchHandler.ValidateBatch() summary = %+v, want %+v", got.Summary, tt.wantSummary)
+			}
+
+			gotValid := []bool{}
+			for i, item := range got.Results {
+				if item.Index != i {
+					t.Errorf("result %d has index %d, want request order", i, item.Index)
+				}
+				gotValid = append(gotValid, item.IsValid)
+			}
+			if !reflect.DeepEqual(gotValid, tt.wantValid) {
+				t.Errorf("BatchHandler.ValidateBatch() valid = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestDecodeBatchRequest(t *testing.T) {
+	geofence := false
+	tests := []struct {
+		name          string
+		body          string
+		maxSize       uint
+		want          handlers.BatchRequest
+		wantTruncated bool
+		wantErr       bool
+	}{
+		{
+			name:    "Test Addresses And Options Are Decoded",
+			body:    `{"addresses": ["1 Main St", "2 Main St"], "checkGeofence": false, "profile": "checkout"}`,
+			maxSize: 10,
+			want:    handlers.BatchRequest{Addresses: []string{"1 Main St", "2 Main St"}, CheckGeofence: &geofence, Profile: "checkout"},
+		},
+		{
+			name:          "Test Addresses Past Max Size Are Dropped",
+			body:          `{"addresses": ["1 Main St", "2 Main St", "3 Main St"]}`,
+			maxSize:       2,
+			want:          handlers.BatchRequest{Addresses: []string{"1 Main St", "2 Main St"}},
+			wantTruncated: true,
+		},
+		{
+			name:    "Test Unknown Fields Are Rejected",
+			body:    `{"source": {"name": "import"}, "addresses": ["1 Main St"]}`,
+			maxSize: 10,
+			wantErr: true,
+		},
+		{
+			name:    "Test Null Addresses Decode As Empty",
+			body:    `{"addresses": null}`,
+			maxSize: 10,
+		},
+		{
+			name:    "Test Non String Address Is Rejected",
+			body:    `{"addresses": ["1 Main St", 2]}`,
+			maxSize: 10,
+			wantErr: true,
+		},
+		{
+			name:    "Test Non Array Addresses Are Rejected",
+			body:    `{"addresses": "1 Main St"}`,
+			maxSize: 10,
+			wantErr: true,
+		},
+		{
+			name:    "Test Truncated Body Is Rejected",
+			body:    `{"addresses": ["1 Main St"`,
+			maxSize: 10,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated, err := handlers.DecodeBatchRequest(strings.NewReader(tt.body), tt.maxSize)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeBatchRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) || truncated != tt.wantTruncated {
+				t.Errorf("DecodeBatchRequest() = %+v, %v, want %+v, %v", got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestBatchHandler_ValidateBatch_UnknownProfile(t *testing.T) {
+	logger := zap.NewNop()
+	service := services.NewAddressService(batchStubValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+	if err := service.SetProfiles(map[string]services.ValidationProfile{"checkout": {}}, ""); err != nil {
+		t.Fatalf("AddressService.SetProfiles() error = %v", err)
+	}
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	h := handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, config.BatchConfig{MaxSize: 10, Concurrency: 3}, logger)
+
+	body, _ := json.Marshal(handlers.BatchRequest{Addresses: []string{"1 Main St"}, Profile: "wholesale"})
+	r := httptest.NewRequest(http.MethodPost, "/validate/batch", strings.NewReader(string(body)))
+	w := httptest.NewRecorder()
+	h.ValidateBatch(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("BatchHandler.ValidateBatch() status = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	var got ports.AddressValidationResult
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.ErrorCode != ports.ERROR_CODE_UNKNOWN_PROFILE {
+		t.Errorf("BatchHandler.ValidateBatch() errorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_UNKNOWN_PROFILE)
+	}
+}
+
+func TestBatchHandler_ValidateBatch_StreamDecode(t *testing.T) {
+	const total = 100000
+	const maxSize = 5
+
+	addresses := make([]string, total)
+	for i := range addresses {
+		addresses[i] = fmt.Sprintf("%d Main St", i)
+	}
+	body, _ := json.Marshal(handlers.BatchRequest{Addresses: addresses})
+
+	// Only the kept addresses are held, the rest of the array is read and discarded
+	req, truncated, err := handlers.DecodeBatchRequest(bytes.NewReader(body), maxSize)
+	if err != nil {
+		t.Fatalf("DecodeBatchRequest() error = %v", err)
+	}
+	if !truncated || len(req.Addresses) != maxSize || cap(req.Addresses) > 2*maxSize {
+		t.Errorf("DecodeBatchRequest() kept len %d cap %d truncated %v, want len %d", len(req.Addresses), cap(req.Addresses), truncated, maxSize)
+	}
+
+	logger := zap.NewNop()
+	service := services.NewAddressService(batchStubValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	h := handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, config.BatchConfig{MaxSize: maxSize, Concurrency: 3, StreamDecode: true}, logger)
+
+	r := httptest.NewRequest(http.MethodPost, "/validate/batch", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ValidateBatch(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("BatchHandler.ValidateBatch() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	var got handlers.BatchResponse
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := handlers.BatchSummary{Total: maxSize, Succeeded: maxSize, Truncated: true}
+	if got.Summary != want {
+		t.Errorf("BatchHandler.ValidateBatch() summary = %+v, want %+v", got.Summary, want)
+	}
+	for i, item := range got.Results {
+		if item.FormattedAddress != addresses[i] {
+			t.Errorf("result %d = %q, want %q", i, item.FormattedAddress, addresses[i])
+		}
+	}
+}
+
+// gatedValidator blocks addresses naming Slow until release is closed
+type gatedValidator struct {
+	release chan struct{}
+}
+
+func (g gatedValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	if strings.Contains(address, "Slow") {
+		<-g.release
+	}
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: address}, nil
+}
+
+func TestBatchHandler_ValidateBatch_NDJSON(t *testing.T) {
+	logger := zap.NewNop()
+	validator := gatedValidator{release: make(chan struct{})}
+	service := services.NewAddressService(validator, logger, config.MapConfig{}, config.ValidationConfig{})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	h := handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, config.BatchConfig{MaxSize: 10, Concurrency: 3}, logger)
+
+	server := httptest.NewServer(http.HandlerFunc(h.ValidateBatch))
+	defer server.Close()
+
+	addresses := []string{"1 Slow Rd", "2 Main St", "3 Main St"}
+	body, _ := json.Marshal(handlers.BatchRequest{Addresses: addresses})
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(string(body)))
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("Content-Type"); got != handlers.NDJSON_CONTENT_TYPE {
+		t.Errorf("Content-Type = %q, want %q", got, handlers.NDJSON_CONTENT_TYPE)
+	}
+
+	seen := map[int]string{}
+	scanner := bufio.NewScanner(resp.Body)
+	readLine := func() {
+		t.Helper()
+		if !scanner.Scan() {
+			t.Fatalf("stream ended early: %v", scanner.Err())
+		}
+		var item handlers.BatchItemResult
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		seen[item.Index] = item.FormattedAddress
+	}
+
+	// The fast addresses arrive while the slow one is still in flight
+	readLine()
+	readLine()
+	if _, ok := seen[0]; ok {
+		t.Errorf("slow address streamed before it was released")
+	}
+
+	close(validator.release)
+	readLine()
+	if scanner.Scan() {
+		t.Errorf("unexpected extra line %q", scanner.Text())
+	}
+
+	for i, address := range addresses {
+		if seen[i] != address {
+			t.Errorf("index %d = %q, want %q", i, seen[i], address)
+		}
+	}
+}
+
+func TestBatchHandler_ValidateBatch_NDJSONOutlivesWriteTimeout(t *testing.T) {
+	logger := zap.NewNop()
+	service := services.NewAddressService(slowValidator{delay: 50 * time.Millisecond}, logger, config.MapConfig{}, config.ValidationConfig{})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	h := handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, config.BatchConfig{MaxSize: 10, Concurrency: 1}, logger)
+
+	// Eight addresses one at a time stream for about 400ms, well past the write deadline
+	server := httptest.NewUnstartedServer(http.HandlerFunc(h.ValidateBatch))
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	addresses := []string{"1 Main St", "2 Main St", "3 Main St", "4 Main St", "5 Main St", "6 Main St", "7 Main St", "8 Main St"}
+	body, _ := json.Marshal(handlers.BatchRequest{Addresses: addresses})
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(string(body)))
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines++
+	}
+	if lines != len(addresses) {
+		t.Errorf("streamed lines = %d, want %d (%v)", lines, len(addresses), scanner.Err())
+	}
+}
+
+func TestBatchHandler_ValidateBatch_DailyQuota(t *testing.T) {
+	logger := zap.NewNop()
+	service := services.NewAddressService(batchStubValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	h := handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, config.BatchConfig{MaxSize: 10, Concurrency: 3}, logger)
+	h.SetDailyQuota(handlers.NewDailyQuota(3, nil))
+
+	// Each address is charged, a batch over what is left is rejected without spending any of it
+	batches := []struct {
+		addresses  []string
+		wantStatus int
+	}{
+		{addresses: []string{"1 Main St", "2 Main St"}, wantStatus: http.StatusOK},
+		{addresses: []string{"3 Main St", "4 Main St"}, wantStatus: http.StatusTooManyRequests},
+		{addresses: []string{"5 Main St"}, wantStatus: http.StatusOK},
+		{addresses: []string{"6 Main St"}, wantStatus: http.StatusTooManyRequests},
+	}
+	for i, batch := range batches {
+		body, _ := json.Marshal(handlers.BatchRequest{Addresses: batch.addresses})
+		r := httptest.NewRequest(http.MethodPost, "/validate/batch", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		h.ValidateBatch(w, r)
+
+		if w.Code != batch.wantStatus {
+			t.Fatalf("batch %d: BatchHandler.ValidateBatch() status = %v, want %v", i, w.Code, batch.wantStatus)
+		}
+		if batch.wantStatus != http.StatusTooManyRequests {
+			continue
+		}
+		var got ports.AddressValidationResult
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("decoding response error = %v", err)
+		}
+		if got.ErrorCode != ports.ERROR_CODE_DAILY_QUOTA {
+			t.Errorf("batch %d: response ErrorCode = %q, want %q", i, got.ErrorCode, ports.ERROR_CODE_DAILY_QUOTA)
+		}
+	}
+}
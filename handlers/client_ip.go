@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"address-validator/config"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIPResolver determines the client IP, honoring the client IP headers only from trusted proxies
+type ClientIPResolver struct {
+	headers        []string
+	trustedProxies []netip.Prefix
+}
+
+// NewClientIPResolver creates a new client IP resolver
+func NewClientIPResolver(config config.InfraConfig) *ClientIPResolver {
+	return &ClientIPResolver{
+		headers:        config.ClientIPHeaders,
+		trustedProxies: config.TrustedProxies,
+	}
+}
+
+// ClientIP returns the IP of the client that sent the request
+func (cr *ClientIPResolver) ClientIP(r *http.Request) string {
+	peer := normalizeIP(r.RemoteAddr)
+
+	// Anyone can set the header, so only trust it when the peer is one of our proxies
+	if !cr.isTrusted(peer) {
+		return peer
+	}
+
+	// The first configured header naming an untrusted hop wins, later ones are fallbacks
+	for _, header := range cr.headers {
+		if hop := cr.clientHop(r.Header.Values(header)); hop != "" {
+			return hop
+		}
+	}
+
+	return peer
+}
+
+// clientHop returns the rightmost untrusted hop of the header values, or "" when there is none
+func (cr *ClientIPResolver) clientHop(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	// Walk right to left, proxies append so the rightmost untrusted hop is the real client
+	hops := strings.Split(strings.Join(values, ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := normalizeIP(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !cr.isTrusted(hop) {
+			return hop
+		}
+	}
+
+	return ""
+}
+
+// isTrusted reports whether the IP belongs to a trusted proxy
+func (cr *ClientIPResolver) isTrusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range cr.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeIP reduces a peer or header address to its canonical IP so every form of a client shares
+// one rate limit key: spaces, the port, IPv6 brackets and zone are dropped and IPv4-mapped IPv6 is
+// unmapped, e.g. " 1.2.3.4:5678" and "[::ffff:1.2.3.4]" both become "1.2.3.4".
+// A value that is not an IP is returned trimmed.
+func normalizeIP(value string) string {
+	value = strings.TrimSpace(value)
+
+	host := value
+	if withoutPort, _, err := net.SplitHostPort(value); err == nil {
+		host = withoutPort
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return value
+	}
+	return addr.Unmap().WithZone("").String()
+}
@@ -0,0 +1,191 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestClientIPResolver_ClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	ordered := []string{"CF-Connecting-IP", "X-Real-IP", "X-Forwarded-For"}
+
+	tests := []struct {
+		name       string
+		config     config.InfraConfig
+		remoteAddr string
+		headers    [][2]string
+		want       string
+	}{
+		{
+			name:       "Test No Header Returns Peer Without Port",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "Test Forwarded For From Trusted Proxy Returns Client",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "203.0.113.7"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Test Custom Header From Trusted Proxy Returns Client",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"CF-Connecting-IP"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"CF-Connecting-IP", "203.0.113.7"}, {"X-Forwarded-For", "198.51.100.1"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Test Default Header Ignored When Custom Header Configured",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"CF-Connecting-IP"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "198.51.100.1"}},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "Test Header From Untrusted Peer Is Ignored",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"CF-Connecting-IP"}, TrustedProxies: trusted},
+			remoteAddr: "198.51.100.9:5555",
+			headers:    [][2]string{{"CF-Connecting-IP", "203.0.113.7"}},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "Test Header Ignored Without Trusted Proxies",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "203.0.113.7"}},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "Test Spoofed Leftmost Hop Is Skipped",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "1.2.3.4, 203.0.113.7, 10.0.0.2"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Test First Present Header Takes Precedence",
+			config:     config.InfraConfig{ClientIPHeaders: ordered, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "198.51.100.1"}, {"X-Real-IP", "192.0.2.4"}, {"CF-Connecting-IP", "203.0.113.7"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Test Missing Header Falls Back To Next",
+			config:     config.InfraConfig{ClientIPHeaders: ordered, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "198.51.100.1"}, {"X-Real-IP", "192.0.2.4"}},
+			want:       "192.0.2.4",
+		},
+		{
+			name:       "Test Header Naming Only Proxies Falls Back To Next",
+			config:     config.InfraConfig{ClientIPHeaders: ordered, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Real-IP", "10.0.0.3"}, {"X-Forwarded-For", "198.51.100.1, 10.0.0.2"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "Test No Configured Header Returns Peer",
+			config:     config.InfraConfig{ClientIPHeaders: ordered, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"True-Client-IP", "203.0.113.7"}},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "Test Ordered Headers From Untrusted Peer Are Ignored",
+			config:     config.InfraConfig{ClientIPHeaders: ordered, TrustedProxies: trusted},
+			remoteAddr: "198.51.100.9:5555",
+			headers:    [][2]string{{"CF-Connecting-IP", "203.0.113.7"}, {"X-Real-IP", "192.0.2.4"}},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "Test Forwarded Hop With Port Returns IP",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", " 203.0.113.7:5678 , 10.0.0.2"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Test Forwarded IPv6 Hop With Port Returns IP",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "[2001:db8::1]:443"}},
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "Test Forwarded Bracketed IPv6 Hop Returns IP",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "[2001:DB8:0::1]"}},
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "Test Trusted Proxy Hop With Port Is Skipped",
+			config:     config.InfraConfig{ClientIPHeaders: []string{"X-Forwarded-For"}, TrustedProxies: trusted},
+			remoteAddr: "10.0.0.1:5555",
+			headers:    [][2]string{{"X-Forwarded-For", "203.0.113.7, 10.0.0.2:8080"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Test IPv4-Mapped Peer Returns IPv4",
+			config:     config.InfraConfig{},
+			remoteAddr: "[::ffff:203.0.113.7]:5555",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Test IPv6 Peer Returns IP Without Port",
+			config:     config.InfraConfig{},
+			remoteAddr: "[2001:db8::1]:5555",
+			want:       "2001:db8::1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/validate", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, pair := range tt.headers {
+				r.Header.Set(pair[0], pair[1])
+			}
+
+			cr := handlers.NewClientIPResolver(tt.config)
+			if got := cr.ClientIP(r); got != tt.want {
+				t.Errorf("ClientIPResolver.ClientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPResolver_ClientIP_SameBucket(t *testing.T) {
+	infraConfig := config.InfraConfig{
+		ClientIPHeaders: []string{"X-Forwarded-For"},
+		TrustedProxies:  []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+	}
+	cr := handlers.NewClientIPResolver(infraConfig)
+
+	tests := []struct {
+		name   string
+		values []string
+	}{
+		{name: "Test IPv4 Forms Share A Key", values: []string{"1.2.3.4", "1.2.3.4:5678", " 1.2.3.4 ", "::ffff:1.2.3.4"}},
+		{name: "Test IPv6 Forms Share A Key", values: []string{"2001:db8::1", "[2001:db8::1]:5678", "2001:DB8:0:0::1", " [2001:db8::1] "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := make(map[string]bool)
+			for _, value := range tt.values {
+				r := httptest.NewRequest(http.MethodPost, "/validate", nil)
+				r.RemoteAddr = "10.0.0.1:5555"
+				r.Header.Set("X-Forwarded-For", value)
+				keys[cr.ClientIP(r)] = true
+			}
+			if len(keys) != 1 {
+				t.Errorf("ClientIPResolver.ClientIP() keys = %v, want one key", keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"address-validator/config"
+
+	"go.uber.org/zap"
+)
+
+// Supported response content encodings, in order of preference
+const (
+	ENCODING_GZIP    = "gzip"
+	ENCODING_DEFLATE = "deflate"
+)
+
+// Compressor compresses responses for clients that accept gzip or deflate
+type Compressor struct {
+	minSize int
+	logger  *zap.Logger
+}
+
+// NewCompressor creates a compressor that leaves responses under the configured minimum size as is
+func NewCompressor(config config.CompressionConfig, logger *zap.Logger) *Compressor {
+	return &Compressor{
+		minSize: int(config.MinSize),
+		logger:  logger,
+	}
+}
+
+// Middleware compresses the response body using the best encoding the client accepts.
+// The body is buffered until it reaches the minimum size, so small responses keep
+// their Content-Length and go out uncompressed.
+func (c *Compressor) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: c.minSize}
+		defer func() {
+			if err := cw.Close(); err != nil {
+				c.logger.Warn("failed to finish compressed response", zap.Error(err))
+			}
+		}()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, honoring q=0.
+// An encoding refused by name stays refused even when the header also accepts "*".
+func negotiateEncoding(header string) string {
+	accepted := map[string]bool{}
+	refused := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if q, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(params)), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				refused[name] = true
+				continue
+			}
+		}
+		accepted[name] = true
+	}
+
+	for _, encoding := range []string{ENCODING_GZIP, ENCODING_DEFLATE} {
+		if refused[encoding] {
+			continue
+		}
+		if accepted[encoding] || accepted["*"] {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// compressWriter buffers the start of the body to decide whether it is worth compressing
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser
+}
+
+// WriteHeader defers the status until the encoding is decided, since it changes the headers
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if cw.status == 0 {
+		cw.status = status
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	if cw.decided {
+		if cw.encoder != nil {
+			return cw.encoder.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= cw.minSize {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Flush sends what is buffered so far. A stream flushed before reaching the minimum size
+// stays uncompressed so each flush reaches the client immediately.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if err := cw.decide(false); err != nil {
+			return
+		}
+	}
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close sends a response that never reached the minimum size and finishes the compressed stream
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if cw.status == 0 {
+			return nil
+		}
+		if bodyAllowed(cw.status) {
+			cw.Header().Set("Content-Length", strconv.Itoa(len(cw.buf)))
+		}
+		if err := cw.decide(false); err != nil {
+			return err
+		}
+	}
+	if cw.encoder != nil {
+		return cw.encoder.Close()
+	}
+	return nil
+}
+
+// decide sends the headers and the buffered body, compressed when compress is set and the
+// response is eligible
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	header := cw.Header()
+	if compress && header.Get("Content-Encoding") == "" && bodyAllowed(cw.status) {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		// The tag of the uncompressed body must not be sent with the compressed one
+		if etag := header.Get("ETag"); etag != "" {
+			header.Set("ETag", encodedETag(etag, cw.encoding))
+		}
+
+		switch cw.encoding {
+		case ENCODING_DEFLATE:
+			encoder, err := flate.NewWriter(cw.ResponseWriter, flate.DefaultCompression)
+			if err != nil {
+				return err
+			}
+			cw.encoder = encoder
+		default:
+			cw.encoder = gzip.NewWriter(cw.ResponseWriter)
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if cw.encoder != nil {
+		_, err := cw.encoder.Write(buf)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buf)
+	return err
+}
+
+// bodyAllowed reports whether a response with status may carry a body
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
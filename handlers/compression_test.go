@@ -0,0 +1,181 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestCompressor_Middleware(t *testing.T) {
+	large := strings.Repeat(`{"isValid":true,"formattedAddress":"123 Main St, Bronx, NY 10456, USA"}`+"\n", 100)
+	small := `{"isValid":true}` + "\n"
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		body           string
+		wantEncoding   string
+	}{
+		{
+			name:           "Test Large Response Is Gzipped",
+			acceptEncoding: "gzip, deflate, br",
+			body:           large,
+			wantEncoding:   "gzip",
+		},
+		{
+			name:           "Test Large Response Is Deflated",
+			acceptEncoding: "deflate",
+			body:           large,
+			wantEncoding:   "deflate",
+		},
+		{
+			name:           "Test Small Response Passes Through",
+			acceptEncoding: "gzip",
+			body:           small,
+		},
+		{
+			name: "Test Missing Accept-Encoding Passes Through",
+			body: large,
+		},
+		{
+			name:           "Test Refused Encoding Passes Through",
+			acceptEncoding: "gzip;q=0, identity",
+			body:           large,
+		},
+		{
+			name:           "Test Wildcard Does Not Override Refused Encoding",
+			acceptEncoding: "gzip;q=0, *",
+			body:           large,
+			wantEncoding:   "deflate",
+		},
+		{
+			name:           "Test Wildcard Refusing Everything Else Passes Through",
+			acceptEncoding: "gzip;q=0, deflate;q=0, *",
+			body:           large,
+		},
+		{
+			name:           "Test Named Encoding Survives Refused Wildcard",
+			acceptEncoding: "*;q=0, deflate",
+			body:           large,
+			wantEncoding:   "deflate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			})
+			h := handlers.NewCompressor(config.CompressionConfig{Enabled: true, MinSize: 1024}, zap.NewNop()).Middleware(next)
+
+			r := httptest.NewRequest(http.MethodPost, "/validate/batch", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+
+			var body io.Reader = w.Body
+			switch tt.wantEncoding {
+			case "gzip":
+				gz, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("invalid gzip body: %v", err)
+				}
+				body = gz
+			case "deflate":
+				body = flate.NewReader(w.Body)
+			}
+
+			if tt.wantEncoding != "" {
+				if w.Header().Get("Content-Length") != "" {
+					t.Errorf("Content-Length = %q on a compressed response", w.Header().Get("Content-Length"))
+				}
+				if w.Body.Len() >= len(tt.body) {
+					t.Errorf("compressed size = %d, want less than %d", w.Body.Len(), len(tt.body))
+				}
+			} else if tt.body == small {
+				if got, want := w.Header().Get("Content-Length"), strconv.Itoa(len(tt.body)); got != want {
+					t.Errorf("Content-Length = %q, want %q", got, want)
+				}
+			}
+
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			if string(got) != tt.body {
+				t.Errorf("body = %q, want %q", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestCompressor_Middleware_Status(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+	})
+	h := handlers.NewCompressor(config.CompressionConfig{Enabled: true, MinSize: 1024}, zap.NewNop()).Middleware(next)
+
+	r := httptest.NewRequest(http.MethodGet, "/validate", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %v, want %v", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Body.String(); got != "Rate limit exceeded\n" {
+		t.Errorf("body = %q, want the error message", got)
+	}
+}
+
+func TestCompressor_Middleware_ETag(t *testing.T) {
+	large := strings.Repeat(`{"isValid":true,"formattedAddress":"123 Main St, Bronx, NY 10456, USA"}`+"\n", 100)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "Test Gzipped Response Gets Weak Encoding ETag", acceptEncoding: "gzip", want: `W/"abc-gzip"`},
+		{name: "Test Deflated Response Gets Weak Encoding ETag", acceptEncoding: "deflate", want: `W/"abc-deflate"`},
+		{name: "Test Uncompressed Response Keeps ETag", want: `"abc"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"abc"`)
+				io.WriteString(w, large)
+			})
+			h := handlers.NewCompressor(config.CompressionConfig{Enabled: true, MinSize: 1024}, zap.NewNop()).Middleware(next)
+
+			r := httptest.NewRequest(http.MethodGet, "/validate", nil)
+			if tt.acceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Header().Get("ETag"); got != tt.want {
+				t.Errorf("ETag = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
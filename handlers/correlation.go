@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"address-validator/audit"
+)
+
+// CORRELATION_ID_HEADER carries the ID that ties a request to its audit records
+const CORRELATION_ID_HEADER = "X-Request-ID"
+
+// maxCorrelationIDLength bounds client supplied IDs so they cannot bloat the audit trail
+const maxCorrelationIDLength = 128
+
+// CorrelationMiddleware attaches a correlation ID to the request context and echoes it in the response.
+// A well-formed client supplied ID is kept so records can be joined with upstream logs.
+func CorrelationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(CORRELATION_ID_HEADER)
+		if !validCorrelationID(id) {
+			id = audit.NewCorrelationID()
+		}
+
+		w.Header().Set(CORRELATION_ID_HEADER, id)
+		next.ServeHTTP(w, r.WithContext(audit.WithCorrelationID(r.Context(), id)))
+	})
+}
+
+// validCorrelationID reports whether id is a non-empty, bounded string of visible ASCII
+func validCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package handlers_test
+
+import (
+	"address-validator/audit"
+	"address-validator/handlers"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCorrelationMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantKept bool
+	}{
+		{name: "Test Client ID Is Kept", header: "req-1", wantKept: true},
+		{name: "Test Missing ID Is Generated"},
+		{name: "Test Oversized ID Is Replaced", header: strings.Repeat("a", 129)},
+		{name: "Test ID With Spaces Is Replaced", header: "req 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = audit.CorrelationID(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/validate", nil)
+			if tt.header != "" {
+				req.Header.Set(handlers.CORRELATION_ID_HEADER, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handlers.CorrelationMiddleware(next).ServeHTTP(rec, req)
+
+			got := rec.Header().Get(handlers.CORRELATION_ID_HEADER)
+			if got == "" || got != seen {
+				t.Fatalf("response ID = %q, context ID = %q, want equal and non-empty", got, seen)
+			}
+			if (got == tt.header) != tt.wantKept {
+				t.Errorf("CorrelationMiddleware() ID = %q, want kept %v", got, tt.wantKept)
+			}
+		})
+	}
+}
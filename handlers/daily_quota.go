@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"address-validator/ports"
+)
+
+// DailyQuota caps the requests of each client per day, on top of the short window of the Limiter.
+// Counts are kept in memory and reset for every client at midnight in its location.
+type DailyQuota struct {
+	limit    uint
+	location *time.Location
+	counts   map[string]uint
+	resetAt  time.Time
+	mu       sync.Mutex
+}
+
+// NewDailyQuota creates a quota of limit requests per client per day, ending at midnight in location.
+// A nil location is UTC.
+func NewDailyQuota(limit uint, location *time.Location) *DailyQuota {
+	if location == nil {
+		location = time.UTC
+	}
+	return &DailyQuota{
+		limit:    limit,
+		location: location,
+		counts:   make(map[string]uint),
+	}
+}
+
+// Allow counts a request from key, reporting the time until the quota resets when it is spent
+func (dq *DailyQuota) Allow(key string) (bool, time.Duration) {
+	return dq.AllowAt(key, time.Now())
+}
+
+// AllowAt counts a request from key arriving at now, reporting the time until the quota resets when it is spent
+func (dq *DailyQuota) AllowAt(key string, now time.Time) (bool, time.Duration) {
+	return dq.AllowNAt([]string{key}, 1, now)
+}
+
+// AllowN counts n requests from each of keys, reporting the time until the quota resets when any of them
+// has fewer than n left. Nothing is counted then, so a batch is admitted whole or not at all.
+func (dq *DailyQuota) AllowN(keys []string, n uint) (bool, time.Duration) {
+	return dq.AllowNAt(keys, n, time.Now())
+}
+
+// AllowNAt counts n requests from each of keys arriving at now, like AllowN
+func (dq *DailyQuota) AllowNAt(keys []string, n uint, now time.Time) (bool, time.Duration) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	if !now.Before(dq.resetAt) {
+		dq.counts = make(map[string]uint)
+		dq.resetAt = nextMidnight(now, dq.location)
+	}
+
+	for _, key := range keys {
+		if dq.counts[key]+n > dq.limit {
+			return false, dq.resetAt.Sub(now)
+		}
+	}
+	for _, key := range keys {
+		dq.counts[key] += n
+	}
+	return true, 0
+}
+
+// nextMidnight returns the start of the day after now in location, AddDate keeps it right across DST changes
+func nextMidnight(now time.Time, location *time.Location) time.Time {
+	year, month, day := now.In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location).AddDate(0, 0, 1)
+}
+
+// writeDailyQuotaExceeded answers 429 with DAILY_QUOTA_EXCEEDED and the whole seconds until the reset in Retry-After
+func writeDailyQuotaExceeded(w http.ResponseWriter, retryAfter time.Duration, fieldCase string) {
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+	encodeJSON(w, ports.AddressValidationResult{
+		Error:     "Daily quota exceeded",
+		ErrorCode: ports.ERROR_CODE_DAILY_QUOTA,
+	}, fieldCase)
+}
@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestDailyQuota_AllowAt(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	// 22:00 in New York, 03:00 the next day in UTC
+	evening := time.Date(2024, time.March, 4, 22, 0, 0, 0, newYork)
+
+	type request struct {
+		ip             string
+		offset         time.Duration
+		want           bool
+		wantRetryAfter time.Duration
+	}
+
+	tests := []struct {
+		name     string
+		limit    uint
+		location *time.Location
+		requests []request
+	}{
+		{
+			name:     "Test Requests Over The Daily Cap Are Rejected Until Midnight",
+			limit:    2,
+			location: newYork,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", offset: time.Minute, want: true},
+				{ip: "1.1.1.1", offset: time.Hour, want: false, wantRetryAfter: time.Hour},
+			},
+		},
+		{
+			name:     "Test Quota Resets At Midnight In The Configured Time Zone",
+			limit:    1,
+			location: newYork,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", offset: time.Hour, want: false, wantRetryAfter: time.Hour},
+				{ip: "1.1.1.1", offset: 2 * time.Hour, want: true},
+			},
+		},
+		{
+			name:  "Test Nil Location Resets At Midnight UTC",
+			limit: 1,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: false, wantRetryAfter: 21 * time.Hour},
+			},
+		},
+		{
+			name:     "Test Each Client Has Its Own Quota",
+			limit:    1,
+			location: newYork,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: false, wantRetryAfter: 2 * time.Hour},
+				{ip: "2.2.2.2", want: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := handlers.NewDailyQuota(tt.limit, tt.location)
+			for i, req := range tt.requests {
+				got, retryAfter := quota.AllowAt(req.ip, evening.Add(req.offset))
+				if got != req.want || retryAfter != req.wantRetryAfter {
+					t.Errorf("request %d: DailyQuota.AllowAt() = %v, %v, want %v, %v", i, got, retryAfter, req.want, req.wantRetryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestAddressHandler_ValidateAddress_DailyQuota(t *testing.T) {
+	logger := zap.NewNop()
+	validator := adapters.NewMockValidator([]adapters.MockFixture{
+		{Match: "1 Main St", Result: ports.AddressValidationResult{IsValid: true, FormattedAddress: "1 Main St, Bronx, NY 10456, USA"}},
+	}, logger)
+	service := services.NewAddressService(validator, logger, config.MapConfig{}, config.ValidationConfig{})
+	rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+	h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+	h.SetDailyQuota(handlers.NewDailyQuota(2, nil))
+
+	wantStatuses := []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}
+	for i, wantStatus := range wantStatuses {
+		r := httptest.NewRequest(http.MethodGet, "/validate?address="+url.QueryEscape("1 Main St"), nil)
+		w := httptest.NewRecorder()
+		h.ValidateAddress(w, r)
+
+		if w.Code != wantStatus {
+			t.Fatalf("request %d: AddressHandler.ValidateAddress() status = %v, want %v", i, w.Code, wantStatus)
+		}
+		if wantStatus != http.StatusTooManyRequests {
+			continue
+		}
+
+		retryAfter, err := time.ParseDuration(w.Header().Get("Retry-After") + "s")
+		if err != nil || retryAfter <= 0 || retryAfter > 24*time.Hour {
+			t.Errorf("Retry-After = %q, want the seconds until midnight UTC", w.Header().Get("Retry-After"))
+		}
+		var got ports.AddressValidationResult
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("decoding response error = %v", err)
+		}
+		if got.ErrorCode != ports.ERROR_CODE_DAILY_QUOTA {
+			t.Errorf("response ErrorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_DAILY_QUOTA)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"address-validator/audit"
+	"address-validator/logging"
+
+	"go.uber.org/zap"
+)
+
+// DebugSampler logs a random fraction of requests at debug level
+type DebugSampler struct {
+	rate        float64
+	debugLogger *zap.Logger
+}
+
+// NewDebugSampler creates a sampler handing debugLogger to a rate fraction of requests.
+// A nil debugLogger or a rate of 0 disables sampling.
+func NewDebugSampler(rate float64, debugLogger *zap.Logger) *DebugSampler {
+	return &DebugSampler{
+		rate:        rate,
+		debugLogger: debugLogger,
+	}
+}
+
+// Middleware attaches the debug logger to the context of sampled requests and logs their
+// request and response. Unsampled requests pass through untouched.
+func (ds *DebugSampler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The top-level math/rand/v2 source is lock-free and safe for concurrent use
+		if ds.debugLogger == nil || ds.rate <= 0 || rand.Float64() >= ds.rate {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		logger := ds.debugLogger.With(zap.String("correlationId", audit.CorrelationID(r.Context())))
+		logger.Debug("sampled request",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.String("query", r.URL.RawQuery),
+			zap.Any("headers", redactHeaders(r.Header)),
+		)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(recorder, r.WithContext(logging.WithLogger(r.Context(), logger)))
+
+		logger.Debug("sampled response",
+			zap.Int("status", recorder.status),
+			zap.Int("bytes", recorder.bytes),
+			zap.Any("headers", recorder.Header()),
+			zap.Duration("duration", time.Since(start)),
+		)
+	})
+}
+
+// redactedHeaders carry credentials and are never logged
+var redactedHeaders = []string{"Authorization", "Cookie", "X-Goog-Api-Key"}
+
+// redactHeaders returns a copy of header with credential values replaced
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[REDACTED]")
+		}
+	}
+	return redacted
+}
+
+// statusRecorder captures the status and size of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+func (sr *statusRecorder) Write(p []byte) (int, error) {
+	n, err := sr.ResponseWriter.Write(p)
+	sr.bytes += n
+	return n, err
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+// Flush keeps streamed batch responses flushing through the recorder
+func (sr *statusRecorder) Flush() {
+	if flusher, ok := sr.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
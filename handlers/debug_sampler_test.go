@@ -0,0 +1,73 @@
+package handlers_test
+
+import (
+	"address-validator/handlers"
+	"address-validator/logging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDebugSampler_Middleware(t *testing.T) {
+	const requests = 100
+
+	tests := []struct {
+		name        string
+		rate        float64
+		wantSampled int
+	}{
+		{name: "Test Rate One Samples Every Request", rate: 1.0, wantSampled: requests},
+		{name: "Test Rate Zero Samples No Request", rate: 0.0, wantSampled: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			debugLogger := zap.New(core)
+			fallback := zap.NewNop()
+
+			sampled := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logger := logging.FromContext(r.Context(), fallback)
+				if logger != fallback {
+					sampled++
+					logger.Debug("handler detail")
+				}
+				w.WriteHeader(http.StatusTeapot)
+			})
+			handler := handlers.NewDebugSampler(tt.rate, debugLogger).Middleware(next)
+
+			for i := 0; i < requests; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/validate?address=1+Main+St", nil)
+				req.Header.Set("Authorization", "Bearer secret")
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				if rec.Code != http.StatusTeapot {
+					t.Fatalf("status = %d, want %d", rec.Code, http.StatusTeapot)
+				}
+			}
+
+			if sampled != tt.wantSampled {
+				t.Errorf("sampled requests = %d, want %d", sampled, tt.wantSampled)
+			}
+			// Each sampled request logs its request, the handler detail and its response
+			if got := logs.Len(); got != 3*tt.wantSampled {
+				t.Errorf("debug entries = %d, want %d", got, 3*tt.wantSampled)
+			}
+
+			for _, entry := range logs.FilterMessage("sampled request").All() {
+				headers, _ := entry.ContextMap()["headers"].(http.Header)
+				if got := headers.Get("Authorization"); got != "[REDACTED]" {
+					t.Fatalf("logged Authorization = %q, want redacted", got)
+				}
+			}
+			for _, entry := range logs.FilterMessage("sampled response").All() {
+				if got := entry.ContextMap()["status"]; got != int64(http.StatusTeapot) {
+					t.Fatalf("logged status = %v, want %d", got, http.StatusTeapot)
+				}
+			}
+		})
+	}
+}
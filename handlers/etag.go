@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// bodyETag computes a strong ETag from the response body, so any change to what is sent changes the tag
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// encodedETag derives the ETag of a body compressed with encoding. The compressed bytes are not
+// guaranteed to be the same each time, so the tag is weak, and it names the encoding so it differs
+// from the tag of the uncompressed body.
+func encodedETag(etag, encoding string) string {
+	opaque := strings.TrimSuffix(strings.TrimPrefix(etag, "W/"), `"`)
+	return `W/` + opaque + "-" + encoding + `"`
+}
+
+// etagMatches reports whether an If-None-Match header value matches the ETag of the uncompressed body.
+// Weak comparison is used as allowed for If-None-Match, a tag of the body in any supported encoding
+// matches as well since it carries the same content.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+		for _, encoding := range []string{ENCODING_GZIP, ENCODING_DEFLATE} {
+			if candidate == encodedETag(etag, encoding) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+// graceState tracks how much of its grace burst a key has used since it was first seen
+type graceState struct {
+	firstSeen time.Time
+	lastSeen  time.Time
+	used      uint
+}
+
+// GraceLimiter lets a key seen for the first time make a burst of requests regardless of the
+// wrapped limiter, for legitimate clients that send several requests on arrival. The wrapped
+// limiter is still consulted on every request but only counts those it allows, so requests
+// granted from the burst past its limit come on top of the normal limit. Normal limiting applies
+// once the burst is spent or the grace window has passed.
+type GraceLimiter struct {
+	next   Limiter
+	burst  uint
+	window time.Duration
+	keys   map[string]*graceState
+	// lastSweep is when keys past their grace window were last dropped
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// NewGraceLimiter wraps a limiter with a grace burst for new keys, available for window after first seen.
+// window must not be shorter than the window of the wrapped limiter, see sweep.
+func NewGraceLimiter(next Limiter, burst uint, window time.Duration) *GraceLimiter {
+	return &GraceLimiter{
+		next:   next,
+		burst:  burst,
+		window: window,
+		keys:   make(map[string]*graceState),
+	}
+}
+
+// Allow checks if a request is allowed, granting it from the grace burst when the limit is exceeded
+func (gl *GraceLimiter) Allow(ip string) bool {
+	return gl.AllowAt(ip, time.Now())
+}
+
+// AllowAt checks if a request arriving at now is allowed, granting it from the grace burst when the limit is exceeded
+func (gl *GraceLimiter) AllowAt(ip string, now time.Time) bool {
+	// Always consult the wrapped limiter so burst requests count toward the normal limit
+	allowed := gl.next.Allow(ip)
+
+	gl.mu.Lock()
+	defer gl.mu.Unlock()
+
+	gl.sweep(now)
+	state, ok := gl.keys[ip]
+	if !ok {
+		state = &graceState{firstSeen: now}
+		gl.keys[ip] = state
+	}
+	state.lastSeen = now
+
+	if state.used >= gl.burst || now.Sub(state.firstSeen) > gl.window {
+		return allowed
+	}
+	state.used++
+	return true
+}
+
+// sweep drops the keys not seen for a whole window, at most once per window so the map stays
+// bounded by the keys seen recently without scanning it on every request. The wrapped limiter has
+// forgotten such a key too, so it gets a new burst like any new client. The caller must hold the lock.
+func (gl *GraceLimiter) sweep(now time.Time) {
+	if now.Sub(gl.lastSweep) < gl.window {
+		return
+	}
+	gl.lastSweep = now
+	for key, state := range gl.keys {
+		if now.Sub(state.lastSeen) > gl.window {
+			delete(gl.keys, key)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"testing"
+	"time"
+)
+
+func TestGraceLimiter_AllowAt(t *testing.T) {
+	type request struct {
+		ip     string
+		offset time.Duration
+		want   bool
+	}
+
+	tests := []struct {
+		name     string
+		burst    uint
+		requests []request
+	}{
+		{
+			name:  "Test New IP Burst Is Allowed Then Limited",
+			burst: 4,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				// The burst is spent and the limiter already holds 2 requests
+				{ip: "1.1.1.1", offset: time.Second, want: false},
+			},
+		},
+		{
+			name:  "Test Each New IP Gets Its Own Burst",
+			burst: 3,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: false},
+				{ip: "2.2.2.2", want: true},
+				{ip: "2.2.2.2", want: true},
+				{ip: "2.2.2.2", want: true},
+				{ip: "2.2.2.2", want: false},
+			},
+		},
+		{
+			name:  "Test Burst Expires After Grace Window",
+			burst: 5,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", offset: 50 * time.Second, want: true},
+				{ip: "1.1.1.1", offset: 100 * time.Second, want: false},
+			},
+		},
+		{
+			// Keys are dropped once idle for a window, so the map does not keep every IP ever seen
+			name:  "Test Idle IP Is Forgotten After Grace Window",
+			burst: 2,
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", offset: 30 * time.Second, want: false},
+				{ip: "1.1.1.1", offset: 3 * time.Minute, want: true},
+			},
+		},
+		{
+			name: "Test Zero Burst Applies Normal Limiting",
+			requests: []request{
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: true},
+				{ip: "1.1.1.1", want: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 2, TimeWindow: time.Hour})
+			limiter := handlers.NewGraceLimiter(next, tt.burst, time.Minute)
+
+			for i, req := range tt.requests {
+				if got := limiter.AllowAt(req.ip, windowStart.Add(req.offset)); got != req.want {
+					t.Errorf("request %d from %s at +%v: GraceLimiter.AllowAt() = %v, want %v", i, req.ip, req.offset, got, req.want)
+				}
+			}
+		})
+	}
+}
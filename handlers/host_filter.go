@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// HostFilter rejects requests whose Host header is not an allowed hostname
+type HostFilter struct {
+	allowed []string
+	logger  *zap.Logger
+}
+
+// NewHostFilter creates a filter accepting the given lowercase hostnames, an empty list accepts any host
+func NewHostFilter(allowed []string, logger *zap.Logger) *HostFilter {
+	return &HostFilter{
+		allowed: allowed,
+		logger:  logger,
+	}
+}
+
+// Middleware answers 400 for requests to an unknown host, guarding against host header
+// injection and cache poisoning. Health checks are exempt since probes address the instance IP.
+func (hf *HostFilter) Middleware(next http.Handler) http.Handler {
+	if len(hf.allowed) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" || r.URL.Path == "/readyz" || hf.isAllowed(r.Host) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		hf.logger.Warn("rejected request for unknown host", zap.String("host", r.Host))
+		http.Error(w, "Invalid host", http.StatusBadRequest)
+	})
+}
+
+// isAllowed reports whether the host, without its port, is in the allowlist
+func (hf *HostFilter) isAllowed(host string) bool {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.TrimSuffix(strings.ToLower(strings.Trim(host, "[]")), ".")
+	return slices.Contains(hf.allowed, host)
+}
@@ -0,0 +1,47 @@
+package handlers_test
+
+import (
+	"address-validator/handlers"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestHostFilter_Middleware(t *testing.T) {
+	allowed := []string{"api.example.com", "localhost"}
+
+	tests := []struct {
+		name       string
+		allowed    []string
+		host       string
+		path       string
+		wantStatus int
+	}{
+		{name: "Test Allowed Host Passes", allowed: allowed, host: "api.example.com", path: "/validate", wantStatus: http.StatusOK},
+		{name: "Test Allowed Host With Port Passes", allowed: allowed, host: "localhost:8080", path: "/validate", wantStatus: http.StatusOK},
+		{name: "Test Host Match Ignores Case", allowed: allowed, host: "API.Example.com", path: "/validate", wantStatus: http.StatusOK},
+		{name: "Test Disallowed Host Is Rejected", allowed: allowed, host: "evil.example.net", path: "/validate", wantStatus: http.StatusBadRequest},
+		{name: "Test Allowed Suffix Does Not Match", allowed: allowed, host: "api.example.com.evil.net", path: "/validate", wantStatus: http.StatusBadRequest},
+		{name: "Test Health Check Skips Host Check", allowed: allowed, host: "10.0.0.12:8080", path: "/health", wantStatus: http.StatusOK},
+		{name: "Test Empty List Allows Any Host", host: "anything.example.org", path: "/validate", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := handlers.NewHostFilter(tt.allowed, zap.NewNop()).Middleware(next)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("HostFilter.Middleware() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
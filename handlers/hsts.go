@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"address-validator/config"
+)
+
+// HSTS_HEADER tells browsers to only reach the host over HTTPS for max-age seconds
+const HSTS_HEADER = "Strict-Transport-Security"
+
+// HSTSMiddleware sets the Strict-Transport-Security header on responses to HTTPS requests.
+// Plain HTTP responses never carry it, a man in the middle could strip or forge it anyway.
+func HSTSMiddleware(infraConfig config.InfraConfig, next http.Handler) http.Handler {
+	if infraConfig.HSTSMaxAge <= 0 {
+		return next
+	}
+
+	value := "max-age=" + strconv.FormatInt(int64(infraConfig.HSTSMaxAge.Seconds()), 10)
+	if infraConfig.HSTSIncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isHTTPS(r, infraConfig) {
+			w.Header().Set(HSTS_HEADER, value)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
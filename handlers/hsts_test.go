@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"crypto/tls"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHSTSMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name      string
+		config    config.InfraConfig
+		tls       bool
+		forwarded string
+		want      string
+	}{
+		{
+			name:   "Test TLS Response Carries HSTS",
+			config: config.InfraConfig{HSTSMaxAge: 365 * 24 * time.Hour},
+			tls:    true,
+			want:   "max-age=31536000",
+		},
+		{
+			name:   "Test TLS Response Includes Subdomains",
+			config: config.InfraConfig{HSTSMaxAge: time.Hour, HSTSIncludeSubdomains: true},
+			tls:    true,
+			want:   "max-age=3600; includeSubDomains",
+		},
+		{
+			name:   "Test Plain HTTP Response Has No HSTS",
+			config: config.InfraConfig{HSTSMaxAge: time.Hour},
+		},
+		{
+			name:      "Test Forwarded HTTPS Behind Proxy Carries HSTS",
+			config:    config.InfraConfig{HSTSMaxAge: time.Hour, BehindTLSProxy: true},
+			forwarded: "https",
+			want:      "max-age=3600",
+		},
+		{
+			name:      "Test Forwarded HTTPS Without Proxy Has No HSTS",
+			config:    config.InfraConfig{HSTSMaxAge: time.Hour},
+			forwarded: "https",
+		},
+		{
+			name:   "Test Zero Max Age Disables HSTS",
+			config: config.InfraConfig{},
+			tls:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := handlers.HSTSMiddleware(tt.config, ok)
+			var server *httptest.Server
+			if tt.tls {
+				server = httptest.NewTLSServer(handler)
+			} else {
+				server = httptest.NewServer(handler)
+			}
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/health", nil)
+			if err != nil {
+				t.Fatalf("http.NewRequest() error = %v", err)
+			}
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.forwarded)
+			}
+			resp, err := server.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request error = %v", err)
+			}
+			resp.Body.Close()
+
+			if got := resp.Header.Get(handlers.HSTS_HEADER); got != tt.want {
+				t.Errorf("%s = %q, want %q", handlers.HSTS_HEADER, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfraConfig_TLSConfig_MinVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		minVersion    uint16
+		clientVersion uint16
+		wantErr       bool
+	}{
+		{name: "Test TLS 1.2 Client Accepted At Minimum 1.2", minVersion: tls.VersionTLS12, clientVersion: tls.VersionTLS12},
+		{name: "Test TLS 1.2 Client Rejected At Minimum 1.3", minVersion: tls.VersionTLS13, clientVersion: tls.VersionTLS12, wantErr: true},
+		{name: "Test TLS 1.3 Client Accepted At Minimum 1.3", minVersion: tls.VersionTLS13, clientVersion: tls.VersionTLS13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			server.TLS = config.InfraConfig{TLSMinVersion: tt.minVersion}.TLSConfig()
+			// The rejected handshake is expected, keep it out of the test output
+			server.Config.ErrorLog = log.New(io.Discard, "", 0)
+			server.StartTLS()
+			defer server.Close()
+
+			client := server.Client()
+			client.Transport.(*http.Transport).TLSClientConfig.MaxVersion = tt.clientVersion
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("request error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
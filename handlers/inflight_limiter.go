@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// InflightLimiter caps the number of requests being processed at once across all clients
+type InflightLimiter struct {
+	slots      chan struct{}
+	retryAfter time.Duration
+	logger     *zap.Logger
+}
+
+// NewInflightLimiter creates a limiter allowing maxInflight concurrent requests
+func NewInflightLimiter(maxInflight uint, retryAfter time.Duration, logger *zap.Logger) *InflightLimiter {
+	return &InflightLimiter{
+		slots:      make(chan struct{}, maxInflight),
+		retryAfter: retryAfter,
+		logger:     logger,
+	}
+}
+
+// Middleware rejects requests with 503 instead of queuing them when every slot is taken
+func (il *InflightLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case il.slots <- struct{}{}:
+			defer func() { <-il.slots }()
+			next.ServeHTTP(w, r)
+		default:
+			il.logger.Warn("too many inflight requests", zap.Int("maxInflight", cap(il.slots)))
+			w.Header().Set("Retry-After", strconv.Itoa(int(il.retryAfter.Seconds())))
+			http.Error(w, "Server busy", http.StatusServiceUnavailable)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"address-validator/handlers"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestInflightLimiter_Middleware(t *testing.T) {
+	const (
+		maxInflight = 5
+		requests    = 50
+	)
+
+	var inflight, peak atomic.Int64
+	release := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inflight.Add(1)
+		for {
+			observed := peak.Load()
+			if current <= observed || peak.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		<-release
+		inflight.Add(-1)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	il := handlers.NewInflightLimiter(maxInflight, 2*time.Second, zap.NewNop())
+	h := il.Middleware(slow)
+
+	var wg sync.WaitGroup
+	recorders := make([]*httptest.ResponseRecorder, requests)
+	for i := range recorders {
+		recorders[i] = httptest.NewRecorder()
+		wg.Add(1)
+		go func(w *httptest.ResponseRecorder) {
+			defer wg.Done()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/validate", nil))
+		}(recorders[i])
+	}
+
+	// Let every request either take a slot or get rejected before releasing the slow ones
+	deadline := time.Now().Add(2 * time.Second)
+	for inflight.Load() < maxInflight && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	var ok, busy int
+	for _, w := range recorders {
+		switch w.Code {
+		case http.StatusOK:
+			ok++
+		case http.StatusServiceUnavailable:
+			busy++
+			if got := w.Header().Get("Retry-After"); got != "2" {
+				t.Errorf("Retry-After = %q, want %q", got, "2")
+			}
+		default:
+			t.Errorf("unexpected status %v", w.Code)
+		}
+	}
+
+	if got := peak.Load(); got > maxInflight {
+		t.Errorf("peak inflight = %v, want at most %v", got, maxInflight)
+	}
+	if ok == 0 || busy == 0 {
+		t.Errorf("got %d OK and %d busy responses, want both", ok, busy)
+	}
+	if ok+busy != requests {
+		t.Errorf("got %d responses, want %d", ok+busy, requests)
+	}
+}
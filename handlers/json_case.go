@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"address-validator/config"
+)
+
+// encodeJSON writes v followed by a newline, renaming object keys to the configured field case
+func encodeJSON(w io.Writer, v any, fieldCase string) error {
+	if fieldCase == config.JSON_CASE_SNAKE {
+		v = snakeCaseJSON{value: v}
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+// snakeCaseJSON marshals its value with every object key converted from camelCase to snake_case.
+// Key order is preserved so both encodings only differ in their key names.
+type snakeCaseJSON struct {
+	value any
+}
+
+// MarshalJSON implements json.Marshaler
+func (s snakeCaseJSON) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(s.value)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := writeSnakeCaseValue(dec, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeSnakeCaseValue copies the next JSON value from dec to buf, renaming object keys
+func writeSnakeCaseValue(dec *json.Decoder, buf *bytes.Buffer) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		scalar, err := json.Marshal(tok)
+		if err != nil {
+			return err
+		}
+		buf.Write(scalar)
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		buf.WriteByte('{')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := keyTok.(string)
+			if !ok {
+				return fmt.Errorf("unexpected object key %v", keyTok)
+			}
+			encodedKey, _ := json.Marshal(toSnakeCase(key))
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+			if err := writeSnakeCaseValue(dec, buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case '[':
+		buf.WriteByte('[')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeSnakeCaseValue(dec, buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	}
+
+	// Consume the closing delimiter
+	_, err = dec.Token()
+	return err
+}
+
+// toSnakeCase converts a camelCase name to snake_case
+func toSnakeCase(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// encodeJSONWithRaw writes the object v like encodeJSON with raw appended under "_raw".
+// The raw payload is spliced in as is so the field case never rewrites the provider's keys.
+func encodeJSONWithRaw(w io.Writer, v any, fieldCase string, raw json.RawMessage) error {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, v, fieldCase); err != nil {
+		return err
+	}
+
+	data := bytes.TrimRight(buf.Bytes(), "\n")
+	if len(data) < 2 || data[len(data)-1] != '}' {
+		return fmt.Errorf("cannot attach a raw response to %s", data)
+	}
+	data = data[:len(data)-1]
+	if len(data) > 1 {
+		data = append(data, ',')
+	}
+	data = append(data, `"_raw":`...)
+	data = append(data, raw...)
+	data = append(data, "}\n"...)
+
+	_, err := w.Write(data)
+	return err
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"address-validator/ports"
+)
+
+// MetricsHandler serves metrics in the Prometheus text exposition format
+type MetricsHandler struct {
+	collectors []ports.MetricsCollector
+}
+
+// NewMetricsHandler creates a new metrics handler
+func NewMetricsHandler(collectors ...ports.MetricsCollector) *MetricsHandler {
+	return &MetricsHandler{
+		collectors: collectors,
+	}
+}
+
+// ServeMetrics handles the metrics endpoint
+func (h *MetricsHandler) ServeMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	for _, collector := range h.collectors {
+		var previous string
+		for _, metric := range collector.CollectMetrics() {
+			// Labeled samples of a metric are grouped under a single HELP and TYPE
+			if metric.Name != previous {
+				fmt.Fprintf(w, "# HELP %s %s\n", metric.Name, metric.Help)
+				fmt.Fprintf(w, "# TYPE %s %s\n", metric.Name, metric.Type)
+				previous = metric.Name
+			}
+			fmt.Fprintf(w, "%s%s %s\n", metric.Name, formatLabels(metric.Labels), strconv.FormatFloat(metric.Value, 'g', -1, 64))
+		}
+	}
+}
+
+// formatLabels renders labels as {name="value",...} sorted by name, or nothing without labels
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + strconv.Quote(labels[name])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
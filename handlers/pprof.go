@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/http/pprof"
+
+	"address-validator/config"
+)
+
+// RegisterPprof registers the net/http/pprof handlers under /debug/pprof/ when the
+// environment is development or pprof is explicitly enabled. It reports whether the
+// routes were registered. Outside development a bearer token is required.
+func RegisterPprof(mux *http.ServeMux, config config.InfraConfig) bool {
+	if !pprofEnabled(config) {
+		return false
+	}
+
+	handle := func(pattern string, handler http.HandlerFunc) {
+		if config.PprofToken == "" {
+			mux.Handle(pattern, handler)
+			return
+		}
+		mux.Handle(pattern, requireBearerToken(config.PprofToken, handler))
+	}
+
+	handle("/debug/pprof/", pprof.Index)
+	handle("/debug/pprof/cmdline", pprof.Cmdline)
+	handle("/debug/pprof/profile", pprof.Profile)
+	handle("/debug/pprof/symbol", pprof.Symbol)
+	handle("/debug/pprof/trace", pprof.Trace)
+
+	return true
+}
+
+func pprofEnabled(cfg config.InfraConfig) bool {
+	if cfg.Environment == config.ENV_DEVELOPMENT {
+		return true
+	}
+	// Profiles expose internals, never serve them publicly without auth
+	return cfg.EnablePprof && cfg.PprofToken != ""
+}
+
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,77 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegisterPprof(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         config.InfraConfig
+		authorization  string
+		wantRegistered bool
+		wantStatus     int
+	}{
+		{
+			name:           "Test Production Does Not Register Routes",
+			config:         config.InfraConfig{Environment: config.ENV_PRODUCTION},
+			wantRegistered: false,
+			wantStatus:     http.StatusNotFound,
+		},
+		{
+			name:           "Test Production Enabled Without Token Does Not Register Routes",
+			config:         config.InfraConfig{Environment: config.ENV_PRODUCTION, EnablePprof: true},
+			wantRegistered: false,
+			wantStatus:     http.StatusNotFound,
+		},
+		{
+			name:           "Test Development Registers Routes",
+			config:         config.InfraConfig{Environment: config.ENV_DEVELOPMENT},
+			wantRegistered: true,
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:           "Test Enabled With Token Requires Authorization",
+			config:         config.InfraConfig{Environment: config.ENV_PRODUCTION, EnablePprof: true, PprofToken: "secret"},
+			wantRegistered: true,
+			wantStatus:     http.StatusUnauthorized,
+		},
+		{
+			name:           "Test Enabled With Wrong Token Is Unauthorized",
+			config:         config.InfraConfig{Environment: config.ENV_PRODUCTION, EnablePprof: true, PprofToken: "secret"},
+			authorization:  "Bearer wrong",
+			wantRegistered: true,
+			wantStatus:     http.StatusUnauthorized,
+		},
+		{
+			name:           "Test Enabled With Token Serves Profiles",
+			config:         config.InfraConfig{Environment: config.ENV_PRODUCTION, EnablePprof: true, PprofToken: "secret"},
+			authorization:  "Bearer secret",
+			wantRegistered: true,
+			wantStatus:     http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			if got := handlers.RegisterPprof(mux, tt.config); got != tt.wantRegistered {
+				t.Errorf("RegisterPprof() = %v, want %v", got, tt.wantRegistered)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("GET /debug/pprof/ status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -6,6 +6,27 @@ import (
 	"time"
 )
 
+// Limiter decides whether a request from a client is allowed
+type Limiter interface {
+	Allow(ip string) bool
+}
+
+// NewLimiter creates the rate limiter selected by the configured algorithm,
+// with a grace burst for new clients when one is configured
+func NewLimiter(cfg config.RateLimitConfig) Limiter {
+	var limiter Limiter
+	if cfg.Algorithm == config.RATE_LIMIT_SLIDING_COUNTER {
+		limiter = NewSlidingWindowRateLimiter(cfg)
+	} else {
+		limiter = NewRateLimiter(cfg)
+	}
+
+	if cfg.GraceBurst > 0 {
+		return NewGraceLimiter(limiter, cfg.GraceBurst, cfg.TimeWindow)
+	}
+	return limiter
+}
+
 // RateLimiter provides a simple rate limiting mechanism
 type RateLimiter struct {
 	requests    map[string][]time.Time
@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// rawValidator answers like a provider that hands its payload to a requested raw capture
+type rawValidator struct{}
+
+func (rawValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	ports.RawCaptureFrom(ctx).Set([]byte(`{"result": {"verdict": {"addressComplete": true}}}`))
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: address}, nil
+}
+
+func TestAddressHandler_ValidateAddress_RawResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		debugToken string
+		fieldCase  string
+		headers    [][2]string
+		wantRaw    bool
+	}{
+		{
+			name:       "Test Raw Omitted By Default",
+			debugToken: "secret",
+		},
+		{
+			name:       "Test Raw Included With Flag And Token",
+			debugToken: "secret",
+			headers:    [][2]string{{handlers.RAW_RESPONSE_HEADER, "true"}, {"Authorization", "Bearer secret"}},
+			wantRaw:    true,
+		},
+		{
+			name:       "Test Raw Keys Keep Provider Case With Snake Case Fields",
+			debugToken: "secret",
+			fieldCase:  config.JSON_CASE_SNAKE,
+			headers:    [][2]string{{handlers.RAW_RESPONSE_HEADER, "true"}, {"Authorization", "Bearer secret"}},
+			wantRaw:    true,
+		},
+		{
+			name:       "Test Raw Omitted With Wrong Token",
+			debugToken: "secret",
+			headers:    [][2]string{{handlers.RAW_RESPONSE_HEADER, "true"}, {"Authorization", "Bearer guess"}},
+		},
+		{
+			name:       "Test Raw Omitted Without Token",
+			debugToken: "secret",
+			headers:    [][2]string{{handlers.RAW_RESPONSE_HEADER, "true"}},
+		},
+		{
+			name:    "Test Raw Omitted When No Debug Token Is Configured",
+			headers: [][2]string{{handlers.RAW_RESPONSE_HEADER, "true"}, {"Authorization", "Bearer "}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(rawValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{DebugToken: tt.debugToken, JSONFieldCase: tt.fieldCase}, logger)
+
+			r := httptest.NewRequest(http.MethodGet, "/validate?address="+url.QueryEscape("123 Main St"), nil)
+			for _, pair := range tt.headers {
+				r.Header.Set(pair[0], pair[1])
+			}
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, http.StatusOK)
+			}
+			var got map[string]json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid response %q: %v", w.Body.String(), err)
+			}
+			raw, ok := got["_raw"]
+			if ok != tt.wantRaw {
+				t.Fatalf("AddressHandler.ValidateAddress() has _raw = %v, want %v", ok, tt.wantRaw)
+			}
+			if tt.wantRaw && string(raw) != `{"result": {"verdict": {"addressComplete": true}}}` {
+				t.Errorf("AddressHandler.ValidateAddress() _raw = %s", raw)
+			}
+			validKey := "isValid"
+			if tt.fieldCase == config.JSON_CASE_SNAKE {
+				validKey = "is_valid"
+			}
+			if _, ok := got[validKey]; !ok {
+				t.Errorf("AddressHandler.ValidateAddress() lost the result fields: %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"address-validator/ports"
+
+	"go.uber.org/zap"
+)
+
+// Readiness statuses
+const (
+	READY_STATUS_UP       = "up"
+	READY_STATUS_DOWN     = "down"
+	READY_STATUS_READY    = "ready"
+	READY_STATUS_DEGRADED = "degraded"
+	READY_STATUS_NOTREADY = "not_ready"
+	READY_STATUS_STARTING = "starting"
+)
+
+// readinessTimeout bounds how long the dependencies together may take to answer
+const readinessTimeout = 2 * time.Second
+
+// Dependency is a component readiness depends on. A non-critical dependency that is down
+// degrades the service but keeps it ready.
+type Dependency struct {
+	Name     string
+	Checker  ports.HealthChecker
+	Critical bool
+}
+
+// ReadinessResponse reports the overall readiness and the status of each dependency
+type ReadinessResponse struct {
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components"`
+}
+
+// ReadinessHandler serves readiness by checking every registered dependency
+type ReadinessHandler struct {
+	dependencies []Dependency
+	logger       *zap.Logger
+	// started is false until the startup check succeeds, readiness answers 503 meanwhile
+	started atomic.Bool
+}
+
+// NewReadinessHandler creates a new readiness handler, started unless WaitForStartup is called
+func NewReadinessHandler(logger *zap.Logger, dependencies ...Dependency) *ReadinessHandler {
+	h := &ReadinessHandler{
+		dependencies: dependencies,
+		logger:       logger,
+	}
+	h.started.Store(true)
+	return h
+}
+
+// WaitForStartup holds readiness at 503 until check succeeds, so no traffic is routed to the instance
+// before the provider is confirmed. The check runs in a goroutine, retried every interval until it
+// succeeds, ctx is done or maxAttempts failed, each attempt bounded by timeout. An instance whose
+// attempts all failed stays unready; 0 retries without limit.
+func (h *ReadinessHandler) WaitForStartup(ctx context.Context, check func(context.Context) error, interval time.Duration, timeout time.Duration, maxAttempts uint) {
+	h.started.Store(false)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for attempt := uint(1); ; attempt++ {
+			attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+			err := check(attemptCtx)
+			cancel()
+			if err == nil {
+				h.started.Store(true)
+				h.logger.Info("startup check succeeded, reporting ready")
+				return
+			}
+			if maxAttempts > 0 && attempt >= maxAttempts {
+				h.logger.Error("startup check failed, giving up and staying unready", zap.Uint("attempts", attempt), zap.Error(err))
+				return
+			}
+			h.logger.Warn("startup check failed, retrying", zap.Duration("interval", interval), zap.Error(err))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Started reports whether the startup check has succeeded
+func (h *ReadinessHandler) Started() bool {
+	return h.started.Load()
+}
+
+// ServeReady handles the readiness endpoint, answering 503 while starting or when a critical dependency is down
+func (h *ReadinessHandler) ServeReady(w http.ResponseWriter, r *http.Request) {
+	if !h.Started() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		if err := json.NewEncoder(w).Encode(ReadinessResponse{Status: READY_STATUS_STARTING, Components: map[string]string{}}); err != nil {
+			h.logger.Error("failed to encode response", zap.Error(err))
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	response := ReadinessResponse{
+		Status:     READY_STATUS_READY,
+		Components: make(map[string]string, len(h.dependencies)),
+	}
+	for _, dependency := range h.dependencies {
+		if err := dependency.Checker.CheckHealth(ctx); err != nil {
+			h.logger.Warn("dependency is down", zap.String("dependency", dependency.Name), zap.Bool("critical", dependency.Critical), zap.Error(err))
+			response.Components[dependency.Name] = READY_STATUS_DOWN
+			if dependency.Critical {
+				response.Status = READY_STATUS_NOTREADY
+			} else if response.Status == READY_STATUS_READY {
+				response.Status = READY_STATUS_DEGRADED
+			}
+			continue
+		}
+		response.Components[dependency.Name] = READY_STATUS_UP
+	}
+
+	status := http.StatusOK
+	if response.Status == READY_STATUS_NOTREADY {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", zap.Error(err))
+	}
+}
@@ -0,0 +1,179 @@
+package handlers_test
+
+import (
+	"address-validator/handlers"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// stubChecker reports err as its health
+type stubChecker struct {
+	err error
+}
+
+func (s stubChecker) CheckHealth(ctx context.Context) error {
+	return s.err
+}
+
+func TestReadinessHandler_ServeReady(t *testing.T) {
+	down := stubChecker{err: errors.New("connection refused")}
+
+	tests := []struct {
+		name         string
+		dependencies []handlers.Dependency
+		wantStatus   int
+		want         handlers.ReadinessResponse
+	}{
+		{
+			name: "Test All Dependencies Up Returns Ready",
+			dependencies: []handlers.Dependency{
+				{Name: "provider", Checker: stubChecker{}, Critical: true},
+				{Name: "cache", Checker: stubChecker{}},
+			},
+			wantStatus: http.StatusOK,
+			want: handlers.ReadinessResponse{
+				Status:     handlers.READY_STATUS_READY,
+				Components: map[string]string{"provider": handlers.READY_STATUS_UP, "cache": handlers.READY_STATUS_UP},
+			},
+		},
+		{
+			name: "Test Critical Dependency Down Returns Unavailable",
+			dependencies: []handlers.Dependency{
+				{Name: "provider", Checker: down, Critical: true},
+				{Name: "cache", Checker: stubChecker{}},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			want: handlers.ReadinessResponse{
+				Status:     handlers.READY_STATUS_NOTREADY,
+				Components: map[string]string{"provider": handlers.READY_STATUS_DOWN, "cache": handlers.READY_STATUS_UP},
+			},
+		},
+		{
+			name: "Test Non Critical Dependency Down Returns Degraded",
+			dependencies: []handlers.Dependency{
+				{Name: "provider", Checker: stubChecker{}, Critical: true},
+				{Name: "cache", Checker: down},
+			},
+			wantStatus: http.StatusOK,
+			want: handlers.ReadinessResponse{
+				Status:     handlers.READY_STATUS_DEGRADED,
+				Components: map[string]string{"provider": handlers.READY_STATUS_UP, "cache": handlers.READY_STATUS_DOWN},
+			},
+		},
+		{
+			name: "Test Critical Down Outranks Degraded",
+			dependencies: []handlers.Dependency{
+				{Name: "cache", Checker: down},
+				{Name: "provider", Checker: down, Critical: true},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			want: handlers.ReadinessResponse{
+				Status:     handlers.READY_STATUS_NOTREADY,
+				Components: map[string]string{"provider": handlers.READY_STATUS_DOWN, "cache": handlers.READY_STATUS_DOWN},
+			},
+		},
+		{
+			name:       "Test No Dependencies Returns Ready",
+			wantStatus: http.StatusOK,
+			want:       handlers.ReadinessResponse{Status: handlers.READY_STATUS_READY, Components: map[string]string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlers.NewReadinessHandler(zap.NewNop(), tt.dependencies...)
+
+			r := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			w := httptest.NewRecorder()
+			h.ServeReady(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("ReadinessHandler.ServeReady() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			var got handlers.ReadinessResponse
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadinessHandler.ServeReady() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadinessHandler_WaitForStartup(t *testing.T) {
+	attempts := make(chan struct{})
+	release := make(chan struct{})
+	check := func(ctx context.Context) error {
+		attempts <- struct{}{}
+		select {
+		case <-release:
+			return nil
+		default:
+			return errors.New("provider unreachable")
+		}
+	}
+
+	h := handlers.NewReadinessHandler(zap.NewNop(), handlers.Dependency{Name: "provider", Checker: stubChecker{}, Critical: true})
+	h.WaitForStartup(context.Background(), check, time.Millisecond, time.Second, 0)
+
+	// Not ready before the first check, nor after a failed one
+	for i := 0; i < 2; i++ {
+		if h.Started() {
+			t.Fatal("ReadinessHandler.Started() = true before the startup check succeeded")
+		}
+		w := httptest.NewRecorder()
+		h.ServeReady(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var got handlers.ReadinessResponse
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if w.Code != http.StatusServiceUnavailable || got.Status != handlers.READY_STATUS_STARTING {
+			t.Errorf("ReadinessHandler.ServeReady() = %v %q while starting, want %v %q", w.Code, got.Status, http.StatusServiceUnavailable, handlers.READY_STATUS_STARTING)
+		}
+		<-attempts
+	}
+
+	close(release)
+	<-attempts
+	deadline := time.Now().Add(time.Second)
+	for !h.Started() {
+		if time.Now().After(deadline) {
+			t.Fatal("ReadinessHandler.Started() = false after the startup check succeeded")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	w := httptest.NewRecorder()
+	h.ServeReady(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("ReadinessHandler.ServeReady() status = %v after startup, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestReadinessHandler_WaitForStartup_MaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	check := func(ctx context.Context) error {
+		attempts.Add(1)
+		return errors.New("provider unreachable")
+	}
+
+	h := handlers.NewReadinessHandler(zap.NewNop())
+	h.WaitForStartup(context.Background(), check, time.Millisecond, time.Second, 3)
+
+	// Well past three intervals the check has stopped and the handler stays unready
+	time.Sleep(100 * time.Millisecond)
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("startup check attempts = %d, want 3", got)
+	}
+	if h.Started() {
+		t.Error("ReadinessHandler.Started() = true after every startup check failed")
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"address-validator/audit"
+	"address-validator/config"
+	"address-validator/ports"
+
+	"go.uber.org/zap"
+)
+
+// Recoverer turns a panicking handler into a logged 500 response instead of a dropped connection
+type Recoverer struct {
+	reraise   bool
+	fieldCase string
+	panics    atomic.Uint64
+	logger    *zap.Logger
+}
+
+// NewRecoverer creates a recoverer. Panics are only re-raised in development with PanicReraise set.
+func NewRecoverer(infraConfig config.InfraConfig, logger *zap.Logger) *Recoverer {
+	return &Recoverer{
+		reraise:   infraConfig.PanicReraise && infraConfig.Environment == config.ENV_DEVELOPMENT,
+		fieldCase: infraConfig.JSONFieldCase,
+		logger:    logger,
+	}
+}
+
+// Middleware recovers panics of next, logging them with their stack and correlation ID and
+// answering with a 500 JSON error
+func (rc *Recoverer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// net/http aborts the response silently for this one, it is not a failure
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			rc.panics.Add(1)
+			// Deferred calls run before the stack unwinds, so the stack still shows where it panicked
+			rc.logger.Error("handler panicked",
+				zap.String("correlationId", audit.CorrelationID(r.Context())),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Any("panic", recovered),
+				zap.Stack("stack"),
+			)
+			if rc.reraise {
+				panic(recovered)
+			}
+
+			// Nothing can be done if the handler already wrote its headers, the status stays as sent
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			encodeJSON(w, ports.AddressValidationResult{
+				Error:     "Internal server error",
+				ErrorCode: ports.ERROR_CODE_INTERNAL,
+			}, rc.fieldCase)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CollectMetrics reports the recovered panics for the metrics endpoint
+func (rc *Recoverer) CollectMetrics() []ports.Metric {
+	return []ports.Metric{
+		{Name: "http_panics_total", Help: "Handler panics recovered and answered with a 500.", Type: ports.METRIC_COUNTER, Value: float64(rc.panics.Load())},
+	}
+}
@@ -0,0 +1,99 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// panickingHandler fails the way a nil map write in a future feature would
+func panickingHandler(w http.ResponseWriter, r *http.Request) {
+	var counts map[string]int
+	counts[r.URL.Path]++
+}
+
+func TestRecoverer_Middleware(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	recoverer := handlers.NewRecoverer(config.InfraConfig{Environment: config.ENV_PRODUCTION, PanicReraise: true}, zap.New(core))
+	handler := handlers.CorrelationMiddleware(recoverer.Middleware(http.HandlerFunc(panickingHandler)))
+
+	req := httptest.NewRequest(http.MethodGet, "/validate", nil)
+	req.Header.Set(handlers.CORRELATION_ID_HEADER, "req-123")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %v, want %v", rec.Code, http.StatusInternalServerError)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	var got ports.AddressValidationResult
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decoding response error = %v", err)
+	}
+	if got.ErrorCode != ports.ERROR_CODE_INTERNAL {
+		t.Errorf("response ErrorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_INTERNAL)
+	}
+
+	entries := logs.FilterMessage("handler panicked").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d panics, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["correlationId"] != "req-123" {
+		t.Errorf("logged correlationId = %v, want req-123", fields["correlationId"])
+	}
+	if stack, _ := fields["stack"].(string); !strings.Contains(stack, "panickingHandler") {
+		t.Errorf("logged stack does not show the panicking handler:\n%s", stack)
+	}
+
+	metrics := recoverer.CollectMetrics()
+	if len(metrics) != 1 || metrics[0].Value != 1 {
+		t.Errorf("Recoverer.CollectMetrics() = %+v, want one recovered panic", metrics)
+	}
+}
+
+func TestRecoverer_Reraise(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      config.InfraConfig
+		wantReraise bool
+	}{
+		{
+			name:        "Test Development With Flag Re-raises",
+			config:      config.InfraConfig{Environment: config.ENV_DEVELOPMENT, PanicReraise: true},
+			wantReraise: true,
+		},
+		{
+			name:   "Test Development Without Flag Recovers",
+			config: config.InfraConfig{Environment: config.ENV_DEVELOPMENT},
+		},
+		{
+			name:   "Test Production With Flag Recovers",
+			config: config.InfraConfig{Environment: config.ENV_PRODUCTION, PanicReraise: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := handlers.NewRecoverer(tt.config, zap.NewNop()).Middleware(http.HandlerFunc(panickingHandler))
+
+			reraised := func() (reraised bool) {
+				defer func() { reraised = recover() != nil }()
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/validate", nil))
+				return false
+			}()
+			if reraised != tt.wantReraise {
+				t.Errorf("re-raised = %v, want %v", reraised, tt.wantReraise)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRegionCode is returned when a request names a region that is not a two letter CLDR code
+var ErrInvalidRegionCode = errors.New("regionCode must be a two letter CLDR region code")
+
+// requestRegionCode returns the region the address should be resolved in: the one the request names,
+// else the one inferred from Accept-Language when enabled, else "" for the configured country
+func (h *AddressHandler) requestRegionCode(r *http.Request, req AddressRequest) (string, error) {
+	if req.RegionCode != "" {
+		if !isRegionCode(req.RegionCode) {
+			return "", ErrInvalidRegionCode
+		}
+		return strings.ToUpper(req.RegionCode), nil
+	}
+	if !h.config.RegionFromLanguage {
+		return "", nil
+	}
+	return RegionFromAcceptLanguage(r.Header.Get("Accept-Language")), nil
+}
+
+// RegionFromAcceptLanguage returns the region of the most preferred language tag that names one,
+// e.g. GB for "fr;q=0.9, en-GB;q=0.8", or "" when no tag does
+func RegionFromAcceptLanguage(header string) string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, entry := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(entry, ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		// q=0 marks a language as not acceptable
+		if quality <= 0 {
+			continue
+		}
+		languages = append(languages, language{tag: strings.TrimSpace(tag), quality: quality})
+	}
+
+	// Stable so equally weighted tags keep the client's order
+	slices.SortStableFunc(languages, func(a, b language) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		}
+		return 0
+	})
+
+	for _, language := range languages {
+		// The region follows the language and an optional script, e.g. zh-Hant-TW
+		subtags := strings.FieldsFunc(language.tag, func(r rune) bool { return r == '-' || r == '_' })
+		for _, subtag := range subtags[min(1, len(subtags)):] {
+			if isRegionCode(subtag) {
+				return strings.ToUpper(subtag)
+			}
+		}
+	}
+
+	return ""
+}
+
+// isRegionCode reports whether code is two ASCII letters
+func isRegionCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range []byte(code) {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestRegionFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "Test Language With Region Returns Region", header: "en-GB", want: "GB"},
+		{name: "Test Lowercase Region Is Uppercased", header: "fr-ca", want: "CA"},
+		{name: "Test Script Subtag Is Skipped", header: "zh-Hant-TW", want: "TW"},
+		{name: "Test Underscore Separator Returns Region", header: "pt_BR", want: "BR"},
+		{name: "Test Highest Quality Tag Wins", header: "en-US;q=0.5, de-DE;q=0.9", want: "DE"},
+		{name: "Test Equal Quality Keeps Client Order", header: "es-MX, es-ES", want: "MX"},
+		{name: "Test Tag Without Region Is Skipped", header: "fr, en-AU;q=0.8", want: "AU"},
+		{name: "Test Numeric Region Is Skipped", header: "es-419, es-AR;q=0.5", want: "AR"},
+		{name: "Test Unacceptable Tag Is Skipped", header: "en-GB;q=0, en-IE;q=0.1", want: "IE"},
+		{name: "Test Language Only Returns Empty", header: "en", want: ""},
+		{name: "Test Wildcard Returns Empty", header: "*", want: ""},
+		{name: "Test Empty Header Returns Empty", header: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := handlers.RegionFromAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("RegionFromAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+// regionRecorder records the region code each call was asked to resolve in
+type regionRecorder struct {
+	regionCode *string
+}
+
+func (rr regionRecorder) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	*rr.regionCode = ports.RegionCode(ctx)
+	return ports.AddressValidationResult{IsValid: true, FormattedAddress: address}, nil
+}
+
+func TestAddressHandler_ValidateAddress_RegionCode(t *testing.T) {
+	tests := []struct {
+		name           string
+		infer          bool
+		regionCode     string
+		acceptLanguage string
+		wantStatus     int
+		want           string
+	}{
+		{name: "Test Inference Disabled Falls Back To Config", acceptLanguage: "en-GB", wantStatus: http.StatusOK, want: ""},
+		{name: "Test Inference Enabled Uses Accept Language", infer: true, acceptLanguage: "en-GB", wantStatus: http.StatusOK, want: "GB"},
+		{name: "Test Header Without Region Falls Back To Config", infer: true, acceptLanguage: "en", wantStatus: http.StatusOK, want: ""},
+		{name: "Test Request Region Wins Over Header", infer: true, regionCode: "ca", acceptLanguage: "en-GB", wantStatus: http.StatusOK, want: "CA"},
+		{name: "Test Invalid Request Region Returns 400", regionCode: "Canada", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			logger := zap.NewNop()
+			service := services.NewAddressService(regionRecorder{regionCode: &got}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{RegionFromLanguage: tt.infer}, logger)
+
+			query := url.Values{"address": {"10 Downing St, London"}}
+			if tt.regionCode != "" {
+				query.Set("regionCode", tt.regionCode)
+			}
+			r := httptest.NewRequest(http.MethodGet, "/validate?"+query.Encode(), nil)
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if got != tt.want {
+				t.Errorf("validator region code = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestAddressHandler_ValidateAddress_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantError string
+	}{
+		{
+			name:      "Test Unknown Field Is Named",
+			body:      `{"adress": "123 Main St"}`,
+			wantError: `Invalid request body: unknown field "adress"`,
+		},
+		{
+			name:      "Test Non String Address Names The Type",
+			body:      `{"address": 123}`,
+			wantError: `Invalid request body: field "address" must be a string, got number`,
+		},
+		{
+			name:      "Test Non Boolean Flag Names The Type",
+			body:      `{"address": "123 Main St", "checkGeofence": "yes"}`,
+			wantError: `Invalid request body: field "checkGeofence" must be a boolean, got string`,
+		},
+		{
+			name:      "Test Empty Body Is Reported",
+			body:      ``,
+			wantError: `Invalid request body: request body is empty`,
+		},
+		{
+			name:      "Test Truncated Body Is Reported",
+			body:      `{"address": "123 Main`,
+			wantError: `Invalid request body: request body is not valid JSON: it ends early`,
+		},
+		{
+			name:      "Test Non Object Body Is Reported",
+			body:      `["123 Main St"]`,
+			wantError: `Invalid request body: request body must be an object, got array`,
+		},
+		{
+			name:      "Test Trailing Value Is Reported",
+			body:      `{"address": "123 Main St"} {"address": "456 Main St"}`,
+			wantError: `Invalid request body: request body must contain a single JSON object`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(stubValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, config.InfraConfig{}, logger)
+
+			r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, http.StatusBadRequest)
+			}
+			var got ports.AddressValidationResult
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Error != tt.wantError {
+				t.Errorf("AddressHandler.ValidateAddress() error = %q, want %q", got.Error, tt.wantError)
+			}
+			if got.ErrorCode != ports.ERROR_CODE_BAD_REQUEST {
+				t.Errorf("AddressHandler.ValidateAddress() errorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_BAD_REQUEST)
+			}
+		})
+	}
+}
+
+func TestBatchHandler_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		body         string
+		streamDecode bool
+		wantError    string
+	}{
+		{
+			name:      "Test Batch Unknown Field Is Named",
+			path:      "/validate/batch",
+			body:      `{"adresses": ["1 Main St"]}`,
+			wantError: `Invalid request body: unknown field "adresses"`,
+		},
+		{
+			name:         "Test Streamed Batch Unknown Field Is Named",
+			path:         "/validate/batch",
+			body:         `{"addresses": ["1 Main St"], "source": "import"}`,
+			streamDecode: true,
+			wantError:    `Invalid request body: unknown field "source"`,
+		},
+		{
+			name:      "Test Batch Non Array Addresses Names The Type",
+			path:      "/validate/batch",
+			body:      `{"addresses": "1 Main St"}`,
+			wantError: `Invalid request body: field "addresses" must be an array, got string`,
+		},
+		{
+			name:         "Test Streamed Batch Non Array Addresses Names The Type",
+			path:         "/validate/batch",
+			body:         `{"addresses": "1 Main St"}`,
+			streamDecode: true,
+			wantError:    `Invalid request body: field "addresses" must be an array, got string`,
+		},
+		{
+			name:      "Test Batch Option Type Error Is Named",
+			path:      "/validate/batch",
+			body:      `{"addresses": ["1 Main St"], "checkGeofence": "yes"}`,
+			wantError: `Invalid request body: field "checkGeofence" must be a boolean, got string`,
+		},
+		{
+			name:         "Test Streamed Batch Option Type Error Is Named",
+			path:         "/validate/batch",
+			body:         `{"addresses": ["1 Main St"], "checkGeofence": "yes"}`,
+			streamDecode: true,
+			wantError:    `Invalid request body: field "checkGeofence" must be a boolean, got string`,
+		},
+		{
+			name:         "Test Streamed Batch Profile Type Error Is Named",
+			path:         "/validate/batch",
+			body:         `{"profile": 7, "addresses": ["1 Main St"]}`,
+			streamDecode: true,
+			wantError:    `Invalid request body: field "profile" must be a string, got number`,
+		},
+		{
+			name:         "Test Streamed Batch Non Object Body Is Reported",
+			path:         "/validate/batch",
+			body:         `["1 Main St"]`,
+			streamDecode: true,
+			wantError:    `Invalid request body: request body must be an object, got array`,
+		},
+		{
+			name:         "Test Streamed Batch Empty Body Is Reported",
+			path:         "/validate/batch",
+			body:         ``,
+			streamDecode: true,
+			wantError:    `Invalid request body: request body is empty`,
+		},
+		{
+			name:      "Test URL Unknown Field Is Named",
+			path:      "/validate/url",
+			body:      `{"url": "https://example.com/a.csv", "colum": "street"}`,
+			wantError: `Invalid request body: unknown field "colum"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(stubValidator{}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 100, TimeWindow: time.Minute})
+			batch := config.BatchConfig{MaxSize: 10, Concurrency: 3, StreamDecode: tt.streamDecode}
+			h := handlers.NewBatchHandler(service, rateLimiter, config.InfraConfig{}, batch, logger)
+
+			r := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			if tt.path == "/validate/url" {
+				h.ValidateURL(w, r)
+			} else {
+				h.ValidateBatch(w, r)
+			}
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("BatchHandler status = %v, want %v", w.Code, http.StatusBadRequest)
+			}
+			var got ports.AddressValidationResult
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Error != tt.wantError {
+				t.Errorf("BatchHandler error = %q, want %q", got.Error, tt.wantError)
+			}
+			if got.ErrorCode != ports.ERROR_CODE_BAD_REQUEST {
+				t.Errorf("BatchHandler errorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_BAD_REQUEST)
+			}
+		})
+	}
+}
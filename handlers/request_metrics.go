@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"address-validator/ports"
+)
+
+// OTHER_ROUTE is the route label of every path that is not a known route
+const OTHER_ROUTE = "other"
+
+// metricRoutes are the route templates used as metric labels, paths are never labeled raw
+// so scanners probing random URLs cannot grow the number of series
+var metricRoutes = []string{"/validate", "/validate/batch", "/validate/url", "/service-area", "/metrics", "/health", "/readyz"}
+
+// metricRoutePrefixes label every path under a subtree with the subtree
+var metricRoutePrefixes = []string{"/debug/pprof/"}
+
+// routeLabel returns the route template of the request for metric labels, or OTHER_ROUTE
+func routeLabel(r *http.Request) string {
+	path := r.URL.Path
+	if slices.Contains(metricRoutes, path) {
+		return path
+	}
+	for _, prefix := range metricRoutePrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return prefix
+		}
+	}
+	return OTHER_ROUTE
+}
+
+// requestKey identifies a request series by route template and status class
+type requestKey struct {
+	route  string
+	status string
+}
+
+// RequestMetrics counts requests by route and status class, e.g. 2xx
+type RequestMetrics struct {
+	counts map[requestKey]uint64
+	mu     sync.Mutex
+}
+
+// NewRequestMetrics creates an empty request counter
+func NewRequestMetrics() *RequestMetrics {
+	return &RequestMetrics{
+		counts: make(map[requestKey]uint64),
+	}
+}
+
+// Middleware counts every request once its response status is known
+func (rm *RequestMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		key := requestKey{route: routeLabel(r), status: statusClass(recorder.status)}
+		rm.mu.Lock()
+		rm.counts[key]++
+		rm.mu.Unlock()
+	})
+}
+
+// CollectMetrics reports the request counts for the metrics endpoint, sorted by route and status
+func (rm *RequestMetrics) CollectMetrics() []ports.Metric {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	metrics := make([]ports.Metric, 0, len(rm.counts))
+	for key, count := range rm.counts {
+		metrics = append(metrics, ports.Metric{
+			Name:   "http_requests_total",
+			Help:   "HTTP requests by route template and status class.",
+			Type:   ports.METRIC_COUNTER,
+			Labels: map[string]string{"route": key.route, "status": key.status},
+			Value:  float64(count),
+		})
+	}
+	slices.SortFunc(metrics, func(a, b ports.Metric) int {
+		if c := strings.Compare(a.Labels["route"], b.Labels["route"]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Labels["status"], b.Labels["status"])
+	})
+	return metrics
+}
+
+// statusClass buckets a status code by its first digit, e.g. 404 to 4xx
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
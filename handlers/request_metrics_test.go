@@ -0,0 +1,68 @@
+package handlers_test
+
+import (
+	"address-validator/handlers"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestMetrics_RouteLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		status    int
+		wantRoute string
+		wantClass string
+	}{
+		{name: "Test Known Route Maps To Its Template", path: "/validate?address=123+Main+St", status: http.StatusOK, wantRoute: "/validate", wantClass: "2xx"},
+		{name: "Test Nested Known Route Maps To Its Template", path: "/validate/batch", status: http.StatusBadRequest, wantRoute: "/validate/batch", wantClass: "4xx"},
+		{name: "Test Unknown Path Maps To Other", path: "/wp-admin/123-main-st", status: http.StatusNotFound, wantRoute: handlers.OTHER_ROUTE, wantClass: "4xx"},
+		{name: "Test Path Under Known Prefix Maps To The Prefix", path: "/debug/pprof/heap", status: http.StatusOK, wantRoute: "/debug/pprof/", wantClass: "2xx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestMetrics := handlers.NewRequestMetrics()
+			handler := requestMetrics.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			metrics := requestMetrics.CollectMetrics()
+			if len(metrics) != 1 {
+				t.Fatalf("RequestMetrics.CollectMetrics() = %+v, want a single series", metrics)
+			}
+			if got := metrics[0].Labels["route"]; got != tt.wantRoute {
+				t.Errorf("route label = %q, want %q", got, tt.wantRoute)
+			}
+			if got := metrics[0].Labels["status"]; got != tt.wantClass {
+				t.Errorf("status label = %q, want %q", got, tt.wantClass)
+			}
+		})
+	}
+}
+
+func TestMetricsHandler_LabeledSamples(t *testing.T) {
+	requestMetrics := handlers.NewRequestMetrics()
+	handler := requestMetrics.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, path := range []string{"/validate", "/validate", "/health", "/10.0.0.1"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	handlers.NewMetricsHandler(requestMetrics).ServeMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	want := `# HELP http_requests_total HTTP requests by route template and status class.
+# TYPE http_requests_total counter
+http_requests_total{route="/health",status="2xx"} 1
+http_requests_total{route="/validate",status="2xx"} 2
+http_requests_total{route="other",status="2xx"} 1
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("MetricsHandler.ServeMetrics() body =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Contains(rec.Body.String(), "10.0.0.1") {
+		t.Errorf("MetricsHandler.ServeMetrics() exposes a raw path as a label")
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"address-validator/ports"
+)
+
+// resultFields are the JSON names of the result fields in encoding order, the names fields can select
+var resultFields = jsonFieldNames(reflect.TypeFor[ports.AddressValidationResult]())
+
+// errorFields are kept in a projected result whenever they are set, so a failure is never silent
+var errorFields = []string{"error", "errorCode"}
+
+// jsonFieldNames returns the JSON object keys of the exported fields of struct type t
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// parseFields parses a comma-separated list of result fields, named in camelCase or snake_case.
+// It returns nil for an empty list, which selects every field.
+func parseFields(input string) ([]string, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, name := range strings.Split(input, ",") {
+		name = strings.TrimSpace(name)
+		index := slices.IndexFunc(resultFields, func(field string) bool {
+			return name == field || name == toSnakeCase(field)
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("unknown field %q in fields", name)
+		}
+		if !slices.Contains(fields, resultFields[index]) {
+			fields = append(fields, resultFields[index])
+		}
+	}
+	return fields, nil
+}
+
+// projectedResult marshals only the selected fields of a result, in their usual order.
+// Fields left out of the full result, e.g. an unchecked inRange, stay out of the projection.
+type projectedResult struct {
+	result ports.AddressValidationResult
+	fields []string
+}
+
+// projectResult returns the value to encode for result, itself when no fields are selected
+func projectResult(result ports.AddressValidationResult, fields []string) any {
+	if len(fields) == 0 {
+		return result
+	}
+	return projectedResult{result: result, fields: fields}
+}
+
+// MarshalJSON implements json.Marshaler
+func (p projectedResult) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(p.result)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, field := range resultFields {
+		value, ok := values[field]
+		if !ok {
+			continue
+		}
+		selected := slices.Contains(p.fields, field)
+		if !selected && !(slices.Contains(errorFields, field) && string(value) != `""`) {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAddressHandler_ValidateAddress_Fields(t *testing.T) {
+	inRange := true
+	distance := 1.2
+	result := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+		Latitude:         40.84,
+		Longitude:        -73.84,
+		InRange:          &inRange,
+		DistanceToCenter: &distance,
+	}
+
+	tests := []struct {
+		name       string
+		fields     string
+		fieldCase  string
+		wantStatus int
+		want       string
+	}{
+		{
+			name:       "Test Subset Returns Only Requested Fields",
+			fields:     "isValid,inRange",
+			wantStatus: http.StatusOK,
+			want:       `{"isValid":true,"inRange":true}`,
+		},
+		{
+			name:       "Test Subset Keeps Result Order",
+			fields:     " distanceToCenter , isValid ,isValid",
+			wantStatus: http.StatusOK,
+			want:       `{"isValid":true,"distanceToCenter":1.2}`,
+		},
+		{
+			name:       "Test Snake Case Names Select Fields",
+			fields:     "is_valid,in_range",
+			fieldCase:  config.JSON_CASE_SNAKE,
+			wantStatus: http.StatusOK,
+			want:       `{"is_valid":true,"in_range":true}`,
+		},
+		{
+			name:       "Test Unset Field Stays Omitted",
+			fields:     "isValid,driveMinutes",
+			wantStatus: http.StatusOK,
+			want:       `{"isValid":true}`,
+		},
+		{
+			name:       "Test Full Set Returns Every Field",
+			fields:     "isValid,formattedAddress,latitude,longitude,inRange,geofenceStatus,distanceToCenter,error",
+			wantStatus: http.StatusOK,
+			want:       `{"isValid":true,"formattedAddress":"123 Main St, Bronx, NY 10456, USA","latitude":40.84,"longitude":-73.84,"inRange":true,"geofenceStatus":"NOT_EVALUATED","distanceToCenter":1.2,"error":""}`,
+		},
+		{
+			name:       "Test No Fields Returns Every Field",
+			wantStatus: http.StatusOK,
+			want:       `{"isValid":true,"formattedAddress":"123 Main St, Bronx, NY 10456, USA","latitude":40.84,"longitude":-73.84,"inRange":true,"geofenceStatus":"NOT_EVALUATED","distanceToCenter":1.2,"error":""}`,
+		},
+		{
+			name:       "Test Unknown Field Returns 400",
+			fields:     "isValid,coordinates",
+			wantStatus: http.StatusBadRequest,
+			want:       `Invalid request: unknown field "coordinates" in fields`,
+		},
+		{
+			name:       "Test Empty Field Name Returns 400",
+			fields:     "isValid,,inRange",
+			wantStatus: http.StatusBadRequest,
+			want:       `Invalid request: unknown field "" in fields`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandlerWithConfig(result, config.InfraConfig{JSONFieldCase: tt.fieldCase})
+			query := url.Values{"address": {"123 Main St"}, "checkGeofence": {"false"}, "fields": {tt.fields}}
+			r := httptest.NewRequest(http.MethodGet, "/validate?"+query.Encode(), nil)
+			w := httptest.NewRecorder()
+			h.ValidateAddress(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("AddressHandler.ValidateAddress() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("AddressHandler.ValidateAddress() body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressHandler_ValidateAddress_FieldsKeepError(t *testing.T) {
+	result := ports.AddressValidationResult{IsValid: false, Error: "No validation result found.", ErrorCode: ports.ERROR_CODE_MISSING_COMPONENT}
+	h := newTestHandler(result)
+
+	r := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"address": "1 Nowhere Rd", "fields": "isValid,inRange"}`))
+	w := httptest.NewRecorder()
+	h.ValidateAddress(w, r)
+
+	want := `{"isValid":false,"error":"No validation result found.","errorCode":"MISSING_COMPONENT"}`
+	if got := strings.TrimSpace(w.Body.String()); got != want {
+		t.Errorf("AddressHandler.ValidateAddress() body = %s, want %s", got, want)
+	}
+}
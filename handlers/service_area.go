@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"address-validator/config"
+	"address-validator/geo"
+)
+
+// SERVICE_AREA_SEGMENTS is the number of polygon edges approximating the geofence circle
+const SERVICE_AREA_SEGMENTS = 64
+
+// serviceArea is a GeoJSON Feature holding the geofence polygon
+type serviceArea struct {
+	Type       string                `json:"type"`
+	Geometry   serviceAreaGeometry   `json:"geometry"`
+	Properties serviceAreaProperties `json:"properties"`
+}
+
+type serviceAreaGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+type serviceAreaProperties struct {
+	Center       [2]float64 `json:"center"`
+	Radius       float64    `json:"radius"`
+	Unit         string     `json:"unit"`
+	RadiusMeters float64    `json:"radiusMeters"`
+	Inclusive    bool       `json:"inclusive"`
+}
+
+// ServiceAreaHandler serves the geofence as GeoJSON for clients drawing the service area on a map
+type ServiceAreaHandler struct {
+	body atomic.Pointer[[]byte]
+}
+
+// NewServiceAreaHandler builds the GeoJSON once, Reload rebuilds it when the geofence changes
+func NewServiceAreaHandler(mapConfig config.MapConfig) *ServiceAreaHandler {
+	h := &ServiceAreaHandler{}
+	h.Reload(mapConfig)
+	return h
+}
+
+// Reload rebuilds the GeoJSON from the geofence in mapConfig
+func (h *ServiceAreaHandler) Reload(mapConfig config.MapConfig) {
+	radiusMeters := geo.ToMeters(mapConfig.MaxDistance, mapConfig.DistanceUnit)
+	area := serviceArea{
+		Type: "Feature",
+		Geometry: serviceAreaGeometry{
+			Type:        "Polygon",
+			Coordinates: [][][2]float64{geo.Circle(mapConfig.CenterLat, mapConfig.CenterLng, radiusMeters, SERVICE_AREA_SEGMENTS)},
+		},
+		Properties: serviceAreaProperties{
+			Center:       [2]float64{mapConfig.CenterLng, mapConfig.CenterLat},
+			Radius:       mapConfig.MaxDistance,
+			Unit:         mapConfig.DistanceUnit,
+			RadiusMeters: radiusMeters,
+			Inclusive:    mapConfig.GeofenceInclusive,
+		},
+	}
+
+	// Plain numbers and strings always marshal
+	body, _ := json.Marshal(area)
+	h.body.Store(&body)
+}
+
+// ServeServiceArea handles the service area endpoint
+func (h *ServiceAreaHandler) ServeServiceArea(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write(*h.body.Load())
+}
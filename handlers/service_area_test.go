@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/geo"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServiceAreaHandler_ServeServiceArea(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     config.MapConfig
+		wantMeters float64
+	}{
+		{
+			name:       "Test Miles Radius Returns Circle Polygon",
+			config:     config.MapConfig{MaxDistance: 2, DistanceUnit: ports.DISTANCE_MILES, CenterLat: 40.8313747, CenterLng: -73.8272283, GeofenceInclusive: true},
+			wantMeters: 2 * geo.MetersPerMile,
+		},
+		{
+			name:       "Test Kilometer Radius Returns Circle Polygon",
+			config:     config.MapConfig{MaxDistance: 5, DistanceUnit: ports.DISTANCE_KILOMETER, CenterLat: 51.5072, CenterLng: -0.1276},
+			wantMeters: 5000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlers.NewServiceAreaHandler(tt.config)
+
+			w := httptest.NewRecorder()
+			h.ServeServiceArea(w, httptest.NewRequest(http.MethodGet, "/service-area", nil))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("ServeServiceArea() status = %v, want %v", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/geo+json" {
+				t.Errorf("ServeServiceArea() Content-Type = %q, want application/geo+json", got)
+			}
+
+			var feature struct {
+				Type     string `json:"type"`
+				Geometry struct {
+					Type        string         `json:"type"`
+					Coordinates [][][2]float64 `json:"coordinates"`
+				} `json:"geometry"`
+				Properties struct {
+					RadiusMeters float64 `json:"radiusMeters"`
+				} `json:"properties"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &feature); err != nil {
+				t.Fatalf("decoding GeoJSON error = %v", err)
+			}
+			if feature.Type != "Feature" || feature.Geometry.Type != "Polygon" || len(feature.Geometry.Coordinates) != 1 {
+				t.Fatalf("ServeServiceArea() = %s, want a Feature with a single ring Polygon", w.Body.String())
+			}
+
+			// A valid linear ring is closed, has at least 4 positions and runs counterclockwise
+			ring := feature.Geometry.Coordinates[0]
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				t.Fatalf("ServeServiceArea() ring of %d points is not a closed linear ring", len(ring))
+			}
+			var area float64
+			for i := 0; i < len(ring)-1; i++ {
+				area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+			}
+			if area <= 0 {
+				t.Errorf("ServeServiceArea() ring is clockwise, want counterclockwise")
+			}
+
+			if math.Abs(feature.Properties.RadiusMeters-tt.wantMeters) > 1e-6 {
+				t.Errorf("ServeServiceArea() radiusMeters = %v, want %v", feature.Properties.RadiusMeters, tt.wantMeters)
+			}
+			for _, point := range ring {
+				distance := geo.HaversineMeters(point[1], point[0], tt.config.CenterLat, tt.config.CenterLng)
+				if math.Abs(distance-tt.wantMeters) > 0.001*tt.wantMeters {
+					t.Errorf("ServeServiceArea() point %v is %v m from center, want about %v", point, distance, tt.wantMeters)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceAreaHandler_ServeServiceArea_MethodNotAllowed(t *testing.T) {
+	h := handlers.NewServiceAreaHandler(config.MapConfig{MaxDistance: 2, DistanceUnit: ports.DISTANCE_MILES})
+
+	w := httptest.NewRecorder()
+	h.ServeServiceArea(w, httptest.NewRequest(http.MethodPost, "/service-area", nil))
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("ServeServiceArea() status = %v, want %v", w.Code, http.StatusMethodNotAllowed)
+	}
+}
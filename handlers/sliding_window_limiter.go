@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"address-validator/config"
+	"sync"
+	"time"
+)
+
+// windowCounter holds the request counts for the current and previous fixed windows of one key
+type windowCounter struct {
+	windowStart time.Time
+	current     uint
+	previous    uint
+}
+
+// SlidingWindowRateLimiter approximates a sliding window by weighting the previous window's count
+// by how much of it still overlaps the sliding window. Memory is bounded to one counter per key.
+type SlidingWindowRateLimiter struct {
+	counters    map[string]*windowCounter
+	maxRequests uint
+	timeWindow  time.Duration
+	mu          sync.Mutex
+}
+
+// NewSlidingWindowRateLimiter creates a new sliding window counter rate limiter
+func NewSlidingWindowRateLimiter(config config.RateLimitConfig) *SlidingWindowRateLimiter {
+	return &SlidingWindowRateLimiter{
+		counters:    make(map[string]*windowCounter),
+		maxRequests: config.MaxRequests,
+		timeWindow:  config.TimeWindow,
+	}
+}
+
+// Allow checks if a request is allowed based on the rate limit
+func (rl *SlidingWindowRateLimiter) Allow(ip string) bool {
+	return rl.AllowAt(ip, time.Now())
+}
+
+// AllowAt checks if a request arriving at now is allowed based on the rate limit
+func (rl *SlidingWindowRateLimiter) AllowAt(ip string, now time.Time) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	windowStart := now.Truncate(rl.timeWindow)
+
+	counter, ok := rl.counters[ip]
+	if !ok {
+		counter = &windowCounter{windowStart: windowStart}
+		rl.counters[ip] = counter
+	}
+
+	// Roll the windows forward, dropping counts older than the previous window
+	switch elapsed := windowStart.Sub(counter.windowStart); {
+	case elapsed == rl.timeWindow:
+		counter.previous = counter.current
+		counter.current = 0
+		counter.windowStart = windowStart
+	case elapsed > rl.timeWindow:
+		counter.previous = 0
+		counter.current = 0
+		counter.windowStart = windowStart
+	}
+
+	// Weight the previous window by the share of it still inside the sliding window
+	overlap := 1 - float64(now.Sub(windowStart))/float64(rl.timeWindow)
+	estimate := float64(counter.previous)*overlap + float64(counter.current)
+
+	// Check if rate limit is exceeded
+	if estimate >= float64(rl.maxRequests) {
+		return false
+	}
+
+	counter.current++
+	return true
+}
@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// windowStart is aligned to a minute so window boundaries are predictable
+var windowStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestSlidingWindowRateLimiter_AllowAt(t *testing.T) {
+	type request struct {
+		ip     string
+		offset time.Duration
+		want   bool
+	}
+
+	// seedEvenly spreads 10 allowed requests across the first window, one every 6 seconds
+	seedEvenly := func() []request {
+		var requests []request
+		for i := 0; i < 10; i++ {
+			requests = append(requests, request{ip: "1.1.1.1", offset: time.Duration(3+6*i) * time.Second, want: true})
+		}
+		return requests
+	}
+
+	tests := []struct {
+		name     string
+		requests []request
+	}{
+		{
+			name: "Test Requests Over Max In One Window Are Denied",
+			requests: append(seedEvenly(),
+				request{ip: "1.1.1.1", offset: 59 * time.Second, want: false},
+			),
+		},
+		{
+			name: "Test Window Boundary Still Counts Previous Window",
+			requests: append(seedEvenly(),
+				request{ip: "1.1.1.1", offset: 60 * time.Second, want: false},
+			),
+		},
+		{
+			// A true sliding window over (30s, 90s] holds the 5 requests at 33s..57s, leaving room for 5 more
+			name: "Test Halfway Into Next Window Matches True Sliding Window",
+			requests: append(seedEvenly(),
+				request{ip: "1.1.1.1", offset: 90 * time.Second, want: true},
+				request{ip: "1.1.1.1", offset: 90 * time.Second, want: true},
+				request{ip: "1.1.1.1", offset: 90 * time.Second, want: true},
+				request{ip: "1.1.1.1", offset: 90 * time.Second, want: true},
+				request{ip: "1.1.1.1", offset: 90 * time.Second, want: true},
+				request{ip: "1.1.1.1", offset: 90 * time.Second, want: false},
+			),
+		},
+		{
+			name: "Test Previous Window Is Dropped After Two Windows",
+			requests: append(seedEvenly(),
+				request{ip: "1.1.1.1", offset: 120 * time.Second, want: true},
+			),
+		},
+		{
+			name: "Test Keys Are Limited Independently",
+			requests: append(seedEvenly(),
+				request{ip: "1.1.1.1", offset: 59 * time.Second, want: false},
+				request{ip: "2.2.2.2", offset: 59 * time.Second, want: true},
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := handlers.NewSlidingWindowRateLimiter(config.RateLimitConfig{
+				MaxRequests: 10,
+				TimeWindow:  60 * time.Second,
+			})
+			for i, r := range tt.requests {
+				if got := rl.AllowAt(r.ip, windowStart.Add(r.offset)); got != r.want {
+					t.Errorf("request %d: SlidingWindowRateLimiter.AllowAt(%s, +%v) = %v, want %v", i, r.ip, r.offset, got, r.want)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRateLimiter_Memory(b *testing.B) {
+	cfg := config.RateLimitConfig{
+		MaxRequests: 1000,
+		TimeWindow:  60 * time.Second,
+	}
+
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("10.0.0.%d", i)
+	}
+
+	limiters := map[string]handlers.Limiter{
+		config.RATE_LIMIT_SLIDING_LOG:     handlers.NewRateLimiter(cfg),
+		config.RATE_LIMIT_SLIDING_COUNTER: handlers.NewSlidingWindowRateLimiter(cfg),
+	}
+	for name, rl := range limiters {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rl.Allow(keys[i%len(keys)])
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"address-validator/config"
+	"address-validator/ports"
+)
+
+// TENANT_ID_HEADER names the tenant a request is made for
+const TENANT_ID_HEADER = "X-Tenant-ID"
+
+// OTHER_TENANT is the tenant of free-form IDs seen once the distinct tenant cap is reached
+const OTHER_TENANT = "other"
+
+// maxTenantIDLength bounds client supplied tenant IDs, they end up in rate limit keys and metric labels
+const maxTenantIDLength = 64
+
+// TenantResolver attributes requests to a tenant from the X-Tenant-ID header. With known tenants
+// configured only those are accepted, otherwise free-form IDs are accepted up to a number of distinct
+// tenants so clients cannot grow the rate limiter and metrics without bound.
+type TenantResolver struct {
+	known       []string
+	seen        map[string]struct{}
+	maxDistinct uint
+	counts      map[string]uint64
+	mu          sync.Mutex
+}
+
+// NewTenantResolver creates a resolver for the configured tenants
+func NewTenantResolver(infraConfig config.InfraConfig) *TenantResolver {
+	return &TenantResolver{
+		known:       infraConfig.Tenants,
+		seen:        make(map[string]struct{}),
+		maxDistinct: infraConfig.MaxTenants,
+		counts:      make(map[string]uint64),
+	}
+}
+
+// Middleware attaches the tenant of the request to its context and counts the request for it
+func (tr *TenantResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := tr.Resolve(r.Header.Get(TENANT_ID_HEADER))
+		next.ServeHTTP(w, r.WithContext(ports.WithTenant(r.Context(), tenant)))
+	})
+}
+
+// Resolve returns the tenant of a header value: DEFAULT_TENANT for a missing, malformed or unknown ID,
+// OTHER_TENANT for a new free-form ID past the distinct tenant cap
+func (tr *TenantResolver) Resolve(id string) string {
+	tenant := tr.tenant(strings.TrimSpace(id))
+
+	tr.mu.Lock()
+	tr.counts[tenant]++
+	tr.mu.Unlock()
+
+	return tenant
+}
+
+// tenant maps a trimmed header value to its tenant, recording new free-form IDs under the cap
+func (tr *TenantResolver) tenant(id string) string {
+	if !validTenantID(id) {
+		return ports.DEFAULT_TENANT
+	}
+	if len(tr.known) > 0 {
+		if slices.Contains(tr.known, id) {
+			return id
+		}
+		return ports.DEFAULT_TENANT
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if _, ok := tr.seen[id]; ok {
+		return id
+	}
+	if uint(len(tr.seen)) >= tr.maxDistinct {
+		return OTHER_TENANT
+	}
+	tr.seen[id] = struct{}{}
+	return id
+}
+
+// validTenantID reports whether id is a non-empty, bounded string of letters, digits, '.', '_' and '-'
+func validTenantID(id string) bool {
+	if id == "" || len(id) > maxTenantIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// CollectMetrics reports the requests of each tenant for the metrics endpoint, sorted by tenant
+func (tr *TenantResolver) CollectMetrics() []ports.Metric {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	metrics := make([]ports.Metric, 0, len(tr.counts))
+	for tenant, count := range tr.counts {
+		metrics = append(metrics, ports.Metric{
+			Name:   "tenant_requests_total",
+			Help:   "HTTP requests by tenant, free-form tenants past TENANT_MAX_DISTINCT are labeled other.",
+			Type:   ports.METRIC_COUNTER,
+			Labels: map[string]string{"tenant": tenant},
+			Value:  float64(count),
+		})
+	}
+	slices.SortFunc(metrics, func(a, b ports.Metric) int {
+		return strings.Compare(a.Labels["tenant"], b.Labels["tenant"])
+	})
+	return metrics
+}
+
+// rateLimitKeys returns the keys a request counts against. The client IP is always the main key,
+// so a client cannot multiply its limits by rotating the unauthenticated X-Tenant-ID header.
+// A tenant on the TENANTS allowlist is also limited as a whole, across all of its clients.
+func rateLimitKeys(r *http.Request, clientIP string, tenants []string) []string {
+	keys := []string{clientIP}
+	if tenant := ports.Tenant(r.Context()); slices.Contains(tenants, tenant) {
+		keys = append(keys, "tenant|"+tenant)
+	}
+	return keys
+}
+
+// allowKeys checks the keys against limiter in order, stopping at the first one that is spent
+func allowKeys(limiter Limiter, keys []string) bool {
+	for _, key := range keys {
+		if !limiter.Allow(key) {
+			return false
+		}
+	}
+	return true
+}
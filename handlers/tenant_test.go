@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"address-validator/config"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"address-validator/services"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestTenantResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.InfraConfig
+		ids    []string
+		want   []string
+	}{
+		{
+			name:   "Test Missing ID Is Anonymous",
+			config: config.InfraConfig{MaxTenants: 10},
+			ids:    []string{"", "  "},
+			want:   []string{ports.DEFAULT_TENANT, ports.DEFAULT_TENANT},
+		},
+		{
+			name:   "Test Malformed ID Is Anonymous",
+			config: config.InfraConfig{MaxTenants: 10},
+			ids:    []string{"acme corp", "acme/1", strings.Repeat("a", 65)},
+			want:   []string{ports.DEFAULT_TENANT, ports.DEFAULT_TENANT, ports.DEFAULT_TENANT},
+		},
+		{
+			name:   "Test Known Tenants Are Kept And Unknown Ones Are Anonymous",
+			config: config.InfraConfig{Tenants: []string{"acme", "globex"}, MaxTenants: 10},
+			ids:    []string{"acme", " globex ", "initech"},
+			want:   []string{"acme", "globex", ports.DEFAULT_TENANT},
+		},
+		{
+			name:   "Test Free-Form Tenants Past The Cap Are Other",
+			config: config.InfraConfig{MaxTenants: 2},
+			ids:    []string{"acme", "globex", "initech", "acme", "umbrella"},
+			want:   []string{"acme", "globex", handlers.OTHER_TENANT, "acme", handlers.OTHER_TENANT},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := handlers.NewTenantResolver(tt.config)
+			var got []string
+			for _, id := range tt.ids {
+				got = append(got, resolver.Resolve(id))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TenantResolver.Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantResolver_CollectMetrics(t *testing.T) {
+	resolver := handlers.NewTenantResolver(config.InfraConfig{MaxTenants: 1})
+	for _, id := range []string{"acme", "acme", "globex", "initech", ""} {
+		resolver.Resolve(id)
+	}
+
+	got := map[string]float64{}
+	for _, metric := range resolver.CollectMetrics() {
+		got[metric.Labels["tenant"]] = metric.Value
+	}
+	want := map[string]float64{"acme": 2, handlers.OTHER_TENANT: 2, ports.DEFAULT_TENANT: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TenantResolver.CollectMetrics() = %v, want %v", got, want)
+	}
+}
+
+func TestTenantResolver_Middleware_RateLimit(t *testing.T) {
+	type request struct {
+		tenant     string
+		remoteAddr string
+		wantStatus int
+	}
+
+	tests := []struct {
+		name     string
+		config   config.InfraConfig
+		requests []request
+	}{
+		{
+			name:   "Test Rotating Free-Form Tenants Share The Client Limit",
+			config: config.InfraConfig{MaxTenants: 10},
+			requests: []request{
+				{tenant: "acme", wantStatus: http.StatusOK},
+				{tenant: "globex", wantStatus: http.StatusTooManyRequests},
+				{tenant: "initech", wantStatus: http.StatusTooManyRequests},
+				{wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name:   "Test Unknown Tenants Share The Client Limit",
+			config: config.InfraConfig{Tenants: []string{"acme"}, MaxTenants: 10},
+			requests: []request{
+				{tenant: "initech", wantStatus: http.StatusOK},
+				{wantStatus: http.StatusTooManyRequests},
+				{tenant: "acme", wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name:   "Test Known Tenants Are Limited Across Their Clients",
+			config: config.InfraConfig{Tenants: []string{"acme"}, MaxTenants: 10},
+			requests: []request{
+				{tenant: "acme", remoteAddr: "192.0.2.1:1234", wantStatus: http.StatusOK},
+				{tenant: "acme", remoteAddr: "192.0.2.2:1234", wantStatus: http.StatusTooManyRequests},
+				{remoteAddr: "192.0.2.3:1234", wantStatus: http.StatusOK},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			service := services.NewAddressService(stubValidator{result: ports.AddressValidationResult{IsValid: true}}, logger, config.MapConfig{}, config.ValidationConfig{})
+			rateLimiter := handlers.NewRateLimiter(config.RateLimitConfig{MaxRequests: 1, TimeWindow: time.Minute})
+			h := handlers.NewAddressHandler(service, rateLimiter, tt.config, logger)
+			handler := handlers.NewTenantResolver(tt.config).Middleware(http.HandlerFunc(h.ValidateAddress))
+
+			for i, req := range tt.requests {
+				r := httptest.NewRequest(http.MethodGet, "/validate?address="+url.QueryEscape("1 Main St"), nil)
+				if req.tenant != "" {
+					r.Header.Set(handlers.TENANT_ID_HEADER, req.tenant)
+				}
+				if req.remoteAddr != "" {
+					r.RemoteAddr = req.remoteAddr
+				}
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, r)
+
+				if w.Code != req.wantStatus {
+					t.Errorf("request %d for tenant %q status = %v, want %v", i, req.tenant, w.Code, req.wantStatus)
+				}
+			}
+		})
+	}
+}
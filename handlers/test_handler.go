@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+
+	"go.uber.org/zap"
+)
+
+// NewTestHandler wires the validation routes in front of validator with development defaults,
+// for integration tests that exercise the HTTP layer without the environment or a provider.
+// HTTPS is not required, the rate limit is generous and the geofence is centered on the Bronx.
+func NewTestHandler(validator ports.AddressValidator) http.Handler {
+	logger := zap.NewNop()
+	mapConfig := config.MapConfig{
+		Provider:          ports.PROVIDER_MOCK,
+		MaxDistance:       2,
+		GeofenceInclusive: true,
+		DistanceUnit:      ports.DISTANCE_MILES,
+		CenterLat:         40.8313747,
+		CenterLng:         -73.8272283,
+		Country:           "us",
+	}
+	infraConfig := config.InfraConfig{
+		Environment:       config.ENV_DEVELOPMENT,
+		JSONFieldCase:     config.JSON_CASE_CAMEL,
+		MaxInflight:       100,
+		MaxRequestTimeout: 10 * time.Second,
+	}
+
+	service := services.NewAddressService(validator, logger, mapConfig, config.ValidationConfig{})
+	rateLimiter := NewLimiter(config.RateLimitConfig{MaxRequests: 1000, TimeWindow: time.Minute})
+	addressHandler := NewAddressHandler(service, rateLimiter, infraConfig, logger)
+	batchHandler := NewBatchHandler(service, rateLimiter, infraConfig, config.BatchConfig{MaxSize: 25, Concurrency: 4}, logger)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", addressHandler.ValidateAddress)
+	mux.HandleFunc("/validate/batch", batchHandler.ValidateBatch)
+	return CorrelationMiddleware(mux)
+}
@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"address-validator/adapters"
+	"address-validator/handlers"
+	"address-validator/ports"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestNewTestHandler(t *testing.T) {
+	validator := adapters.NewMockValidator([]adapters.MockFixture{
+		{
+			Match: "123 Main St",
+			Result: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+				Latitude:         40.8350,
+				Longitude:        -73.8300,
+			},
+		},
+	}, zap.NewNop())
+	server := httptest.NewServer(handlers.NewTestHandler(validator))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		request       func() (*http.Response, error)
+		wantStatus    int
+		wantValid     bool
+		wantFormatted string
+	}{
+		{
+			name: "Test GET Validates Through The HTTP Layer",
+			request: func() (*http.Response, error) {
+				return http.Get(server.URL + "/validate?address=" + url.QueryEscape("123 Main St, Bronx, NY"))
+			},
+			wantStatus:    http.StatusOK,
+			wantValid:     true,
+			wantFormatted: "123 Main St, Bronx, NY 10456, USA",
+		},
+		{
+			name: "Test POST Validates Through The HTTP Layer",
+			request: func() (*http.Response, error) {
+				return http.Post(server.URL+"/validate", "application/json", strings.NewReader(`{"address": "123 Main St, Bronx, NY"}`))
+			},
+			wantStatus:    http.StatusOK,
+			wantValid:     true,
+			wantFormatted: "123 Main St, Bronx, NY 10456, USA",
+		},
+		{
+			name: "Test Empty Address Is Rejected",
+			request: func() (*http.Response, error) {
+				return http.Get(server.URL + "/validate?address=")
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.request()
+			if err != nil {
+				t.Fatalf("request error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+			if resp.Header.Get(handlers.CORRELATION_ID_HEADER) == "" {
+				t.Errorf("response has no %s header", handlers.CORRELATION_ID_HEADER)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got ports.AddressValidationResult
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("decoding response error = %v", err)
+			}
+			if got.IsValid != tt.wantValid || got.FormattedAddress != tt.wantFormatted {
+				t.Errorf("response = %+v, want isValid %v and formattedAddress %q", got, tt.wantValid, tt.wantFormatted)
+			}
+			if got.InRange == nil || !*got.InRange {
+				t.Errorf("response InRange = %v, want true", got.InRange)
+			}
+		})
+	}
+}
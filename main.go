@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,74 +11,76 @@ import (
 	"syscall"
 	"time"
 
-	"address-validator/adapters"
+	"address-validator/app"
 	"address-validator/config"
 	"address-validator/handlers"
-	"address-validator/services"
 
 	"go.uber.org/zap"
 )
 
 func main() {
+	address := flag.String("address", "", "validate a single address, print the JSON result and exit")
+	unit := flag.String("unit", "", "distance unit for the -address result, km or mi (default MAP_DISTANCE_UNIT)")
+	flag.Parse()
+
 	// Load configuration
 	env := config.LoadConfig()
 
-	infraConfig := env.NewInfraConfig()
-
-	// Initialize logger
-	loggerConfig := env.NewLoggerConfig(infraConfig.Environment)
-
-	logger, err := config.NewLogger(loggerConfig)
-	if err != nil {
-		log.Fatalf("Failed to implement logger: %v", err)
+	if *address != "" {
+		os.Exit(validateOnce(env, *address, *unit))
 	}
 
-	logger.Info("starting address validator service")
-
-	// Create Google Maps adapter
-	mapConfig := env.NewMapConfig(logger)
-
-	addressAdapter, err := adapters.NewGoogleAddressValidationAdapter(mapConfig, logger)
+	// Wire the logger, adapters, service and routes
+	application, err := app.NewApp(env)
 	if err != nil {
-		logger.Error("failed to create Google Address Validation adapter", zap.Error(err))
-		os.Exit(1)
+		log.Fatalf("Failed to start address validator: %v", err)
 	}
 
-	// Create address service
-	addressService := services.NewAddressService(addressAdapter, logger, mapConfig)
+	logger := application.Logger()
+	infraConfig := application.InfraConfig()
 
-	// Create address handler
-	rateLimitConfig := env.NewRateLimitConfig(logger)
-	rateLimiter := handlers.NewRateLimiter(rateLimitConfig)
-	addressHandler := handlers.NewAddressHandler(addressService, rateLimiter, infraConfig, logger)
-
-	// Set up HTTP server
-	mux := http.NewServeMux()
-	mux.HandleFunc("/validate", addressHandler.ValidateAddress)
+	logger.Info("starting address validator service")
 
-	// Add basic health check endpoint
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	// /readyz answers 503 until the provider is confirmed, the server itself starts right away
+	startup, stopStartup := context.WithCancel(context.Background())
+	defer stopStartup()
+	application.WaitForStartup(startup)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", infraConfig.Port),
-		Handler:      mux,
+		Handler:      application.Handler(),
 		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		WriteTimeout: handlers.WRITE_TIMEOUT,
 		IdleTimeout:  120 * time.Second,
+		TLSConfig:    infraConfig.TLSConfig(),
 	}
 
 	// Start server in a goroutine
 	go func() {
-		logger.Info("starting HTTP server", zap.Uint16("port", infraConfig.Port))
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Info("starting HTTP server", zap.Uint16("port", infraConfig.Port), zap.Bool("tls", infraConfig.TLSCertFile != ""))
+		var err error
+		if infraConfig.TLSCertFile != "" {
+			err = server.ListenAndServeTLS(infraConfig.TLSCertFile, infraConfig.TLSKeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			logger.Error("server error", zap.Error(err))
 			os.Exit(1)
 		}
 	}()
 
+	// SIGHUP reloads the geofence from the .env file and CONFIG_FILE without dropping connections
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := application.ReloadGeofence(env); err != nil {
+				logger.Error("geofence reload failed, keeping the current geofence", zap.Error(err))
+			}
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -96,3 +99,28 @@ func main() {
 
 	logger.Info("server exited properly")
 }
+
+// validateOnce runs a single validation without the HTTP server and returns the exit code
+func validateOnce(env config.Config, address string, unit string) int {
+	env.OneShot = true
+
+	// Keep stdout for the JSON result
+	for _, name := range []string{"OUTPUT_PATH", "AUDIT_OUTPUT_PATH"} {
+		if path := os.Getenv(name); path == "" || path == "stdout" {
+			os.Setenv(name, "stderr")
+		}
+	}
+
+	var opts []app.Option
+	if unit != "" {
+		opts = append(opts, app.WithDistanceUnit(unit))
+	}
+
+	application, err := app.NewApp(env, opts...)
+	if err != nil {
+		log.Printf("Failed to start address validator: %v", err)
+		return app.EXIT_ERROR
+	}
+
+	return application.ValidateOnce(context.Background(), address, os.Stdout)
+}
@@ -1,25 +1,227 @@
 package ports
 
 import (
+	"address-validator/geo"
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrQuotaExhausted is returned when the daily upstream geocode budget is spent
+var ErrQuotaExhausted = errors.New("daily geocode budget exhausted")
+
+// ErrUpstreamUnavailable is returned while the upstream geocoder is considered down
+var ErrUpstreamUnavailable = errors.New("upstream geocoder unavailable")
+
+// ErrProviderQuota is returned when the upstream provider rejects calls over its query limit
+var ErrProviderQuota = errors.New("provider query limit exceeded")
+
+// ErrProviderDenied is returned when the upstream provider denies the request, e.g. an invalid
+// API key or billing not enabled
+var ErrProviderDenied = errors.New("provider denied the request")
+
+// ErrProviderTimeout is returned when the upstream provider does not answer within its client timeout.
+// It is transient, unlike a deadline set by the caller.
+var ErrProviderTimeout = errors.New("provider timed out")
+
+// ErrNoRoute is returned when no drivable route connects two points, e.g. across water without a bridge.
+// It is a definitive answer, not an upstream failure.
+var ErrNoRoute = errors.New("no drivable route found")
+
+// ErrAddressNotFound is returned when the upstream has no result for the address.
+// It is a definitive answer, not an upstream failure.
+var ErrAddressNotFound = errors.New("no validation result found")
+
 // AddressValidationResult represents the result of address validation
 type AddressValidationResult struct {
-	IsValid          bool    `json:"isValid"`
-	FormattedAddress string  `json:"formattedAddress"`
-	Latitude         float64 `json:"latitude"`
-	Longitude        float64 `json:"longitude"`
-	InRange          bool    `json:"inRange"`
-	Error            string  `json:"error"`
+	IsValid          bool      `json:"isValid"`
+	FormattedAddress string    `json:"formattedAddress"`
+	RegionCode       string    `json:"regionCode,omitempty"`
+	CountryCode      string    `json:"countryCode,omitempty"`   // ISO 3166-1 alpha-2, e.g. US
+	AdminAreaCode    string    `json:"adminAreaCode,omitempty"` // ISO 3166-2 of the state or province, e.g. US-NY, when the provider abbreviates it
+	Latitude         float64   `json:"latitude"`
+	Longitude        float64   `json:"longitude"`
+	PlusCode         string    `json:"plusCode,omitempty"`
+	LocationType     string    `json:"locationType,omitempty"`
+	Types            []string  `json:"types,omitempty"`       // place types of the match, e.g. street_address or premise
+	StreetLevel      bool      `json:"streetLevel,omitempty"` // the match includes a street or premise component
+	LowConfidence    bool      `json:"lowConfidence,omitempty"`
+	Confidence       float64   `json:"confidence,omitempty"` // provider precision normalized to 0-1, omitted when the provider gives no signal
+	Viewport         *Viewport `json:"viewport,omitempty"`
+	UTM              *geo.UTM  `json:"utm,omitempty"`
+	TimeZone         string    `json:"timeZone,omitempty"` // IANA time zone, e.g. America/New_York, only looked up when enabled
+	InRange          *bool     `json:"inRange,omitempty"`
+	Ambiguous        bool      `json:"ambiguous,omitempty"` // an approximate location within the uncertainty margin of the boundary
+	GeofenceStatus   string    `json:"geofenceStatus,omitempty"`
+	InPremiumZone    *bool     `json:"inPremiumZone,omitempty"` // advisory, set when a premium zone is configured, never affects InRange
+	DistanceToCenter *float64  `json:"distanceToCenter,omitempty"`
+	DistanceMeters   *float64  `json:"distanceMeters,omitempty"`
+	DriveMinutes     *float64  `json:"driveMinutes,omitempty"` // drive time from the center, when the drive-time geofence decided
+	Elevation        *float64  `json:"elevation,omitempty"`    // meters above sea level, only looked up when an elevation limit is set
+	ReplacedInput    bool      `json:"replacedInput,omitempty"`
+	InputGranularity string    `json:"inputGranularity,omitempty"`
+	Granularity      string    `json:"validationGranularity,omitempty"` // the granularity Google validated the address to
+	InferenceGap     int       `json:"inferenceGap,omitempty"`          // levels Granularity is finer than InputGranularity
+	OriginalInput    string    `json:"originalInput,omitempty"`
+	SanitizedAddress string    `json:"sanitizedAddress,omitempty"` // the address sent to the geocoder, only with debug fields
+	Stale            bool      `json:"stale,omitempty"`
+	USPS             *USPSData `json:"usps,omitempty"`
+	AddressType      string    `json:"addressType,omitempty"` // PO box, commercial or residential, empty when unknown
+	Deliverable      *bool     `json:"deliverable,omitempty"` // mail can be delivered to the address, nil when the provider cannot tell
+	Provider         string    `json:"provider,omitempty"`
+	ElapsedMs        int       `json:"elapsedMs,omitempty"` // Provider and ElapsedMs are only set when debug fields are enabled
+	Error            string    `json:"error"`
+	ErrorCode        string    `json:"errorCode,omitempty"`
+	Suggestions      []string  `json:"suggestions,omitempty"` // actionable hints for fixing an invalid address
+
+	// Components are the parts of the address the provider returned, in address order
+	Components []AddressComponent `json:"components,omitempty"`
+}
+
+// Viewport is the rectangle recommended for framing a location on a map
+type Viewport struct {
+	NE LatLng `json:"ne"`
+	SW LatLng `json:"sw"`
+}
+
+// LatLng is a point in degrees
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// AddressComponent is one part of a validated address, e.g. a street_number or postal_code
+type AddressComponent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// USPSData holds the USPS CASS standardization of a US address
+type USPSData struct {
+	StandardizedAddress string `json:"standardizedAddress"`
+	DPVConfirmation     string `json:"dpvConfirmation"`
+	CarrierRoute        string `json:"carrierRoute"`
+}
+
+const (
+	ERROR_CODE_DISALLOWED_SCRIPT        = "DISALLOWED_SCRIPT"
+	ERROR_CODE_EMPTY_ADDRESS            = "EMPTY_ADDRESS"
+	ERROR_CODE_ADDRESS_TOO_LONG         = "ADDRESS_TOO_LONG"
+	ERROR_CODE_MALFORMED_ADDRESS        = "MALFORMED_ADDRESS"
+	ERROR_CODE_QUOTA_EXHAUSTED          = "QUOTA_EXHAUSTED"
+	ERROR_CODE_CONTEXT_CANCELLED        = "CONTEXT_CANCELLED"
+	ERROR_CODE_UPSTREAM_UNAVAILABLE     = "UPSTREAM_UNAVAILABLE"
+	ERROR_CODE_PROVIDER_QUOTA           = "PROVIDER_QUOTA"
+	ERROR_CODE_PROVIDER_DENIED          = "PROVIDER_DENIED"
+	ERROR_CODE_PROVIDER_TIMEOUT         = "PROVIDER_TIMEOUT"
+	ERROR_CODE_REQUEST_TIMEOUT          = "REQUEST_TIMEOUT"
+	ERROR_CODE_INVALID_COORDINATES      = "INVALID_COORDINATES"
+	ERROR_CODE_BLOCKED_REGION           = "BLOCKED_REGION"
+	ERROR_CODE_LOW_CONFIDENCE           = "LOW_CONFIDENCE"
+	ERROR_CODE_DISALLOWED_TYPE          = "DISALLOWED_ADDRESS_TYPE"
+	ERROR_CODE_MISSING_COMPONENT        = "MISSING_COMPONENT"
+	ERROR_CODE_NOT_DELIVERABLE          = "NOT_DELIVERABLE"
+	ERROR_CODE_INSUFFICIENT_GRANULARITY = "INSUFFICIENT_GRANULARITY"
+	ERROR_CODE_UNKNOWN_PROFILE          = "UNKNOWN_PROFILE"
+	ERROR_CODE_INTERNAL                 = "INTERNAL_ERROR"
+	ERROR_CODE_BAD_REQUEST              = "BAD_REQUEST"
+	ERROR_CODE_DAILY_QUOTA              = "DAILY_QUOTA_EXCEEDED"
+)
+
+// Address types, the kind of delivery point an address resolves to
+const (
+	ADDRESS_TYPE_PO_BOX      = "PO_BOX"
+	ADDRESS_TYPE_COMMERCIAL  = "COMMERCIAL"
+	ADDRESS_TYPE_RESIDENTIAL = "RESIDENTIAL"
+)
+
+// Geofence statuses, the geofence decision of a result including the cases InRange cannot express
+const (
+	GEOFENCE_IN_RANGE      = "IN_RANGE"
+	GEOFENCE_OUT_OF_RANGE  = "OUT_OF_RANGE"
+	GEOFENCE_NEAR_BOUNDARY = "NEAR_BOUNDARY" // a precise location within the uncertainty margin of the boundary
+	GEOFENCE_AMBIGUOUS     = "AMBIGUOUS"     // an approximate location within the uncertainty margin of the boundary
+	GEOFENCE_NOT_EVALUATED = "NOT_EVALUATED" // the result is invalid or the geofence was skipped
+	GEOFENCE_TOO_HIGH      = "TOO_HIGH"      // within range but above the maximum elevation
+)
+
+// Validation granularities, how precisely the Address Validation API matched an address
+const (
+	GRANULARITY_SUB_PREMISE       = "SUB_PREMISE"
+	GRANULARITY_PREMISE           = "PREMISE"
+	GRANULARITY_PREMISE_PROXIMITY = "PREMISE_PROXIMITY"
+	GRANULARITY_BLOCK             = "BLOCK"
+	GRANULARITY_ROUTE             = "ROUTE"
+	GRANULARITY_OTHER             = "OTHER"
+)
+
+// GRANULARITIES are the validation granularities from the finest to the coarsest
+var GRANULARITIES = []string{
+	GRANULARITY_SUB_PREMISE, GRANULARITY_PREMISE, GRANULARITY_PREMISE_PROXIMITY,
+	GRANULARITY_BLOCK, GRANULARITY_ROUTE, GRANULARITY_OTHER,
 }
 
+// Location types, the precision of a geocode following the Geocoding API location_type scale
 const (
-	DISTANCE_KILOMETER = "km"
-	DISTANCE_MILES     = "mi"
+	LOCATION_TYPE_ROOFTOP            = "ROOFTOP"
+	LOCATION_TYPE_RANGE_INTERPOLATED = "RANGE_INTERPOLATED"
+	LOCATION_TYPE_GEOMETRIC_CENTER   = "GEOMETRIC_CENTER"
+	LOCATION_TYPE_APPROXIMATE        = "APPROXIMATE"
+)
+
+const (
+	DISTANCE_KILOMETER = geo.UnitKilometers
+	DISTANCE_MILES     = geo.UnitMiles
+)
+
+const (
+	PROVIDER_GOOGLE = "google"
+	PROVIDER_MOCK   = "mock"
+	PROVIDER_SMARTY = "smartystreets"
+	// PROVIDER_GEOCODING validates addresses with the Google Geocoding API, biased towards the geofence
+	PROVIDER_GEOCODING = "google_geocoding"
 )
 
 // AddressValidator defines the interface for address validation
 type AddressValidator interface {
 	ValidateAddress(ctx context.Context, address string) (AddressValidationResult, error)
 }
+
+// ReverseGeocoder defines the interface for resolving coordinates to a formatted address
+type ReverseGeocoder interface {
+	ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (AddressValidationResult, error)
+}
+
+// TravelTimeEstimator defines the interface for estimating the drive time between two points
+type TravelTimeEstimator interface {
+	DriveTime(ctx context.Context, originLat float64, originLng float64, destinationLat float64, destinationLng float64) (time.Duration, error)
+}
+
+// ElevationProvider defines the interface for looking up the elevation of a point in meters above sea level
+type ElevationProvider interface {
+	Elevation(ctx context.Context, latitude float64, longitude float64) (float64, error)
+}
+
+// TimeZoneProvider defines the interface for looking up the IANA time zone of a point
+type TimeZoneProvider interface {
+	TimeZone(ctx context.Context, latitude float64, longitude float64) (string, error)
+}
+
+// NamedProvider is implemented by validators and geocoders that can report which provider answers
+// their calls. Decorators report the provider of the component they wrap.
+type NamedProvider interface {
+	Provider() string
+}
+
+// ProviderOf returns the provider name of component, or "" when it does not report one
+func ProviderOf(component any) string {
+	if named, ok := component.(NamedProvider); ok {
+		return named.Provider()
+	}
+	return ""
+}
+
+// AddressNormalizer defines the interface for rewriting an address before it is validated
+type AddressNormalizer interface {
+	Normalize(address string, regionCode string) string
+}
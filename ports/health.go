@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// HealthChecker defines the interface for dependencies that can report whether they are usable
+type HealthChecker interface {
+	// CheckHealth returns nil when the dependency is up, or why it is down
+	CheckHealth(ctx context.Context) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import "context"
+
+type localityKey struct{}
+
+// WithLocality returns a context asking the validator to resolve addresses in the locality,
+// instead of its configured one
+func WithLocality(ctx context.Context, locality string) context.Context {
+	return context.WithValue(ctx, localityKey{}, locality)
+}
+
+// Locality returns the locality requested for the context, or "" when the configured one applies
+func Locality(ctx context.Context) string {
+	locality, _ := ctx.Value(localityKey{}).(string)
+	return locality
+}
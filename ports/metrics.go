@@ -0,0 +1,23 @@
+package ports
+
+// Metric types of the Prometheus text exposition format
+const (
+	METRIC_COUNTER = "counter"
+	METRIC_GAUGE   = "gauge"
+)
+
+// Metric is a single sample exposed on the metrics endpoint
+// Samples of one metric share its name, help and type and differ by labels.
+// Label values must come from a small fixed set, never from raw request data.
+type Metric struct {
+	Name   string
+	Help   string
+	Type   string
+	Labels map[string]string
+	Value  float64
+}
+
+// MetricsCollector defines the interface for components that expose metrics
+type MetricsCollector interface {
+	CollectMetrics() []Metric
+}
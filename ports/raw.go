@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// RawCapture collects the raw provider response of a call for debugging.
+// A nil RawCapture discards everything.
+type RawCapture struct {
+	mu   sync.Mutex
+	data json.RawMessage
+}
+
+type rawCaptureKey struct{}
+
+// WithRawCapture returns a context asking the provider to hand its raw response to capture
+func WithRawCapture(ctx context.Context, capture *RawCapture) context.Context {
+	return context.WithValue(ctx, rawCaptureKey{}, capture)
+}
+
+// RawCaptureFrom returns the capture of the context, or nil when the raw response was not asked for
+func RawCaptureFrom(ctx context.Context) *RawCapture {
+	capture, _ := ctx.Value(rawCaptureKey{}).(*RawCapture)
+	return capture
+}
+
+// Set stores the raw response, replacing an earlier one. Invalid JSON is ignored.
+func (c *RawCapture) Set(data []byte) {
+	if c == nil || !json.Valid(data) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data = append(json.RawMessage(nil), data...)
+}
+
+// Raw returns the captured response, or nil when the provider was not called
+func (c *RawCapture) Raw() json.RawMessage {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data
+}
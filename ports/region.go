@@ -0,0 +1,17 @@
+package ports
+
+import "context"
+
+type regionCodeKey struct{}
+
+// WithRegionCode returns a context asking the validator to resolve addresses in the CLDR region code,
+// instead of its configured country
+func WithRegionCode(ctx context.Context, regionCode string) context.Context {
+	return context.WithValue(ctx, regionCodeKey{}, regionCode)
+}
+
+// RegionCode returns the region code requested for the context, or "" when the configured country applies
+func RegionCode(ctx context.Context) string {
+	regionCode, _ := ctx.Value(regionCodeKey{}).(string)
+	return regionCode
+}
@@ -0,0 +1,21 @@
+package ports
+
+import "context"
+
+// DEFAULT_TENANT is the tenant of requests that do not name a known one
+const DEFAULT_TENANT = "anonymous"
+
+type tenantKey struct{}
+
+// WithTenant returns a context attributing the request to the tenant, for rate limits and metrics
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// Tenant returns the tenant of the context, or DEFAULT_TENANT when none was attached
+func Tenant(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok && tenant != "" {
+		return tenant
+	}
+	return DEFAULT_TENANT
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"address-validator/config"
+	"address-validator/ports"
+)
+
+// usAbbreviations maps lowercase USPS words to their standard abbreviations
+var usAbbreviations = map[string]string{
+	// Directionals
+	"north":     "N",
+	"south":     "S",
+	"east":      "E",
+	"west":      "W",
+	"northeast": "NE",
+	"northwest": "NW",
+	"southeast": "SE",
+	"southwest": "SW",
+
+	// Street suffixes
+	"avenue":     "Ave",
+	"boulevard":  "Blvd",
+	"circle":     "Cir",
+	"court":      "Ct",
+	"drive":      "Dr",
+	"expressway": "Expy",
+	"highway":    "Hwy",
+	"lane":       "Ln",
+	"parkway":    "Pkwy",
+	"place":      "Pl",
+	"road":       "Rd",
+	"square":     "Sq",
+	"street":     "St",
+	"terrace":    "Ter",
+
+	// Secondary unit designators
+	"apartment": "Apt",
+	"building":  "Bldg",
+	"floor":     "Fl",
+	"suite":     "Ste",
+	"unit":      "Unit",
+}
+
+// usFixedCase are the lowercase words title-casing writes in a fixed form, e.g. PO rather than Po
+var usFixedCase = map[string]string{
+	"po":  "PO",
+	"p.o": "P.O",
+	"rr":  "RR",
+	"hc":  "HC",
+	"apo": "APO",
+	"fpo": "FPO",
+	"dpo": "DPO",
+	"psc": "PSC",
+
+	// Directionals written abbreviated, NE is also Nebraska
+	"n":  "N",
+	"s":  "S",
+	"e":  "E",
+	"w":  "W",
+	"ne": "NE",
+	"nw": "NW",
+	"se": "SE",
+	"sw": "SW",
+}
+
+// usMinorWords stay lowercase inside a title-cased address, e.g. Avenue of the Americas
+var usMinorWords = map[string]bool{"of": true, "the": true, "and": true}
+
+// usStateCodes are the USPS state and territory codes, uppercased after the first comma
+var usStateCodes = map[string]bool{
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true, "DE": true,
+	"DC": true, "FL": true, "GA": true, "HI": true, "ID": true, "IL": true, "IN": true, "IA": true,
+	"KS": true, "KY": true, "LA": true, "ME": true, "MD": true, "MA": true, "MI": true, "MN": true,
+	"MS": true, "MO": true, "MT": true, "NE": true, "NV": true, "NH": true, "NJ": true, "NM": true,
+	"NY": true, "NC": true, "ND": true, "OH": true, "OK": true, "OR": true, "PA": true, "RI": true,
+	"SC": true, "SD": true, "TN": true, "TX": true, "UT": true, "VT": true, "VA": true, "WA": true,
+	"WV": true, "WI": true, "WY": true, "AS": true, "GU": true, "MP": true, "PR": true, "VI": true,
+}
+
+// ordinalPattern matches numbered street names such as 42nd, whose suffix stays lowercase
+var ordinalPattern = regexp.MustCompile(`^[0-9]+(st|nd|rd|th)$`)
+
+// USNormalizerOptions refine the US normalizer beyond abbreviations
+type USNormalizerOptions struct {
+	// TitleCase rewrites lowercase words, and every word of an all-uppercase address, in title case
+	TitleCase bool
+	// TrimPunctuation drops trailing periods from words, e.g. St. becomes St, and separators ending the address
+	TrimPunctuation bool
+}
+
+// NewUSAddressNormalizerFor creates the US address normalizer configured by the validation policy
+func NewUSAddressNormalizerFor(validationConfig config.ValidationConfig) *USAddressNormalizer {
+	return NewUSAddressNormalizer(USNormalizerOptions{
+		TitleCase:       validationConfig.NormalizeTitleCase,
+		TrimPunctuation: validationConfig.NormalizeTrimPunctuation,
+	})
+}
+
+// USAddressNormalizer abbreviates common US address words the way USPS does
+type USAddressNormalizer struct {
+	options USNormalizerOptions
+}
+
+// NewUSAddressNormalizer creates a new US address normalizer
+func NewUSAddressNormalizer(options USNormalizerOptions) *USAddressNormalizer {
+	return &USAddressNormalizer{options: options}
+}
+
+// Normalize abbreviates street suffixes, directionals and unit designators of US addresses,
+// then title-cases and trims punctuation when enabled. Addresses for other regions are returned unchanged.
+func (n *USAddressNormalizer) Normalize(address string, regionCode string) string {
+	if !strings.EqualFold(regionCode, "us") {
+		return address
+	}
+
+	// Casing of a mixed-case address is deliberate, only an all-uppercase one is recased whole
+	shouting := !strings.ContainsFunc(address, unicode.IsLower)
+	afterComma := false
+
+	words := strings.Split(address, " ")
+	for i, word := range words {
+		// Keep trailing punctuation such as the comma in "Street,"
+		trimmed := strings.TrimRight(word, ",.")
+		suffix := strings.TrimPrefix(word, trimmed)
+		// A period closing an abbreviation written with periods, e.g. P.O., is kept
+		if n.options.TrimPunctuation && !strings.Contains(trimmed, ".") {
+			suffix = strings.ReplaceAll(suffix, ".", "")
+		}
+
+		if abbreviation, ok := usAbbreviations[strings.ToLower(trimmed)]; ok {
+			trimmed = abbreviation
+		} else if n.options.TitleCase {
+			trimmed = usTitleCase(trimmed, i == 0, afterComma, shouting)
+		}
+		words[i] = trimmed + suffix
+		afterComma = afterComma || strings.Contains(suffix, ",")
+	}
+
+	normalized := strings.Join(words, " ")
+	if n.options.TrimPunctuation {
+		normalized = strings.TrimRight(normalized, ", ")
+	}
+	return normalized
+}
+
+// usTitleCase title-cases one word of a US address. Mixed-case words are kept as written,
+// and so are uppercase ones unless the whole address is uppercase.
+func usTitleCase(word string, first bool, afterComma bool, shouting bool) string {
+	lower := strings.ToLower(word)
+	if fixed, ok := usFixedCase[lower]; ok {
+		return fixed
+	}
+	if upper := strings.ToUpper(word); afterComma && usStateCodes[upper] {
+		return upper
+	}
+
+	hasLower := strings.ContainsFunc(word, unicode.IsLower)
+	hasUpper := strings.ContainsFunc(word, unicode.IsUpper)
+	switch {
+	case hasLower && hasUpper, hasUpper && !shouting:
+		return word
+	case strings.ContainsFunc(word, unicode.IsDigit):
+		// Unit numbers such as 4B are uppercase, ordinals such as 42nd are not
+		if ordinalPattern.MatchString(lower) {
+			return lower
+		}
+		return strings.ToUpper(word)
+	case !first && usMinorWords[lower]:
+		return lower
+	}
+
+	// Each part of a hyphenated name is capitalized, e.g. Wilkes-Barre
+	parts := strings.Split(lower, "-")
+	for i, part := range parts {
+		if r, size := utf8.DecodeRuneInString(part); size > 0 {
+			parts[i] = string(unicode.ToUpper(r)) + part[size:]
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Ensure the US normalizer satisfies the port
+var _ ports.AddressNormalizer = (*USAddressNormalizer)(nil)
@@ -0,0 +1,180 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestUSAddressNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		address    string
+		regionCode string
+		want       string
+	}{
+		{
+			name:       "Test Directional Suffix And Unit Are Abbreviated",
+			address:    "123 North Main Street Apartment 4",
+			regionCode: "us",
+			want:       "123 N Main St Apt 4",
+		},
+		{
+			name:       "Test Any Casing Is Abbreviated",
+			address:    "55 WEST 42nd street, suite 200, New York",
+			regionCode: "US",
+			want:       "55 W 42nd St, Ste 200, New York",
+		},
+		{
+			name:       "Test Already Abbreviated Is Unchanged",
+			address:    "123 N Main St Apt 4",
+			regionCode: "us",
+			want:       "123 N Main St Apt 4",
+		},
+		{
+			name:       "Test Non-US Region Is Left Alone",
+			address:    "10 Downing Street, London",
+			regionCode: "gb",
+			want:       "10 Downing Street, London",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := services.NewUSAddressNormalizer(services.USNormalizerOptions{})
+			if got := n.Normalize(tt.address, tt.regionCode); got != tt.want {
+				t.Errorf("USAddressNormalizer.Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUSAddressNormalizer_Normalize_CasingAndPunctuation(t *testing.T) {
+	both := services.USNormalizerOptions{TitleCase: true, TrimPunctuation: true}
+
+	tests := []struct {
+		name    string
+		address string
+		options services.USNormalizerOptions
+		want    string
+	}{
+		{
+			name:    "Test Lowercase Address Is Title Cased",
+			address: "123 main st., bronx, ny 10456",
+			options: both,
+			want:    "123 Main St, Bronx, NY 10456",
+		},
+		{
+			name:    "Test Abbreviated And Spelled Out Inputs Agree",
+			address: "123 Main Street.",
+			options: both,
+			want:    "123 Main St",
+		},
+		{
+			name:    "Test Uppercase Address Is Title Cased",
+			address: "55 WEST 42ND STREET APT 4B, NEW YORK, NY",
+			options: both,
+			want:    "55 W 42nd St Apt 4B, New York, NY",
+		},
+		{
+			name:    "Test PO Box Is Not Title Cased",
+			address: "po box 1234, bronx, ny",
+			options: both,
+			want:    "PO Box 1234, Bronx, NY",
+		},
+		{
+			name:    "Test PO Box With Periods Keeps Them",
+			address: "p.o. box 1234, bronx, ny",
+			options: both,
+			want:    "P.O. Box 1234, Bronx, NY",
+		},
+		{
+			name:    "Test Directionals Stay Uppercase",
+			address: "100 ne 5th ave, miami, fl",
+			options: both,
+			want:    "100 NE 5th Ave, Miami, FL",
+		},
+		{
+			name:    "Test Mixed Case Words Are Kept",
+			address: "1 McDonald ave, JFK Airport, queens, ny",
+			options: both,
+			want:    "1 McDonald Ave, JFK Airport, Queens, NY",
+		},
+		{
+			name:    "Test Minor And Hyphenated Words",
+			address: "1211 avenue of the americas, wilkes-barre, pa",
+			options: both,
+			want:    "1211 Ave of the Americas, Wilkes-Barre, PA",
+		},
+		{
+			name:    "Test State Code Before Comma Is Title Cased",
+			address: "9 ct st, hartford, ct",
+			options: both,
+			want:    "9 Ct St, Hartford, CT",
+		},
+		{
+			name:    "Test Trailing Separators Are Dropped",
+			address: "123 Main St, Bronx,",
+			options: both,
+			want:    "123 Main St, Bronx",
+		},
+		{
+			name:    "Test Title Case Disabled Keeps Casing",
+			address: "123 main st., bronx",
+			options: services.USNormalizerOptions{TrimPunctuation: true},
+			want:    "123 main st, bronx",
+		},
+		{
+			name:    "Test Punctuation Trimming Disabled Keeps Periods",
+			address: "123 main street., bronx",
+			options: services.USNormalizerOptions{TitleCase: true},
+			want:    "123 Main St., Bronx",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := services.NewUSAddressNormalizer(tt.options)
+			if got := n.Normalize(tt.address, "us"); got != tt.want {
+				t.Errorf("USAddressNormalizer.Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingValidator records the address that reached the upstream validator
+type recordingValidator struct {
+	stubValidator
+	address string
+}
+
+func (r *recordingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	r.address = address
+	return r.stubValidator.ValidateAddress(ctx, address)
+}
+
+func TestAddressService_ValidateAddress_Normalization(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.ValidationConfig
+		want   string
+	}{
+		{name: "Test Disabled Sends Sanitized Address", want: "123 North Main Street Apartment 4"},
+		{name: "Test Enabled Sends Normalized Address", config: config.ValidationConfig{NormalizeUS: true}, want: "123 N Main St Apt 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &recordingValidator{}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{Country: "us"}, tt.config)
+
+			if _, err := s.ValidateAddress(context.Background(), "  123 North  Main Street Apartment 4 ", services.ValidationOptions{}); err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if validator.address != tt.want {
+				t.Errorf("upstream address = %q, want %q", validator.address, tt.want)
+			}
+		})
+	}
+}
@@ -3,123 +3,622 @@ package services
 import (
 	"context"
 	"errors"
-	"math"
+	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"address-validator/audit"
 	"address-validator/config"
+	"address-validator/geo"
+	"address-validator/logging"
 	"address-validator/ports"
+	"address-validator/timing"
 
 	"go.uber.org/zap"
 )
 
 // Common validation errors
 var (
-	ErrEmptyAddress      = errors.New("address is empty")
-	ErrSuspiciousPattern = errors.New("suspicious address detected")
-	ErrOutsideGeofence   = errors.New("address outside allowed geographic area")
+	ErrEmptyAddress            = errors.New("address is empty")
+	ErrAddressTooLong          = errors.New("address is too long")
+	ErrMalformedAddress        = errors.New("address contains invalid characters")
+	ErrSuspiciousPattern       = errors.New("suspicious address detected")
+	ErrOutsideGeofence         = errors.New("address outside allowed geographic area")
+	ErrDisallowedScript        = errors.New("address contains a disallowed script")
+	ErrContextCancelled        = errors.New("request cancelled before validation completed")
+	ErrRequestTimeout          = errors.New("request deadline exceeded before validation completed")
+	ErrInvalidCoordinates      = errors.New("coordinates are out of range")
+	ErrBlockedRegion           = errors.New("address is in a blocked region")
+	ErrLowConfidence           = errors.New("address only matched an approximate area")
+	ErrDisallowedType          = errors.New("address type is not accepted")
+	ErrMissingComponent        = errors.New("address is missing required components")
+	ErrNotDeliverable          = errors.New("address is not deliverable")
+	ErrInsufficientGranularity = errors.New("address was not validated precisely enough")
+	ErrUnknownProfile          = errors.New("unknown validation profile")
 )
 
-// earthRadiusKm is the radius of the Earth in kilometers
-const earthRadiusKm = 6371.0
-
-// earthRadiusMi is the radius of the Earth in miles
-const earthRadiusMi = 3958.8
-
 // AddressService handles address validation business logic
 type AddressService struct {
-	validator ports.AddressValidator
-	logger    *zap.Logger
-	config    config.MapConfig
+	validator      ports.AddressValidator
+	logger         *zap.Logger
+	config         config.MapConfig
+	geofence       atomic.Pointer[geofence]
+	validation     config.ValidationConfig
+	allowedScripts []*unicode.RangeTable
+	normalizer     ports.AddressNormalizer
+	reverse        ports.ReverseGeocoder
+	travel         ports.TravelTimeEstimator
+	elevation      ports.ElevationProvider
+	timeZone       ports.TimeZoneProvider
+	audit          *audit.Logger
+	profiles       map[string]ValidationProfile
+	defaultProfile string
+	rejections     rejectionCounter
 }
 
 // NewAddressService creates a new address service
-func NewAddressService(validator ports.AddressValidator, logger *zap.Logger, config config.MapConfig) *AddressService {
-	return &AddressService{
-		validator: validator,
-		logger:    logger,
-		config:    config,
+func NewAddressService(validator ports.AddressValidator, logger *zap.Logger, config config.MapConfig, validationConfig config.ValidationConfig) *AddressService {
+	var allowedScripts []*unicode.RangeTable
+	for _, name := range validationConfig.AllowedScripts {
+		if table, ok := unicode.Scripts[name]; ok {
+			allowedScripts = append(allowedScripts, table)
+		}
 	}
+
+	var normalizer ports.AddressNormalizer
+	if validationConfig.NormalizeUS {
+		normalizer = NewUSAddressNormalizerFor(validationConfig)
+	}
+
+	service := &AddressService{
+		validator:      validator,
+		logger:         logger,
+		config:         config,
+		validation:     validationConfig,
+		allowedScripts: allowedScripts,
+		normalizer:     normalizer,
+	}
+	service.geofence.Store(newGeofence(config))
+	return service
+}
+
+// SetNormalizer replaces the normalizer applied before validation, nil disables normalization
+func (s *AddressService) SetNormalizer(normalizer ports.AddressNormalizer) {
+	s.normalizer = normalizer
+}
+
+// SetReverseGeocoder sets the geocoder used to resolve submitted coordinates to an address.
+// Without one, coordinate submissions are only checked against the geofence.
+func (s *AddressService) SetReverseGeocoder(reverse ports.ReverseGeocoder) {
+	s.reverse = reverse
+}
+
+// SetTravelTimeEstimator sets the estimator of the drive-time geofence, used when MaxDriveTime is set.
+// Without one, or whenever it fails, the straight-line geofence applies.
+func (s *AddressService) SetTravelTimeEstimator(travel ports.TravelTimeEstimator) {
+	s.travel = travel
+}
+
+// SetElevationProvider sets the provider of the elevation limit, used when MaxElevation is set.
+// Without one, elevation is not checked.
+func (s *AddressService) SetElevationProvider(elevation ports.ElevationProvider) {
+	s.elevation = elevation
+}
+
+// SetTimeZoneProvider sets the provider of the result time zone, used when IncludeTimeZone is set.
+// Without one, no time zone is reported.
+func (s *AddressService) SetTimeZoneProvider(timeZone ports.TimeZoneProvider) {
+	s.timeZone = timeZone
+}
+
+// SetAuditLogger sets the audit trail every validation decision is recorded to, nil disables it
+func (s *AddressService) SetAuditLogger(auditLogger *audit.Logger) {
+	s.audit = auditLogger
+}
+
+// ValidationOptions holds per-request validation options, the zero value applies every check
+type ValidationOptions struct {
+	SkipGeofence bool
+	// Profile names the validation profile to apply, empty applies the default one
+	Profile string
 }
 
 // ValidateAddress validates an address
-func (s *AddressService) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+func (s *AddressService) ValidateAddress(ctx context.Context, address string, options ValidationOptions) (ports.AddressValidationResult, error) {
+	result, err := s.validateAddress(ctx, address, options)
+	if result.GeofenceStatus == "" {
+		result.GeofenceStatus = ports.GEOFENCE_NOT_EVALUATED
+	}
+	s.audit.Record(ctx, address, result, err)
+	return result, err
+}
+
+func (s *AddressService) validateAddress(ctx context.Context, address string, options ValidationOptions) (ports.AddressValidationResult, error) {
+	// Whitespace or punctuation alone has nothing to look up, answer before any other check
+	if !hasAddressContent(address) {
+		s.logger.Warn("empty address")
+		return s.reject(ports.ERROR_CODE_EMPTY_ADDRESS, ErrEmptyAddress)
+	}
+
+	profile, err := s.profile(options.Profile)
+	if err != nil {
+		return s.unknownProfile(err)
+	}
+
+	// Refuse what is not an address before spending an upstream call on it
+	if s.isTooLong(address) {
+		s.logger.Warn("address too long", zap.Int("length", utf8.RuneCountInString(address)))
+		return s.reject(ports.ERROR_CODE_ADDRESS_TOO_LONG, ErrAddressTooLong)
+	}
+	if isMalformed(address) {
+		s.logger.Warn("address contains invalid characters")
+		return s.reject(ports.ERROR_CODE_MALFORMED_ADDRESS, ErrMalformedAddress)
+	}
+
+	// Reject foreign scripts before sanitization strips them
+	if !s.isScriptAllowed(address) {
+		s.logger.Warn("address contains a disallowed script")
+		return s.reject(ports.ERROR_CODE_DISALLOWED_SCRIPT, ErrDisallowedScript)
+	}
+
+	// A request asking for a blocked region is refused without resolving the address
+	if regionCode := ports.RegionCode(ctx); s.isRegionBlocked(regionCode) {
+		s.rejections.add(ports.ERROR_CODE_BLOCKED_REGION)
+		return s.blocked(ports.AddressValidationResult{RegionCode: strings.ToUpper(regionCode)})
+	}
 
 	// Sanitize the address
+	stopSanitize := timing.Track(ctx, "sanitize")
 	cleanAddress := sanitizeAddress(address)
+	stopSanitize()
 
-	// Check if address is empty after sanitization
-	if cleanAddress == "" || cleanAddress == " " {
+	// Check if address is empty after sanitization, which strips letters outside ASCII
+	if !hasAddressContent(cleanAddress) {
 		s.logger.Warn("empty address after sanitization")
-		return ports.AddressValidationResult{
-			IsValid: false,
-			Error:   ErrEmptyAddress.Error(),
-		}, ErrEmptyAddress
+		return s.reject(ports.ERROR_CODE_EMPTY_ADDRESS, ErrEmptyAddress)
 	}
 
 	// If validation passes, delegate to the external validator
+	// Normalize the address to the form the upstream geocodes best
+	if s.normalizer != nil {
+		cleanAddress = s.normalizer.Normalize(cleanAddress, s.config.Country)
+		s.requestLogger(ctx).Debug("normalized address", zap.String("address", cleanAddress))
+	}
+
+	// Don't spend an upstream call on a request nobody is waiting for
+	if err := ctx.Err(); err != nil {
+		return s.cancelled(err)
+	}
+
+	stopGeocode := timing.Track(ctx, "geocode")
+	start := time.Now()
 	result, err := s.validator.ValidateAddress(ctx, cleanAddress)
+	elapsed := time.Since(start)
+	stopGeocode()
+
+	// A result returned after cancellation may be partial, so it is discarded
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return s.cancelled(ctxErr)
+	}
 	if err != nil {
 		return result, err
 	}
 
-	s.logger.Debug("Request Completed", zap.Any("result", result))
+	s.requestLogger(ctx).Debug("Request Completed", zap.Any("result", result))
 
-	// Check if the address is within the geofence
-	if result.IsValid {
-		distance := calculateDistance(
-			result.Latitude, result.Longitude,
-			s.config.CenterLat, s.config.CenterLng,
-			s.config.DistanceUnit,
-		)
-		s.logger.Debug("Checking Distance", zap.Float64("distance", distance))
+	if s.isRegionBlocked(result.RegionCode) {
+		return s.blocked(result)
+	}
+	s.checkConfidence(&result)
+	s.checkAddressType(&result)
+	s.checkGranularity(&result, profile.MinGranularity)
+	s.checkRequiredComponents(&result, profile.RequiredComponents)
+	s.checkDeliverable(&result, profile.RequireDeliverable)
+
+	// Flag results the provider rewrote so clients can ask the user to confirm
+	if result.IsValid && !result.ReplacedInput {
+		result.ReplacedInput = isInputReplaced(cleanAddress, result.FormattedAddress)
+	}
+	if result.ReplacedInput {
+		result.OriginalInput = address
+	}
+	// Show exactly what was geocoded next to what was submitted, to explain a changed address
+	if s.validation.DebugFields {
+		result.OriginalInput = address
+		result.SanitizedAddress = cleanAddress
+	}
 
-		// Check if the distance is less than or equal to the maximum allowed distance
-		result.InRange = distance <= s.config.MaxDistance
-		s.logger.Debug("Checking Distance", zap.Bool("inRange", result.InRange))
+	// Check if the address is within the geofence, unless the caller only wants normalization.
+	// Without coordinates the distance would be measured from 0,0, far out of any range.
+	// Either the request or its profile can turn the geofence off.
+	checkGeofence := !options.SkipGeofence && (profile.CheckGeofence == nil || *profile.CheckGeofence)
+	if result.IsValid && checkGeofence && hasLocation(result) {
+		s.applyGeofence(ctx, &result, result.LocationType == ports.LOCATION_TYPE_APPROXIMATE)
+	} else if result.IsValid && checkGeofence {
+		s.requestLogger(ctx).Warn("skipping geofence, the provider returned no coordinates")
+	}
 
+	// Encodings of 0,0 would place a result without coordinates in the Gulf of Guinea
+	if hasLocation(result) {
+		s.encodeLocation(&result)
+		s.applyTimeZone(ctx, &result)
 	}
+	s.addDebugFields(&result, s.validator, elapsed)
 
 	return result, nil
 }
 
-// calculateDistance calculates the distance between two points using the Haversine formula
-func calculateDistance(lat1, lng1, lat2, lng2 float64, unit string) float64 {
-	// Convert latitude and longitude from degrees to radians
-	lat1Rad := lat1 * (math.Pi / 180.0)
-	lng1Rad := lng1 * (math.Pi / 180.0)
-	lat2Rad := lat2 * (math.Pi / 180.0)
-	lng2Rad := lng2 * (math.Pi / 180.0)
+// ValidateCoordinates resolves already-geocoded coordinates to a formatted address and
+// checks them against the geofence. The submitted coordinates are kept in the result.
+func (s *AddressService) ValidateCoordinates(ctx context.Context, latitude float64, longitude float64, options ValidationOptions) (ports.AddressValidationResult, error) {
+	result, err := s.validateCoordinates(ctx, latitude, longitude, options)
+	if result.GeofenceStatus == "" {
+		result.GeofenceStatus = ports.GEOFENCE_NOT_EVALUATED
+	}
+	// Coordinates locate a person as precisely as an address, so they are hashed the same way
+	submitted := strconv.FormatFloat(latitude, 'f', -1, 64) + "," + strconv.FormatFloat(longitude, 'f', -1, 64)
+	s.audit.Record(ctx, submitted, result, err)
+	return result, err
+}
+
+func (s *AddressService) validateCoordinates(ctx context.Context, latitude float64, longitude float64, options ValidationOptions) (ports.AddressValidationResult, error) {
+	// Only the geofence setting of a profile applies, the coordinates are not validated as an address
+	profile, err := s.profile(options.Profile)
+	if err != nil {
+		return s.unknownProfile(err)
+	}
+
+	if !validCoordinates(latitude, longitude) {
+		s.logger.Warn("coordinates out of range", zap.Float64("latitude", latitude), zap.Float64("longitude", longitude))
+		return s.reject(ports.ERROR_CODE_INVALID_COORDINATES, ErrInvalidCoordinates)
+	}
+
+	result := ports.AddressValidationResult{IsValid: true}
+	var elapsed time.Duration
+	if s.reverse != nil {
+		if err := ctx.Err(); err != nil {
+			return s.cancelled(err)
+		}
+
+		stopGeocode := timing.Track(ctx, "geocode")
+		start := time.Now()
+		reversed, err := s.reverse.ReverseGeocode(ctx, latitude, longitude)
+		elapsed = time.Since(start)
+		stopGeocode()
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return s.cancelled(ctxErr)
+		}
+		if err != nil {
+			return reversed, err
+		}
+		if s.isRegionBlocked(reversed.RegionCode) {
+			return s.blocked(reversed)
+		}
+		s.checkConfidence(&reversed)
+		result = reversed
+	}
+
+	// The geofence applies to where the client says it is, not the matched address
+	result.Latitude = latitude
+	result.Longitude = longitude
+
+	// Submitted coordinates are exact, whatever the precision of the matched address
+	if result.IsValid && !options.SkipGeofence && (profile.CheckGeofence == nil || *profile.CheckGeofence) {
+		s.applyGeofence(ctx, &result, false)
+	}
+
+	s.encodeLocation(&result)
+	s.applyTimeZone(ctx, &result)
+	if s.reverse != nil {
+		s.addDebugFields(&result, s.reverse, elapsed)
+	}
+
+	return result, nil
+}
+
+// requestLogger returns the logger of the request, which is raised to debug for sampled requests
+func (s *AddressService) requestLogger(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// isRegionBlocked reports whether the resolved region code is in the blocked list
+func (s *AddressService) isRegionBlocked(regionCode string) bool {
+	return regionCode != "" && slices.Contains(s.validation.BlockedRegions, strings.ToUpper(regionCode))
+}
+
+// blocked refuses a result in a blocked region, keeping only the region for the caller.
+// The refusal is always logged for the compliance audit trail.
+func (s *AddressService) blocked(result ports.AddressValidationResult) (ports.AddressValidationResult, error) {
+	s.logger.Warn("address validation refused for blocked region",
+		zap.String("audit", "blocked_region"),
+		zap.String("regionCode", result.RegionCode),
+	)
+	return ports.AddressValidationResult{
+		IsValid:    false,
+		RegionCode: result.RegionCode,
+		Error:      ErrBlockedRegion.Error(),
+		ErrorCode:  ports.ERROR_CODE_BLOCKED_REGION,
+	}, ErrBlockedRegion
+}
+
+// checkConfidence flags valid results located only approximately with no street component,
+// typical of garbage input geocoded to the middle of a region, and rejects them if configured
+func (s *AddressService) checkConfidence(result *ports.AddressValidationResult) {
+	if s.validation.LowConfidencePolicy == config.LOW_CONFIDENCE_OFF || !result.IsValid {
+		return
+	}
+	if result.LocationType != ports.LOCATION_TYPE_APPROXIMATE || result.StreetLevel {
+		return
+	}
+
+	result.LowConfidence = true
+	if s.validation.LowConfidencePolicy == config.LOW_CONFIDENCE_REJECT {
+		s.logger.Info("rejecting low confidence result", zap.String("locationType", result.LocationType))
+		result.IsValid = false
+		result.Error = ErrLowConfidence.Error()
+		result.ErrorCode = ports.ERROR_CODE_LOW_CONFIDENCE
+	}
+}
+
+// checkAddressType rejects valid results whose address type is not in the allowed types.
+// Results of an unknown type are accepted, most regions have no type information.
+func (s *AddressService) checkAddressType(result *ports.AddressValidationResult) {
+	if len(s.validation.AllowedAddressTypes) == 0 || !result.IsValid || result.AddressType == "" {
+		return
+	}
+	if slices.Contains(s.validation.AllowedAddressTypes, result.AddressType) {
+		return
+	}
+
+	s.logger.Info("rejecting disallowed address type", zap.String("addressType", result.AddressType))
+	result.IsValid = false
+	result.Error = ErrDisallowedType.Error()
+	result.ErrorCode = ports.ERROR_CODE_DISALLOWED_TYPE
+}
+
+// applyElevation marks an in range result above the maximum elevation out of range. Results
+// already out of range are not looked up, and the verdict is kept when the lookup fails.
+func (s *AddressService) applyElevation(ctx context.Context, result *ports.AddressValidationResult, fence *geofence) {
+	if s.elevation == nil || fence.config.MaxElevation <= 0 || !*result.InRange {
+		return
+	}
+
+	stopElevation := timing.Track(ctx, "elevation")
+	elevation, err := s.elevation.Elevation(ctx, result.Latitude, result.Longitude)
+	stopElevation()
+	if err != nil {
+		s.requestLogger(ctx).Warn("elevation unavailable, keeping the geofence verdict", zap.Error(err))
+		return
+	}
+
+	result.Elevation = &elevation
+	if elevation <= fence.config.MaxElevation {
+		return
+	}
+	inRange := false
+	result.InRange = &inRange
+	result.Ambiguous = false
+	result.GeofenceStatus = ports.GEOFENCE_TOO_HIGH
+}
 
-	// Haversine formula
-	dLat := lat2Rad - lat1Rad
-	dLng := lng2Rad - lng1Rad
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+// checkRequiredComponents rejects valid results missing any of the required component types,
+// e.g. an address validated to its route without a street number
+func (s *AddressService) checkRequiredComponents(result *ports.AddressValidationResult, requiredComponents []string) {
+	if len(requiredComponents) == 0 || !result.IsValid {
+		return
+	}
 
-	// Calculate distance based on unit
-	var distance float64
-	if strings.ToLower(unit) == ports.DISTANCE_MILES {
-		distance = earthRadiusMi * c
-	} else {
-		// Default to kilometers
-		distance = earthRadiusKm * c
+	var missing []string
+	for _, required := range requiredComponents {
+		present := slices.ContainsFunc(result.Components, func(component ports.AddressComponent) bool {
+			return component.Type == required
+		})
+		if !present {
+			missing = append(missing, required)
+		}
 	}
+	if len(missing) == 0 {
+		return
+	}
+
+	s.logger.Info("rejecting address missing required components", zap.Strings("missing", missing))
+	result.IsValid = false
+	result.Error = ErrMissingComponent.Error() + ": " + strings.Join(missing, ", ")
+	result.ErrorCode = ports.ERROR_CODE_MISSING_COMPONENT
+}
+
+// checkDeliverable rejects valid results the provider reports as not deliverable when deliverability
+// is required. Results of unknown deliverability are accepted, like those of an unknown address type.
+func (s *AddressService) checkDeliverable(result *ports.AddressValidationResult, requireDeliverable bool) {
+	if !requireDeliverable || !result.IsValid || result.Deliverable == nil || *result.Deliverable {
+		return
+	}
+
+	s.logger.Info("rejecting undeliverable address")
+	result.IsValid = false
+	result.Error = ErrNotDeliverable.Error()
+	result.ErrorCode = ports.ERROR_CODE_NOT_DELIVERABLE
+}
+
+// applyGeofence sets the distance to the configured center, whether it is within range and the geofence status
+// The geofence is loaded once so a reload during the lookups below cannot mix two configurations.
+func (s *AddressService) applyGeofence(ctx context.Context, result *ports.AddressValidationResult, approximate bool) {
+	fence := s.geofence.Load()
+	distance := calculateDistance(fence.center, result.Latitude, result.Longitude, fence.config.DistanceUnit)
+	s.logger.Debug("Checking Distance", zap.Float64("distance", distance))
 
-	return distance
+	inRange := fence.within(distance)
+	result.InRange = &inRange
+	result.DistanceToCenter = &distance
+	distanceMeters := geo.ToMeters(distance, fence.config.DistanceUnit)
+	result.DistanceMeters = &distanceMeters
+	result.GeofenceStatus = fence.status(distance, inRange, approximate)
+	result.Ambiguous = result.GeofenceStatus == ports.GEOFENCE_AMBIGUOUS
+	s.applyDriveTime(ctx, result, fence)
+	s.applyElevation(ctx, result, fence)
+	// Advisory only, applied after every check so no verdict ever depends on it
+	result.InPremiumZone = fence.inPremiumZone(result.Latitude, result.Longitude)
+	s.logger.Debug("Checking Distance", zap.Bool("inRange", *result.InRange), zap.String("status", result.GeofenceStatus))
 }
 
-// cleaning up spaces and only allowing words, spaces, period, comma, and dash
+// applyDriveTime replaces the straight-line verdict with the drive time from the center when a
+// drive-time geofence is configured. A close address can be far by road, e.g. across a river
+// without a bridge, so the straight-line verdict is only kept when the estimate fails.
+func (s *AddressService) applyDriveTime(ctx context.Context, result *ports.AddressValidationResult, fence *geofence) {
+	if s.travel == nil || fence.config.MaxDriveTime <= 0 {
+		return
+	}
+
+	stopDriveTime := timing.Track(ctx, "drive_time")
+	driveTime, err := s.travel.DriveTime(ctx, fence.config.CenterLat, fence.config.CenterLng, result.Latitude, result.Longitude)
+	stopDriveTime()
+
+	var inRange bool
+	switch {
+	case errors.Is(err, ports.ErrNoRoute):
+		inRange = false
+	case err != nil:
+		s.requestLogger(ctx).Warn("drive time unavailable, falling back to straight-line distance", zap.Error(err))
+		return
+	default:
+		inRange = driveTime <= fence.config.MaxDriveTime
+		minutes := driveTime.Minutes()
+		result.DriveMinutes = &minutes
+	}
+
+	result.InRange = &inRange
+	// The uncertainty margin is a distance, it does not qualify a drive time
+	result.Ambiguous = false
+	result.GeofenceStatus = ports.GEOFENCE_OUT_OF_RANGE
+	if inRange {
+		result.GeofenceStatus = ports.GEOFENCE_IN_RANGE
+	}
+}
+
+// hasLocation reports whether a geocoded result carries coordinates, providers leave them at 0,0 when
+// they return none and no address lies there
+func hasLocation(result ports.AddressValidationResult) bool {
+	return result.Latitude != 0 || result.Longitude != 0
+}
+
+// validCoordinates reports whether latitude and longitude are finite and within range
+func validCoordinates(latitude float64, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+// cancelled builds the result for a request whose context ended before validation completed.
+// Running out of the request deadline is a timeout the client may retry with a longer one, while a
+// cancellation means the client went away, e.g. disconnected, and nobody reads the result.
+func (s *AddressService) cancelled(cause error) (ports.AddressValidationResult, error) {
+	if errors.Is(cause, context.DeadlineExceeded) {
+		s.logger.Warn("address validation timed out", zap.Error(cause))
+		return ports.AddressValidationResult{
+			IsValid:   false,
+			Error:     ErrRequestTimeout.Error(),
+			ErrorCode: ports.ERROR_CODE_REQUEST_TIMEOUT,
+		}, fmt.Errorf("%w: %w", ErrRequestTimeout, cause)
+	}
+
+	s.logger.Info("address validation cancelled by client", zap.Error(cause))
+	return ports.AddressValidationResult{
+		IsValid:   false,
+		Error:     ErrContextCancelled.Error(),
+		ErrorCode: ports.ERROR_CODE_CONTEXT_CANCELLED,
+	}, fmt.Errorf("%w: %w", ErrContextCancelled, cause)
+}
+
+// encodeLocation adds the enabled alternative location encodings to a valid result
+func (s *AddressService) encodeLocation(result *ports.AddressValidationResult) {
+	if !s.validation.IncludePlusCode || !result.IsValid {
+		result.PlusCode = ""
+	} else if result.PlusCode == "" {
+		// Prefer the provider's plus code and only compute one when it was not returned
+		result.PlusCode = geo.PlusCode(result.Latitude, result.Longitude)
+	}
+
+	if s.validation.IncludeUTM && result.IsValid {
+		if utm, err := geo.ToUTM(result.Latitude, result.Longitude); err == nil {
+			result.UTM = &utm
+		} else {
+			s.logger.Debug("skipping UTM encoding", zap.Error(err))
+		}
+	}
+}
+
+// applyTimeZone reports the time zone of a valid result when enabled, a failed lookup only omits it
+func (s *AddressService) applyTimeZone(ctx context.Context, result *ports.AddressValidationResult) {
+	if s.timeZone == nil || !s.validation.IncludeTimeZone || !result.IsValid {
+		return
+	}
+
+	stopTimeZone := timing.Track(ctx, "time_zone")
+	timeZone, err := s.timeZone.TimeZone(ctx, result.Latitude, result.Longitude)
+	stopTimeZone()
+	if err != nil {
+		s.requestLogger(ctx).Warn("time zone unavailable", zap.Error(err))
+		return
+	}
+	result.TimeZone = timeZone
+}
+
+// addDebugFields reports the provider that answered and how long the upstream call took, when enabled.
+// Elapsed time is rounded up so an answered call never reports 0 ms.
+func (s *AddressService) addDebugFields(result *ports.AddressValidationResult, upstream any, elapsed time.Duration) {
+	if !s.validation.DebugFields {
+		return
+	}
+	result.Provider = ports.ProviderOf(upstream)
+	result.ElapsedMs = int((elapsed + time.Millisecond - 1) / time.Millisecond)
+}
+
+// calculateDistance calculates the distance from a point to the precomputed center in the given unit using the Haversine formula
+func calculateDistance(center geo.Center, lat, lng float64, unit string) float64 {
+	return geo.FromMeters(center.MetersFrom(lat, lng), unit)
+}
+
+// isScriptAllowed reports whether every letter in the address belongs to an allowed script.
+// Digits, spaces and punctuation are shared across scripts and are always allowed.
+func (s *AddressService) isScriptAllowed(address string) bool {
+	if len(s.allowedScripts) == 0 {
+		return true
+	}
+
+	for _, r := range address {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if !unicode.IsOneOf(s.allowedScripts, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Sanitization patterns, compiled once since they run on every request
+var (
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	// Keeps alphanumeric, spaces, basic punctuation and # for apartment numbers
+	disallowedCharsPattern = regexp.MustCompile(`[^\w\s,.#-]`)
+)
+
+// cleaning up spaces and only allowing words, spaces, period, comma, dash, and #
 func sanitizeAddress(address string) string {
 	// 1. Trim leading/trailing whitespace
 	address = strings.TrimSpace(address)
 
 	// 2. Collapse multiple spaces into one
-	address = regexp.MustCompile(`\s+`).ReplaceAllString(address, " ")
+	address = whitespacePattern.ReplaceAllString(address, " ")
 
 	// 3. Remove potentially dangerous characters
-	//    (keeps alphanumeric, spaces, basic punctuation)
-	address = regexp.MustCompile(`[^\w\s,.-]`).ReplaceAllString(address, "")
+	address = disallowedCharsPattern.ReplaceAllString(address, "")
 
 	return address
 }
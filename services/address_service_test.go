@@ -0,0 +1,1206 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/geo"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// stubValidator records whether the upstream validator was reached
+type stubValidator struct {
+	called  bool
+	address string
+	result  ports.AddressValidationResult
+}
+
+func (s *stubValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	s.called = true
+	s.address = address
+	return s.result, nil
+}
+
+// ReverseGeocode returns the same fixed result for any coordinates
+func (s *stubValidator) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	s.called = true
+	return s.result, nil
+}
+
+// stubTravelTime returns a fixed drive time or error, standing in for the Distance Matrix API
+type stubTravelTime struct {
+	driveTime time.Duration
+	err       error
+}
+
+func (s *stubTravelTime) DriveTime(ctx context.Context, originLat float64, originLng float64, destinationLat float64, destinationLng float64) (time.Duration, error) {
+	return s.driveTime, s.err
+}
+
+// stubElevation returns a fixed elevation or error, standing in for the Elevation API
+type stubElevation struct {
+	called    bool
+	elevation float64
+	err       error
+}
+
+func (s *stubElevation) Elevation(ctx context.Context, latitude float64, longitude float64) (float64, error) {
+	s.called = true
+	return s.elevation, s.err
+}
+
+func TestAddressService_ValidateAddress_AllowedScripts(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedScripts []string
+		address        string
+		wantErr        error
+		wantCode       string
+		wantCalled     bool
+	}{
+		{
+			name:       "Test Unset Allowed Scripts Accepts Cyrillic",
+			address:    "ул. Тверская 1, Москва",
+			wantCalled: true,
+		},
+		{
+			name:           "Test Latin Allowed Accepts Latin",
+			allowedScripts: []string{"Latin"},
+			address:        "123 Main St, Bronx, NY",
+			wantCalled:     true,
+		},
+		{
+			name:           "Test Latin Allowed Accepts Accented Latin",
+			allowedScripts: []string{"Latin"},
+			address:        "12 Rue de l'Église, Montréal",
+			wantCalled:     true,
+		},
+		{
+			name:           "Test Latin Allowed Rejects Cyrillic",
+			allowedScripts: []string{"Latin"},
+			address:        "ул. Тверская 1, Москва",
+			wantErr:        services.ErrDisallowedScript,
+			wantCode:       ports.ERROR_CODE_DISALLOWED_SCRIPT,
+		},
+		{
+			name:           "Test Latin Allowed Rejects Mixed Latin and Cyrillic",
+			allowedScripts: []string{"Latin"},
+			address:        "123 Main St, Москва",
+			wantErr:        services.ErrDisallowedScript,
+			wantCode:       ports.ERROR_CODE_DISALLOWED_SCRIPT,
+		},
+		{
+			name:           "Test Latin and Cyrillic Allowed Accepts Cyrillic",
+			allowedScripts: []string{"Latin", "Cyrillic"},
+			address:        "ул. Тверская 1, Москва",
+			wantCalled:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &stubValidator{}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{
+				AllowedScripts: tt.allowedScripts,
+			})
+
+			got, err := s.ValidateAddress(context.Background(), tt.address, services.ValidationOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddressService.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %v, want %v", got.ErrorCode, tt.wantCode)
+			}
+			if validator.called != tt.wantCalled {
+				t.Errorf("validator called = %v, want %v", validator.called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_LocationEncoding(t *testing.T) {
+	bronx := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+		Latitude:         40.8448,
+		Longitude:        -73.8648,
+	}
+	withProviderCode := bronx
+	withProviderCode.PlusCode = "9FWM33GV+HQ"
+	// A reference Open Location Code cell
+	switzerland := ports.AddressValidationResult{IsValid: true, Latitude: 47.0000625, Longitude: 8.0000625}
+
+	tests := []struct {
+		name         string
+		config       config.ValidationConfig
+		result       ports.AddressValidationResult
+		wantPlusCode string
+		wantUTM      bool
+	}{
+		{
+			name:   "Test Disabled Omits Encodings",
+			result: withProviderCode,
+		},
+		{
+			name:         "Test Plus Code Computed From Coordinates",
+			config:       config.ValidationConfig{IncludePlusCode: true},
+			result:       switzerland,
+			wantPlusCode: "8FVC2222+22",
+		},
+		{
+			name:         "Test Provider Plus Code Is Kept",
+			config:       config.ValidationConfig{IncludePlusCode: true},
+			result:       withProviderCode,
+			wantPlusCode: "9FWM33GV+HQ",
+		},
+		{
+			name:    "Test UTM Enabled Returns UTM",
+			config:  config.ValidationConfig{IncludeUTM: true},
+			result:  bronx,
+			wantUTM: true,
+		},
+		{
+			name:   "Test Invalid Result Omits Encodings",
+			config: config.ValidationConfig{IncludePlusCode: true, IncludeUTM: true},
+			result: ports.AddressValidationResult{IsValid: false},
+		},
+		{
+			name:   "Test Result Without Location Omits Encodings",
+			config: config.ValidationConfig{IncludePlusCode: true, IncludeUTM: true},
+			result: ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St, Bronx, NY 10456, USA"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := services.NewAddressService(&stubValidator{result: tt.result}, zap.NewNop(), config.MapConfig{}, tt.config)
+
+			got, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.PlusCode != tt.wantPlusCode {
+				t.Errorf("AddressService.ValidateAddress() PlusCode = %v, want %v", got.PlusCode, tt.wantPlusCode)
+			}
+			if (got.UTM != nil) != tt.wantUTM {
+				t.Errorf("AddressService.ValidateAddress() UTM = %v, want present %v", got.UTM, tt.wantUTM)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_CheckGeofence(t *testing.T) {
+	mapConfig := config.MapConfig{
+		MaxDistance:  2,
+		DistanceUnit: ports.DISTANCE_MILES,
+		CenterLat:    40.8313747,
+		CenterLng:    -73.8272283,
+	}
+	bronx := ports.AddressValidationResult{IsValid: true, Latitude: 40.84, Longitude: -73.84}
+
+	tests := []struct {
+		name        string
+		options     services.ValidationOptions
+		wantChecked bool
+		wantInRange bool
+	}{
+		{
+			name:        "Test Default Options Check Geofence",
+			wantChecked: true,
+			wantInRange: true,
+		},
+		{
+			name:    "Test Skip Geofence Omits Range And Distance",
+			options: services.ValidationOptions{SkipGeofence: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := services.NewAddressService(&stubValidator{result: bronx}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+
+			got, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", tt.options)
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if (got.InRange != nil) != tt.wantChecked || (got.DistanceToCenter != nil) != tt.wantChecked {
+				t.Fatalf("AddressService.ValidateAddress() InRange = %v, DistanceToCenter = %v, want present %v", got.InRange, got.DistanceToCenter, tt.wantChecked)
+			}
+			if tt.wantChecked && *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() InRange = %v, want %v", *got.InRange, tt.wantInRange)
+			}
+			if !got.IsValid {
+				t.Error("AddressService.ValidateAddress() IsValid = false, want normalized result")
+			}
+		})
+	}
+}
+
+// blockingValidator honors ctx, returning a bogus result only if it is never cancelled
+type blockingValidator struct {
+	started chan struct{}
+	delay   time.Duration
+}
+
+func (b *blockingValidator) ValidateAddress(ctx context.Context, address string) (ports.AddressValidationResult, error) {
+	close(b.started)
+	select {
+	case <-ctx.Done():
+		// Like a real client, a cancelled call can still hand back partial data
+		return ports.AddressValidationResult{IsValid: true, FormattedAddress: "partial"}, ctx.Err()
+	case <-time.After(b.delay):
+		return ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St"}, nil
+	}
+}
+
+func TestAddressService_ValidateAddress_Cancellation(t *testing.T) {
+	tests := []struct {
+		name       string
+		cancel     func(cancel context.CancelFunc, started <-chan struct{})
+		wantCause  error
+		wantCalled bool
+	}{
+		{
+			name: "Test Cancelled Before Call Skips Upstream",
+			cancel: func(cancel context.CancelFunc, started <-chan struct{}) {
+				cancel()
+			},
+			wantCause: context.Canceled,
+		},
+		{
+			name: "Test Cancelled Mid-Flight Returns Promptly",
+			cancel: func(cancel context.CancelFunc, started <-chan struct{}) {
+				go func() {
+					<-started
+					cancel()
+				}()
+			},
+			wantCause:  context.Canceled,
+			wantCalled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &blockingValidator{started: make(chan struct{}), delay: 5 * time.Second}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			tt.cancel(cancel, validator.started)
+
+			start := time.Now()
+			got, err := s.ValidateAddress(ctx, "123 Main St", services.ValidationOptions{})
+			elapsed := time.Since(start)
+
+			if !errors.Is(err, services.ErrContextCancelled) || !errors.Is(err, tt.wantCause) {
+				t.Errorf("AddressService.ValidateAddress() error = %v, want %v wrapping %v", err, services.ErrContextCancelled, tt.wantCause)
+			}
+			if got.ErrorCode != ports.ERROR_CODE_CONTEXT_CANCELLED {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %v, want %v", got.ErrorCode, ports.ERROR_CODE_CONTEXT_CANCELLED)
+			}
+			if got.IsValid || got.FormattedAddress != "" {
+				t.Errorf("AddressService.ValidateAddress() = %+v, want no partial result", got)
+			}
+			if elapsed > time.Second {
+				t.Errorf("AddressService.ValidateAddress() took %v, want prompt return", elapsed)
+			}
+
+			select {
+			case <-validator.started:
+				if !tt.wantCalled {
+					t.Error("upstream validator was called after cancellation")
+				}
+			default:
+				if tt.wantCalled {
+					t.Error("upstream validator was not called")
+				}
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_DeadlineExceeded(t *testing.T) {
+	validator := &blockingValidator{started: make(chan struct{}), delay: 5 * time.Second}
+	s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	got, err := s.ValidateAddress(ctx, "123 Main St", services.ValidationOptions{})
+	if !errors.Is(err, services.ErrRequestTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AddressService.ValidateAddress() error = %v, want %v wrapping %v", err, services.ErrRequestTimeout, context.DeadlineExceeded)
+	}
+	// Running out of time is not a client disconnect
+	if errors.Is(err, services.ErrContextCancelled) || errors.Is(err, context.Canceled) {
+		t.Errorf("AddressService.ValidateAddress() error = %v, want no cancellation", err)
+	}
+	if got.ErrorCode != ports.ERROR_CODE_REQUEST_TIMEOUT {
+		t.Errorf("AddressService.ValidateAddress() ErrorCode = %v, want %v", got.ErrorCode, ports.ERROR_CODE_REQUEST_TIMEOUT)
+	}
+	if got.IsValid || got.FormattedAddress != "" {
+		t.Errorf("AddressService.ValidateAddress() = %+v, want no partial result", got)
+	}
+}
+
+func TestAddressService_ValidateAddress_ReplacedInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		address      string
+		result       ports.AddressValidationResult
+		wantReplaced bool
+	}{
+		{
+			name:    "Test Matching Address Is Not Replaced",
+			address: "123 Main Street, Bronx, NY",
+			result:  ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St, Bronx, NY 10456, USA"},
+		},
+		{
+			name:         "Test Rewritten Street Is Replaced",
+			address:      "123 Fakestreet Bronx",
+			result:       ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Fake St, Bronx, NY 10456, USA"},
+			wantReplaced: true,
+		},
+		{
+			name:         "Test Changed House Number Is Replaced",
+			address:      "125 Main St, Bronx, NY",
+			result:       ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St, Bronx, NY 10456, USA"},
+			wantReplaced: true,
+		},
+		{
+			name:         "Test Provider Flag Is Kept",
+			address:      "123 Main St, Bronx, NY",
+			result:       ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St, Bronx, NY 10456, USA", ReplacedInput: true},
+			wantReplaced: true,
+		},
+		{
+			name:    "Test Invalid Result Is Not Flagged",
+			address: "123 Fakestreet",
+			result:  ports.AddressValidationResult{IsValid: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := services.NewAddressService(&stubValidator{result: tt.result}, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+
+			got, err := s.ValidateAddress(context.Background(), tt.address, services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.ReplacedInput != tt.wantReplaced {
+				t.Errorf("AddressService.ValidateAddress() ReplacedInput = %v, want %v", got.ReplacedInput, tt.wantReplaced)
+			}
+
+			wantOriginal := ""
+			if tt.wantReplaced {
+				wantOriginal = tt.address
+			}
+			if got.OriginalInput != wantOriginal {
+				t.Errorf("AddressService.ValidateAddress() OriginalInput = %q, want %q", got.OriginalInput, wantOriginal)
+			}
+		})
+	}
+}
+
+// namedValidator reports a provider name like the real adapters
+type namedValidator struct {
+	stubValidator
+}
+
+func (n *namedValidator) Provider() string {
+	return ports.PROVIDER_MOCK
+}
+
+func TestAddressService_ValidateAddress_DebugFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		debugFields  bool
+		wantProvider string
+		wantElapsed  bool
+	}{
+		{name: "Test Debug Flag Adds Provider And Elapsed", debugFields: true, wantProvider: ports.PROVIDER_MOCK, wantElapsed: true},
+		{name: "Test Debug Fields Are Omitted By Default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &namedValidator{stubValidator{result: ports.AddressValidationResult{IsValid: true, FormattedAddress: "1 Main St"}}}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{DebugFields: tt.debugFields})
+
+			got, err := s.ValidateAddress(context.Background(), "1 Main St", services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.Provider != tt.wantProvider {
+				t.Errorf("AddressService.ValidateAddress() Provider = %q, want %q", got.Provider, tt.wantProvider)
+			}
+			if (got.ElapsedMs > 0) != tt.wantElapsed {
+				t.Errorf("AddressService.ValidateAddress() ElapsedMs = %d, want set %v", got.ElapsedMs, tt.wantElapsed)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_SanitizedAddress(t *testing.T) {
+	const input = "  123 Main St<script>;   Bronx, NY!  "
+	const sanitized = "123 Main Stscript Bronx, NY"
+
+	tests := []struct {
+		name          string
+		debugFields   bool
+		wantOriginal  string
+		wantSanitized string
+	}{
+		{name: "Test Debug Flag Shows Original And Sanitized Address", debugFields: true, wantOriginal: input, wantSanitized: sanitized},
+		{name: "Test Sanitized Address Is Omitted By Default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &stubValidator{result: ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St, Bronx, NY 10456, USA"}}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{DebugFields: tt.debugFields})
+
+			got, err := s.ValidateAddress(context.Background(), input, services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if validator.address != sanitized {
+				t.Fatalf("geocoded address = %q, want %q", validator.address, sanitized)
+			}
+			if got.OriginalInput != tt.wantOriginal {
+				t.Errorf("AddressService.ValidateAddress() OriginalInput = %q, want %q", got.OriginalInput, tt.wantOriginal)
+			}
+			if got.SanitizedAddress != tt.wantSanitized {
+				t.Errorf("AddressService.ValidateAddress() SanitizedAddress = %q, want %q", got.SanitizedAddress, tt.wantSanitized)
+			}
+		})
+	}
+}
+
+func TestAddressService_GeofenceBoundary(t *testing.T) {
+	const centerLat, centerLng = 40.8313747, -73.8272283
+	const lat, lng = 40.84, -73.84
+	// The address sits exactly on the boundary
+	boundary := geo.FromMeters(geo.HaversineMeters(lat, lng, centerLat, centerLng), ports.DISTANCE_MILES)
+	onBoundary := ports.AddressValidationResult{IsValid: true, Latitude: lat, Longitude: lng}
+
+	tests := []struct {
+		name        string
+		inclusive   bool
+		wantInRange bool
+	}{
+		{name: "Test Inclusive Boundary Is In Range", inclusive: true, wantInRange: true},
+		{name: "Test Exclusive Boundary Is Out Of Range", inclusive: false, wantInRange: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapConfig := config.MapConfig{
+				MaxDistance:       boundary,
+				GeofenceInclusive: tt.inclusive,
+				DistanceUnit:      ports.DISTANCE_MILES,
+				CenterLat:         centerLat,
+				CenterLng:         centerLng,
+			}
+			s := services.NewAddressService(&stubValidator{result: onBoundary}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+
+			got, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+
+			got, err = s.ValidateCoordinates(context.Background(), lat, lng, services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateCoordinates() error = %v", err)
+			}
+			if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateCoordinates() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_AmbiguousBoundary(t *testing.T) {
+	const centerLat, centerLng = 40.8313747, -73.8272283
+	const lat, lng = 40.84, -73.84
+	distance := geo.FromMeters(geo.HaversineMeters(lat, lng, centerLat, centerLng), ports.DISTANCE_MILES)
+
+	tests := []struct {
+		name          string
+		locationType  string
+		maxDistance   float64
+		margin        float64
+		wantAmbiguous bool
+		wantInRange   bool
+	}{
+		{
+			name:          "Test Approximate Just Inside Boundary Is Ambiguous",
+			locationType:  ports.LOCATION_TYPE_APPROXIMATE,
+			maxDistance:   distance + 0.05,
+			margin:        0.1,
+			wantAmbiguous: true,
+			wantInRange:   true,
+		},
+		{
+			name:          "Test Approximate Just Outside Boundary Is Ambiguous",
+			locationType:  ports.LOCATION_TYPE_APPROXIMATE,
+			maxDistance:   distance - 0.05,
+			margin:        0.1,
+			wantAmbiguous: true,
+		},
+		{
+			name:         "Test Approximate Far Inside Boundary Is Not Ambiguous",
+			locationType: ports.LOCATION_TYPE_APPROXIMATE,
+			maxDistance:  distance + 1,
+			margin:       0.1,
+			wantInRange:  true,
+		},
+		{
+			name:         "Test Rooftop Near Boundary Is Not Ambiguous",
+			locationType: ports.LOCATION_TYPE_ROOFTOP,
+			maxDistance:  distance + 0.05,
+			margin:       0.1,
+			wantInRange:  true,
+		},
+		{
+			name:         "Test Zero Margin Disables Check",
+			locationType: ports.LOCATION_TYPE_APPROXIMATE,
+			maxDistance:  distance,
+			wantInRange:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapConfig := config.MapConfig{
+				MaxDistance:         tt.maxDistance,
+				GeofenceInclusive:   true,
+				GeofenceUncertainty: tt.margin,
+				DistanceUnit:        ports.DISTANCE_MILES,
+				CenterLat:           centerLat,
+				CenterLng:           centerLng,
+			}
+			result := ports.AddressValidationResult{IsValid: true, Latitude: lat, Longitude: lng, LocationType: tt.locationType}
+			s := services.NewAddressService(&stubValidator{result: result}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+
+			got, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.Ambiguous != tt.wantAmbiguous {
+				t.Errorf("AddressService.ValidateAddress() Ambiguous = %v, want %v", got.Ambiguous, tt.wantAmbiguous)
+			}
+			if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+
+			// Submitted coordinates are exact, the geocode precision does not apply to them
+			got, err = s.ValidateCoordinates(context.Background(), lat, lng, services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateCoordinates() error = %v", err)
+			}
+			if got.Ambiguous {
+				t.Errorf("AddressService.ValidateCoordinates() Ambiguous = true, want false")
+			}
+		})
+	}
+}
+
+func TestAddressService_GeofenceStatus(t *testing.T) {
+	const centerLat, centerLng = 40.8313747, -73.8272283
+	const lat, lng = 40.84, -73.84
+	distance := geo.FromMeters(geo.HaversineMeters(lat, lng, centerLat, centerLng), ports.DISTANCE_MILES)
+
+	tests := []struct {
+		name            string
+		result          ports.AddressValidationResult
+		maxDistance     float64
+		margin          float64
+		options         services.ValidationOptions
+		wantAddress     string
+		wantCoordinates string
+	}{
+		{
+			name:            "Test Far Inside Is In Range",
+			result:          ports.AddressValidationResult{IsValid: true, LocationType: ports.LOCATION_TYPE_ROOFTOP},
+			maxDistance:     distance + 1,
+			margin:          0.1,
+			wantAddress:     ports.GEOFENCE_IN_RANGE,
+			wantCoordinates: ports.GEOFENCE_IN_RANGE,
+		},
+		{
+			name:            "Test Far Outside Is Out Of Range",
+			result:          ports.AddressValidationResult{IsValid: true, LocationType: ports.LOCATION_TYPE_ROOFTOP},
+			maxDistance:     distance - 0.5,
+			margin:          0.1,
+			wantAddress:     ports.GEOFENCE_OUT_OF_RANGE,
+			wantCoordinates: ports.GEOFENCE_OUT_OF_RANGE,
+		},
+		{
+			name:            "Test Precise Location Within Margin Is Near Boundary",
+			result:          ports.AddressValidationResult{IsValid: true, LocationType: ports.LOCATION_TYPE_ROOFTOP},
+			maxDistance:     distance + 0.05,
+			margin:          0.1,
+			wantAddress:     ports.GEOFENCE_NEAR_BOUNDARY,
+			wantCoordinates: ports.GEOFENCE_NEAR_BOUNDARY,
+		},
+		{
+			// Submitted coordinates are exact even when the matched address is approximate
+			name:            "Test Approximate Location Within Margin Is Ambiguous",
+			result:          ports.AddressValidationResult{IsValid: true, LocationType: ports.LOCATION_TYPE_APPROXIMATE},
+			maxDistance:     distance - 0.05,
+			margin:          0.1,
+			wantAddress:     ports.GEOFENCE_AMBIGUOUS,
+			wantCoordinates: ports.GEOFENCE_NEAR_BOUNDARY,
+		},
+		{
+			name:            "Test Zero Margin Never Near Boundary",
+			result:          ports.AddressValidationResult{IsValid: true, LocationType: ports.LOCATION_TYPE_APPROXIMATE},
+			maxDistance:     distance,
+			wantAddress:     ports.GEOFENCE_IN_RANGE,
+			wantCoordinates: ports.GEOFENCE_IN_RANGE,
+		},
+		{
+			name:            "Test Skipped Geofence Is Not Evaluated",
+			result:          ports.AddressValidationResult{IsValid: true, LocationType: ports.LOCATION_TYPE_ROOFTOP},
+			maxDistance:     distance + 1,
+			options:         services.ValidationOptions{SkipGeofence: true},
+			wantAddress:     ports.GEOFENCE_NOT_EVALUATED,
+			wantCoordinates: ports.GEOFENCE_NOT_EVALUATED,
+		},
+		{
+			name:            "Test Invalid Result Is Not Evaluated",
+			result:          ports.AddressValidationResult{IsValid: false, Error: "Address is incomplete."},
+			maxDistance:     distance + 1,
+			wantAddress:     ports.GEOFENCE_NOT_EVALUATED,
+			wantCoordinates: ports.GEOFENCE_NOT_EVALUATED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapConfig := config.MapConfig{
+				MaxDistance:         tt.maxDistance,
+				GeofenceInclusive:   true,
+				GeofenceUncertainty: tt.margin,
+				DistanceUnit:        ports.DISTANCE_MILES,
+				CenterLat:           centerLat,
+				CenterLng:           centerLng,
+			}
+			result := tt.result
+			result.Latitude, result.Longitude = lat, lng
+			validator := &stubValidator{result: result}
+			s := services.NewAddressService(validator, zap.NewNop(), mapConfig, config.ValidationConfig{})
+			s.SetReverseGeocoder(validator)
+
+			got, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", tt.options)
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.GeofenceStatus != tt.wantAddress {
+				t.Errorf("AddressService.ValidateAddress() GeofenceStatus = %q, want %q", got.GeofenceStatus, tt.wantAddress)
+			}
+			if got.Ambiguous != (tt.wantAddress == ports.GEOFENCE_AMBIGUOUS) {
+				t.Errorf("AddressService.ValidateAddress() Ambiguous = %v, want status %q", got.Ambiguous, tt.wantAddress)
+			}
+
+			got, err = s.ValidateCoordinates(context.Background(), lat, lng, tt.options)
+			if err != nil {
+				t.Fatalf("AddressService.ValidateCoordinates() error = %v", err)
+			}
+			if got.GeofenceStatus != tt.wantCoordinates {
+				t.Errorf("AddressService.ValidateCoordinates() GeofenceStatus = %q, want %q", got.GeofenceStatus, tt.wantCoordinates)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_MissingLocation(t *testing.T) {
+	mapConfig := config.MapConfig{
+		MaxDistance:       2,
+		GeofenceInclusive: true,
+		DistanceUnit:      ports.DISTANCE_MILES,
+		CenterLat:         40.8313747,
+		CenterLng:         -73.8272283,
+	}
+
+	tests := []struct {
+		name        string
+		result      ports.AddressValidationResult
+		wantStatus  string
+		wantInRange bool
+	}{
+		{
+			// A verdict without a geocode leaves the coordinates at 0,0
+			name:       "Test Missing Geocode Is Not Evaluated",
+			result:     ports.AddressValidationResult{IsValid: true, FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457, USA"},
+			wantStatus: ports.GEOFENCE_NOT_EVALUATED,
+		},
+		{
+			name:        "Test Geocoded Address Is Evaluated",
+			result:      ports.AddressValidationResult{IsValid: true, FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457, USA", Latitude: 40.8399, Longitude: -73.8272283},
+			wantStatus:  ports.GEOFENCE_IN_RANGE,
+			wantInRange: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := services.NewAddressService(&stubValidator{result: tt.result}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+
+			got, err := s.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY", services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.GeofenceStatus != tt.wantStatus {
+				t.Errorf("AddressService.ValidateAddress() GeofenceStatus = %q, want %q", got.GeofenceStatus, tt.wantStatus)
+			}
+			if tt.wantStatus == ports.GEOFENCE_NOT_EVALUATED {
+				if got.InRange != nil || got.DistanceToCenter != nil {
+					t.Errorf("AddressService.ValidateAddress() InRange = %v, DistanceToCenter = %v, want neither", got.InRange, got.DistanceToCenter)
+				}
+			} else if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_LowConfidence(t *testing.T) {
+	approximate := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "North Atlantic Ocean",
+		LocationType:     ports.LOCATION_TYPE_APPROXIMATE,
+	}
+	streetLevel := approximate
+	streetLevel.StreetLevel = true
+	rooftop := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+		LocationType:     ports.LOCATION_TYPE_ROOFTOP,
+		StreetLevel:      true,
+	}
+
+	tests := []struct {
+		name              string
+		policy            string
+		result            ports.AddressValidationResult
+		wantLowConfidence bool
+		wantValid         bool
+		wantCode          string
+	}{
+		{
+			name:              "Test Approximate Only Result Is Flagged",
+			policy:            config.LOW_CONFIDENCE_FLAG,
+			result:            approximate,
+			wantLowConfidence: true,
+			wantValid:         true,
+		},
+		{
+			name:              "Test Reject Policy Invalidates Approximate Only Result",
+			policy:            config.LOW_CONFIDENCE_REJECT,
+			result:            approximate,
+			wantLowConfidence: true,
+			wantCode:          ports.ERROR_CODE_LOW_CONFIDENCE,
+		},
+		{
+			name:      "Test Approximate Result With Street Component Is Not Flagged",
+			policy:    config.LOW_CONFIDENCE_REJECT,
+			result:    streetLevel,
+			wantValid: true,
+		},
+		{
+			name:      "Test Rooftop Result Is Not Flagged",
+			policy:    config.LOW_CONFIDENCE_REJECT,
+			result:    rooftop,
+			wantValid: true,
+		},
+		{
+			name:      "Test Off Policy Skips Check",
+			policy:    config.LOW_CONFIDENCE_OFF,
+			result:    approximate,
+			wantValid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validationConfig := config.ValidationConfig{LowConfidencePolicy: tt.policy}
+			s := services.NewAddressService(&stubValidator{result: tt.result}, zap.NewNop(), config.MapConfig{}, validationConfig)
+
+			got, err := s.ValidateAddress(context.Background(), "asdf qwerty", services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.LowConfidence != tt.wantLowConfidence {
+				t.Errorf("AddressService.ValidateAddress() LowConfidence = %v, want %v", got.LowConfidence, tt.wantLowConfidence)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("AddressService.ValidateAddress() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_AddressType(t *testing.T) {
+	noPOBox := []string{ports.ADDRESS_TYPE_COMMERCIAL, ports.ADDRESS_TYPE_RESIDENTIAL}
+
+	tests := []struct {
+		name        string
+		allowed     []string
+		addressType string
+		wantValid   bool
+		wantCode    string
+	}{
+		{
+			name:        "Test PO Box Rejected Under No PO Box Policy",
+			allowed:     noPOBox,
+			addressType: ports.ADDRESS_TYPE_PO_BOX,
+			wantCode:    ports.ERROR_CODE_DISALLOWED_TYPE,
+		},
+		{
+			name:        "Test Residential Accepted Under No PO Box Policy",
+			allowed:     noPOBox,
+			addressType: ports.ADDRESS_TYPE_RESIDENTIAL,
+			wantValid:   true,
+		},
+		{
+			name:      "Test Unknown Type Accepted Under No PO Box Policy",
+			allowed:   noPOBox,
+			wantValid: true,
+		},
+		{
+			name:        "Test PO Box Accepted By Default",
+			addressType: ports.ADDRESS_TYPE_PO_BOX,
+			wantValid:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "PO Box 123, Bronx, NY 10456, USA",
+				LocationType:     ports.LOCATION_TYPE_ROOFTOP,
+				StreetLevel:      true,
+				AddressType:      tt.addressType,
+			}
+			validationConfig := config.ValidationConfig{AllowedAddressTypes: tt.allowed}
+			s := services.NewAddressService(&stubValidator{result: result}, zap.NewNop(), config.MapConfig{}, validationConfig)
+
+			got, err := s.ValidateAddress(context.Background(), "PO Box 123, Bronx, NY", services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("AddressService.ValidateAddress() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+			if got.AddressType != tt.addressType {
+				t.Errorf("AddressService.ValidateAddress() AddressType = %q, want %q", got.AddressType, tt.addressType)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_DriveTime(t *testing.T) {
+	mapConfig := config.MapConfig{
+		MaxDistance:       2,
+		GeofenceInclusive: true,
+		DistanceUnit:      ports.DISTANCE_MILES,
+		CenterLat:         40.8313747,
+		CenterLng:         -73.8272283,
+		MaxDriveTime:      20 * time.Minute,
+	}
+	// Across the river from the center, close in a straight line
+	nearby := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "1 College Point Blvd, Queens, NY 11356, USA",
+		Latitude:         40.8399,
+		Longitude:        -73.8272283,
+	}
+
+	tests := []struct {
+		name             string
+		travel           ports.TravelTimeEstimator
+		wantStatus       string
+		wantInRange      bool
+		wantDriveMinutes float64
+	}{
+		{
+			name:             "Test Short Drive Is In Range",
+			travel:           &stubTravelTime{driveTime: 12 * time.Minute},
+			wantStatus:       ports.GEOFENCE_IN_RANGE,
+			wantInRange:      true,
+			wantDriveMinutes: 12,
+		},
+		{
+			name:             "Test Long Drive Is Out Of Range Despite Straight-Line Distance",
+			travel:           &stubTravelTime{driveTime: 45 * time.Minute},
+			wantStatus:       ports.GEOFENCE_OUT_OF_RANGE,
+			wantDriveMinutes: 45,
+		},
+		{
+			name:       "Test No Route Is Out Of Range",
+			travel:     &stubTravelTime{err: ports.ErrNoRoute},
+			wantStatus: ports.GEOFENCE_OUT_OF_RANGE,
+		},
+		{
+			name:        "Test Unavailable Travel API Falls Back To Straight Line",
+			travel:      &stubTravelTime{err: ports.ErrProviderQuota},
+			wantStatus:  ports.GEOFENCE_IN_RANGE,
+			wantInRange: true,
+		},
+		{
+			name:        "Test No Estimator Uses Straight Line",
+			wantStatus:  ports.GEOFENCE_IN_RANGE,
+			wantInRange: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := services.NewAddressService(&stubValidator{result: nearby}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+			s.SetTravelTimeEstimator(tt.travel)
+
+			got, err := s.ValidateAddress(context.Background(), "1 College Point Blvd, Queens, NY", services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.GeofenceStatus != tt.wantStatus {
+				t.Errorf("AddressService.ValidateAddress() GeofenceStatus = %q, want %q", got.GeofenceStatus, tt.wantStatus)
+			}
+			if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+			var gotDriveMinutes float64
+			if got.DriveMinutes != nil {
+				gotDriveMinutes = *got.DriveMinutes
+			}
+			if gotDriveMinutes != tt.wantDriveMinutes {
+				t.Errorf("AddressService.ValidateAddress() DriveMinutes = %v, want %v", gotDriveMinutes, tt.wantDriveMinutes)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_RequiredComponents(t *testing.T) {
+	shipping := []string{"street_number", "postal_code"}
+	routeOnly := []ports.AddressComponent{
+		{Type: "route", Text: "Grand Concourse"},
+		{Type: "locality", Text: "Bronx"},
+		{Type: "postal_code", Text: "10457"},
+	}
+	premise := append([]ports.AddressComponent{{Type: "street_number", Text: "1600"}}, routeOnly...)
+
+	tests := []struct {
+		name       string
+		required   []string
+		components []ports.AddressComponent
+		wantValid  bool
+		wantCode   string
+		wantError  string
+	}{
+		{
+			name:       "Test Missing Street Number Is Rejected",
+			required:   shipping,
+			components: routeOnly,
+			wantCode:   ports.ERROR_CODE_MISSING_COMPONENT,
+			wantError:  "address is missing required components: street_number",
+		},
+		{
+			name:      "Test Every Missing Component Is Listed",
+			required:  shipping,
+			wantCode:  ports.ERROR_CODE_MISSING_COMPONENT,
+			wantError: "address is missing required components: street_number, postal_code",
+		},
+		{
+			name:       "Test Address With Required Components Is Accepted",
+			required:   shipping,
+			components: premise,
+			wantValid:  true,
+		},
+		{
+			name:       "Test No Policy Accepts Route Level Address",
+			components: routeOnly,
+			wantValid:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "Grand Concourse, Bronx, NY 10457, USA",
+				LocationType:     ports.LOCATION_TYPE_GEOMETRIC_CENTER,
+				StreetLevel:      true,
+				Components:       tt.components,
+			}
+			validationConfig := config.ValidationConfig{RequiredComponents: tt.required}
+			s := services.NewAddressService(&stubValidator{result: result}, zap.NewNop(), config.MapConfig{}, validationConfig)
+
+			got, err := s.ValidateAddress(context.Background(), "Grand Concourse, Bronx, NY", services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("AddressService.ValidateAddress() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+			if got.Error != tt.wantError {
+				t.Errorf("AddressService.ValidateAddress() Error = %q, want %q", got.Error, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_Elevation(t *testing.T) {
+	mapConfig := config.MapConfig{
+		MaxDistance:       2,
+		GeofenceInclusive: true,
+		DistanceUnit:      ports.DISTANCE_MILES,
+		CenterLat:         40.8313747,
+		CenterLng:         -73.8272283,
+		MaxElevation:      100,
+	}
+	nearby := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457, USA",
+		Latitude:         40.8399,
+		Longitude:        -73.8272283,
+	}
+	faraway := nearby
+	faraway.Latitude = 41.5
+
+	tests := []struct {
+		name          string
+		result        ports.AddressValidationResult
+		elevation     *stubElevation
+		wantStatus    string
+		wantInRange   bool
+		wantElevation float64
+		wantCalled    bool
+	}{
+		{
+			name:          "Test Low Address Stays In Range",
+			result:        nearby,
+			elevation:     &stubElevation{elevation: 25},
+			wantStatus:    ports.GEOFENCE_IN_RANGE,
+			wantInRange:   true,
+			wantElevation: 25,
+			wantCalled:    true,
+		},
+		{
+			name:          "Test High Address Is Too High",
+			result:        nearby,
+			elevation:     &stubElevation{elevation: 150},
+			wantStatus:    ports.GEOFENCE_TOO_HIGH,
+			wantElevation: 150,
+			wantCalled:    true,
+		},
+		{
+			name:        "Test Unavailable Elevation Keeps Verdict",
+			result:      nearby,
+			elevation:   &stubElevation{err: ports.ErrProviderQuota},
+			wantStatus:  ports.GEOFENCE_IN_RANGE,
+			wantInRange: true,
+			wantCalled:  true,
+		},
+		{
+			name:       "Test Out Of Range Address Is Not Looked Up",
+			result:     faraway,
+			elevation:  &stubElevation{elevation: 150},
+			wantStatus: ports.GEOFENCE_OUT_OF_RANGE,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := services.NewAddressService(&stubValidator{result: tt.result}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+			s.SetElevationProvider(tt.elevation)
+
+			got, err := s.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY", services.ValidationOptions{})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.GeofenceStatus != tt.wantStatus {
+				t.Errorf("AddressService.ValidateAddress() GeofenceStatus = %q, want %q", got.GeofenceStatus, tt.wantStatus)
+			}
+			if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+			var gotElevation float64
+			if got.Elevation != nil {
+				gotElevation = *got.Elevation
+			}
+			if gotElevation != tt.wantElevation {
+				t.Errorf("AddressService.ValidateAddress() Elevation = %v, want %v", gotElevation, tt.wantElevation)
+			}
+			if tt.elevation.called != tt.wantCalled {
+				t.Errorf("elevation looked up = %v, want %v", tt.elevation.called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_Deliverable(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name        string
+		require     bool
+		deliverable *bool
+		wantValid   bool
+		wantCode    string
+	}{
+		{
+			name:        "Test Deliverable Accepted When Required",
+			require:     true,
+			deliverable: &yes,
+			wantValid:   true,
+		},
+		{
+			name:        "Test Not Deliverable Rejected When Required",
+			require:     true,
+			deliverable: &no,
+			wantCode:    ports.ERROR_CODE_NOT_DELIVERABLE,
+		},
+		{
+			name:      "Test Unknown Deliverability Accepted When Required",
+			require:   true,
+			wantValid: true,
+		},
+		{
+			name:        "Test Not Deliverable Accepted By Default",
+			deliverable: &no,
+			wantValid:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+				LocationType:     ports.LOCATION_TYPE_ROOFTOP,
+				StreetLevel:      true,
+				Deliverable:      tt.deliverable,
+			}
+			validationConfig := config.ValidationConfig{RequireDeliverable: tt.require}
+			s := services.NewAddressService(&stubValidator{result: result}, zap.NewNop(), config.MapConfig{}, validationConfig)
+
+			got, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("AddressService.ValidateAddress() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+			if got.Deliverable != tt.deliverable {
+				t.Errorf("AddressService.ValidateAddress() Deliverable = %v, want %v", got.Deliverable, tt.deliverable)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"address-validator/audit"
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAddressService_ValidateAddress_AuditRecord(t *testing.T) {
+	const address = "123 Main St, Bronx, NY"
+
+	tests := []struct {
+		name          string
+		logRawAddress bool
+		want          map[string]interface{}
+	}{
+		{
+			name: "Test Record Carries Hash Without Raw Address",
+			want: map[string]interface{}{
+				"correlationId": "req-1",
+				"addressHash":   audit.HashAddress(address),
+				"provider":      ports.PROVIDER_MOCK,
+				"regionCode":    "US",
+				"isValid":       true,
+				"inRange":       true,
+				"errorCode":     "",
+				"error":         "",
+			},
+		},
+		{
+			name:          "Test Record Carries Raw Address When Enabled",
+			logRawAddress: true,
+			want: map[string]interface{}{
+				"correlationId": "req-1",
+				"addressHash":   audit.HashAddress(address),
+				"provider":      ports.PROVIDER_MOCK,
+				"regionCode":    "US",
+				"isValid":       true,
+				"inRange":       true,
+				"errorCode":     "",
+				"error":         "",
+				"address":       address,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			validator := &stubValidator{result: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "123 Main St, Bronx, NY 10456, USA",
+				RegionCode:       "US",
+				Latitude:         40.8448,
+				Longitude:        -73.8648,
+			}}
+			mapConfig := config.MapConfig{CenterLat: 40.8448, CenterLng: -73.8648, MaxDistance: 10, DistanceUnit: "km"}
+			s := services.NewAddressService(validator, zap.NewNop(), mapConfig, config.ValidationConfig{})
+			s.SetAuditLogger(audit.NewLogger(zap.New(core), ports.PROVIDER_MOCK, tt.logRawAddress))
+
+			ctx := audit.WithCorrelationID(context.Background(), "req-1")
+			if _, err := s.ValidateAddress(ctx, address, services.ValidationOptions{}); err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+
+			records := logs.FilterMessage(audit.AUDIT_EVENT).All()
+			if len(records) != 1 {
+				t.Fatalf("audit records = %d, want 1", len(records))
+			}
+			if got := records[0].ContextMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("audit record = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
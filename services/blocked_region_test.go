@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAddressService_ValidateAddress_BlockedRegions(t *testing.T) {
+	tests := []struct {
+		name       string
+		regionCode string
+		wantErr    error
+		wantValid  bool
+		wantAudit  bool
+	}{
+		{name: "Test Blocked Region Is Refused", regionCode: "KP", wantErr: services.ErrBlockedRegion, wantAudit: true},
+		{name: "Test Blocked Region Match Ignores Case", regionCode: "ir", wantErr: services.ErrBlockedRegion, wantAudit: true},
+		{name: "Test Allowed Region Is Valid", regionCode: "US", wantValid: true},
+		{name: "Test Unknown Region Is Valid", wantValid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			validator := &stubValidator{result: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "1 Main St",
+				RegionCode:       tt.regionCode,
+				Latitude:         40.84,
+				Longitude:        -73.84,
+			}}
+			validationConfig := config.ValidationConfig{BlockedRegions: []string{"KP", "IR"}}
+			s := services.NewAddressService(validator, zap.New(core), config.MapConfig{}, validationConfig)
+
+			got, err := s.ValidateAddress(context.Background(), "1 Main St", services.ValidationOptions{SkipGeofence: true})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddressService.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("AddressService.ValidateAddress() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+
+			if tt.wantErr != nil {
+				if got.ErrorCode != ports.ERROR_CODE_BLOCKED_REGION {
+					t.Errorf("AddressService.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, ports.ERROR_CODE_BLOCKED_REGION)
+				}
+				if got.FormattedAddress != "" || got.Latitude != 0 || got.Longitude != 0 {
+					t.Errorf("AddressService.ValidateAddress() leaked location data for a blocked region: %+v", got)
+				}
+			}
+
+			audits := logs.FilterField(zap.String("audit", "blocked_region")).Len()
+			if (audits > 0) != tt.wantAudit {
+				t.Errorf("audit log lines = %d, want present %v", audits, tt.wantAudit)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// stubReverseGeocoder returns a fixed address for any coordinates
+type stubReverseGeocoder struct {
+	called bool
+}
+
+func (s *stubReverseGeocoder) ReverseGeocode(ctx context.Context, latitude float64, longitude float64) (ports.AddressValidationResult, error) {
+	s.called = true
+	return ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "2155 Bruckner Blvd, Bronx, NY 10472, USA",
+		Latitude:         40.8299,
+		Longitude:        -73.8559,
+	}, nil
+}
+
+func TestAddressService_ValidateCoordinates(t *testing.T) {
+	mapConfig := config.MapConfig{
+		MaxDistance:  2,
+		DistanceUnit: ports.DISTANCE_MILES,
+		CenterLat:    40.8313747,
+		CenterLng:    -73.8272283,
+	}
+
+	tests := []struct {
+		name        string
+		latitude    float64
+		longitude   float64
+		wantErr     error
+		wantCode    string
+		wantCalled  bool
+		wantInRange bool
+	}{
+		{
+			name:        "Test In Range Coordinates Return Formatted Address",
+			latitude:    40.84,
+			longitude:   -73.84,
+			wantCalled:  true,
+			wantInRange: true,
+		},
+		{
+			name:        "Test Out Of Range Coordinates Return Not In Range",
+			latitude:    40.7128,
+			longitude:   -74.0060,
+			wantCalled:  true,
+			wantInRange: false,
+		},
+		{
+			name:      "Test Latitude Above 90 Is Rejected",
+			latitude:  90.5,
+			longitude: -73.84,
+			wantErr:   services.ErrInvalidCoordinates,
+			wantCode:  ports.ERROR_CODE_INVALID_COORDINATES,
+		},
+		{
+			name:      "Test Longitude Below -180 Is Rejected",
+			latitude:  40.84,
+			longitude: -180.1,
+			wantErr:   services.ErrInvalidCoordinates,
+			wantCode:  ports.ERROR_CODE_INVALID_COORDINATES,
+		},
+		{
+			name:      "Test NaN Is Rejected",
+			latitude:  math.NaN(),
+			longitude: -73.84,
+			wantErr:   services.ErrInvalidCoordinates,
+			wantCode:  ports.ERROR_CODE_INVALID_COORDINATES,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reverse := &stubReverseGeocoder{}
+			s := services.NewAddressService(&stubValidator{}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+			s.SetReverseGeocoder(reverse)
+
+			got, err := s.ValidateCoordinates(context.Background(), tt.latitude, tt.longitude, services.ValidationOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddressService.ValidateCoordinates() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateCoordinates() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+			if reverse.called != tt.wantCalled {
+				t.Errorf("reverse geocoder called = %v, want %v", reverse.called, tt.wantCalled)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.FormattedAddress == "" {
+				t.Error("AddressService.ValidateCoordinates() FormattedAddress is empty")
+			}
+			if got.Latitude != tt.latitude || got.Longitude != tt.longitude {
+				t.Errorf("AddressService.ValidateCoordinates() = (%v, %v), want submitted (%v, %v)", got.Latitude, got.Longitude, tt.latitude, tt.longitude)
+			}
+			if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateCoordinates() InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"math"
+
+	"address-validator/config"
+	"address-validator/geo"
+	"address-validator/ports"
+)
+
+// geofence is a snapshot of the geofence settings. It is replaced whole on reload, never modified,
+// so a validation that loaded it sees one consistent center, distance and unit throughout.
+type geofence struct {
+	config  config.MapConfig
+	center  geo.Center
+	premium geo.Center
+}
+
+// newGeofence precomputes the center of the geofence in mapConfig
+func newGeofence(mapConfig config.MapConfig) *geofence {
+	return &geofence{
+		config:  mapConfig,
+		center:  geo.NewCenter(mapConfig.CenterLat, mapConfig.CenterLng),
+		premium: geo.NewCenter(mapConfig.PremiumZoneLat, mapConfig.PremiumZoneLng),
+	}
+}
+
+// Geofence returns the map configuration with the geofence currently in use
+func (s *AddressService) Geofence() config.MapConfig {
+	return s.geofence.Load().config
+}
+
+// ReloadGeofence swaps in the geofence fields of mapConfig: the center, maximum distance and unit,
+// boundary inclusion, uncertainty margin, the drive-time and elevation limits and the premium zone.
+// The provider and other fields keep their startup values. An invalid geofence is rejected and the
+// current one kept.
+// Validations already running finish with the geofence they started with.
+func (s *AddressService) ReloadGeofence(mapConfig config.MapConfig) error {
+	if errs := mapConfig.ValidateGeofence(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	next := s.geofence.Load().config
+	next.CenterLat = mapConfig.CenterLat
+	next.CenterLng = mapConfig.CenterLng
+	next.MaxDistance = mapConfig.MaxDistance
+	next.DistanceUnit = mapConfig.DistanceUnit
+	next.GeofenceInclusive = mapConfig.GeofenceInclusive
+	next.GeofenceUncertainty = mapConfig.GeofenceUncertainty
+	next.MaxDriveTime = mapConfig.MaxDriveTime
+	next.MaxElevation = mapConfig.MaxElevation
+	next.PremiumZoneDistance = mapConfig.PremiumZoneDistance
+	next.PremiumZoneLat = mapConfig.PremiumZoneLat
+	next.PremiumZoneLng = mapConfig.PremiumZoneLng
+	s.geofence.Store(newGeofence(next))
+	return nil
+}
+
+// status qualifies the in-range verdict of a distance. Within the uncertainty margin of the
+// maximum distance the verdict could go either way for an approximate location, and is close for a
+// precise one.
+func (g *geofence) status(distance float64, inRange bool, approximate bool) string {
+	nearBoundary := g.config.GeofenceUncertainty > 0 && math.Abs(distance-g.config.MaxDistance) <= g.config.GeofenceUncertainty
+	switch {
+	case nearBoundary && approximate:
+		return ports.GEOFENCE_AMBIGUOUS
+	case nearBoundary:
+		return ports.GEOFENCE_NEAR_BOUNDARY
+	case inRange:
+		return ports.GEOFENCE_IN_RANGE
+	default:
+		return ports.GEOFENCE_OUT_OF_RANGE
+	}
+}
+
+// within compares the distance to the maximum allowed distance, including the
+// boundary unless the geofence is configured as strictly inside
+func (g *geofence) within(distance float64) bool {
+	if g.config.GeofenceInclusive {
+		return distance <= g.config.MaxDistance
+	}
+	return distance < g.config.MaxDistance
+}
+
+// inPremiumZone reports whether the location is within the advisory premium zone, always
+// including its boundary. It is nil without a configured zone.
+func (g *geofence) inPremiumZone(latitude float64, longitude float64) *bool {
+	if g.config.PremiumZoneDistance <= 0 {
+		return nil
+	}
+	inZone := calculateDistance(g.premium, latitude, longitude, g.config.DistanceUnit) <= g.config.PremiumZoneDistance
+	return &inZone
+}
@@ -0,0 +1,158 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestAddressService_ReloadGeofence(t *testing.T) {
+	// About 2.1 miles from the center
+	address := ports.AddressValidationResult{IsValid: true, Latitude: 40.8448, Longitude: -73.8648}
+	initial := config.MapConfig{
+		MaxDistance:       1,
+		GeofenceInclusive: true,
+		DistanceUnit:      ports.DISTANCE_MILES,
+		CenterLat:         40.8313747,
+		CenterLng:         -73.8272283,
+	}
+
+	tests := []struct {
+		name        string
+		reload      config.MapConfig
+		wantErr     bool
+		wantInRange bool
+		wantUnit    string
+	}{
+		{
+			name:        "Test Larger Distance Brings The Address In Range",
+			reload:      config.MapConfig{MaxDistance: 3, GeofenceInclusive: true, DistanceUnit: ports.DISTANCE_MILES, CenterLat: 40.8313747, CenterLng: -73.8272283},
+			wantInRange: true,
+			wantUnit:    ports.DISTANCE_MILES,
+		},
+		{
+			name:        "Test Moved Center Brings The Address In Range",
+			reload:      config.MapConfig{MaxDistance: 1, GeofenceInclusive: true, DistanceUnit: ports.DISTANCE_KILOMETER, CenterLat: 40.8448, CenterLng: -73.8648},
+			wantInRange: true,
+			wantUnit:    ports.DISTANCE_KILOMETER,
+		},
+		{
+			name:        "Test Invalid Geofence Keeps The Current One",
+			reload:      config.MapConfig{MaxDistance: 3, DistanceUnit: ports.DISTANCE_MILES, CenterLat: 91, CenterLng: -73.8272283},
+			wantErr:     true,
+			wantInRange: false,
+			wantUnit:    ports.DISTANCE_MILES,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := services.NewAddressService(&stubValidator{result: address}, zap.NewNop(), initial, config.ValidationConfig{})
+
+			got, _ := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", services.ValidationOptions{})
+			if got.InRange == nil || *got.InRange {
+				t.Fatalf("AddressService.ValidateAddress() before reload InRange = %v, want false", got.InRange)
+			}
+
+			if err := s.ReloadGeofence(tt.reload); (err != nil) != tt.wantErr {
+				t.Fatalf("AddressService.ReloadGeofence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, _ = s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", services.ValidationOptions{})
+			if got.InRange == nil || *got.InRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() after reload InRange = %v, want %v", got.InRange, tt.wantInRange)
+			}
+			if unit := s.Geofence().DistanceUnit; unit != tt.wantUnit {
+				t.Errorf("AddressService.Geofence() DistanceUnit = %q, want %q", unit, tt.wantUnit)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateAddress_PremiumZone(t *testing.T) {
+	const centerLat, centerLng = 40.8313747, -73.8272283
+	// About 2.1 miles from the center
+	address := ports.AddressValidationResult{IsValid: true, LocationType: ports.LOCATION_TYPE_ROOFTOP, Latitude: 40.8448, Longitude: -73.8648}
+	yes, no := true, false
+
+	tests := []struct {
+		name          string
+		maxDistance   float64
+		premium       float64
+		premiumLat    float64
+		premiumLng    float64
+		options       services.ValidationOptions
+		wantInRange   bool
+		wantInPremium *bool
+	}{
+		{
+			name:          "Test In Range But Outside Premium Zone",
+			maxDistance:   3,
+			premium:       1,
+			premiumLat:    centerLat,
+			premiumLng:    centerLng,
+			wantInRange:   true,
+			wantInPremium: &no,
+		},
+		{
+			name:          "Test In Premium Zone But Out Of Range",
+			maxDistance:   1,
+			premium:       0.5,
+			premiumLat:    40.8448,
+			premiumLng:    -73.86,
+			wantInPremium: &yes,
+		},
+		{
+			name:          "Test In Range And In Premium Zone",
+			maxDistance:   3,
+			premium:       2.5,
+			premiumLat:    centerLat,
+			premiumLng:    centerLng,
+			wantInRange:   true,
+			wantInPremium: &yes,
+		},
+		{
+			name:        "Test Unconfigured Premium Zone Is Omitted",
+			maxDistance: 3,
+			wantInRange: true,
+		},
+		{
+			name:        "Test Skipped Geofence Omits Premium Zone",
+			maxDistance: 3,
+			premium:     2.5,
+			premiumLat:  centerLat,
+			premiumLng:  centerLng,
+			options:     services.ValidationOptions{SkipGeofence: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapConfig := config.MapConfig{
+				MaxDistance:         tt.maxDistance,
+				GeofenceInclusive:   true,
+				DistanceUnit:        ports.DISTANCE_MILES,
+				CenterLat:           centerLat,
+				CenterLng:           centerLng,
+				PremiumZoneDistance: tt.premium,
+				PremiumZoneLat:      tt.premiumLat,
+				PremiumZoneLng:      tt.premiumLng,
+			}
+			s := services.NewAddressService(&stubValidator{result: address}, zap.NewNop(), mapConfig, config.ValidationConfig{})
+
+			got, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", tt.options)
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if gotInRange := got.InRange != nil && *got.InRange; gotInRange != tt.wantInRange {
+				t.Errorf("AddressService.ValidateAddress() InRange = %v, want %v", gotInRange, tt.wantInRange)
+			}
+			if (got.InPremiumZone == nil) != (tt.wantInPremium == nil) ||
+				got.InPremiumZone != nil && *got.InPremiumZone != *tt.wantInPremium {
+				t.Errorf("AddressService.ValidateAddress() InPremiumZone = %v, want %v", got.InPremiumZone, tt.wantInPremium)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"address-validator/ports"
+)
+
+// rejectionCodes are the error codes of input refused before it reaches the provider,
+// the fixed label values of the rejection counter
+var rejectionCodes = []string{
+	ports.ERROR_CODE_EMPTY_ADDRESS,
+	ports.ERROR_CODE_ADDRESS_TOO_LONG,
+	ports.ERROR_CODE_MALFORMED_ADDRESS,
+	ports.ERROR_CODE_DISALLOWED_SCRIPT,
+	ports.ERROR_CODE_BLOCKED_REGION,
+	ports.ERROR_CODE_INVALID_COORDINATES,
+}
+
+// rejectionCounter counts input refused locally by error code
+type rejectionCounter struct {
+	counts map[string]uint64
+	mu     sync.Mutex
+}
+
+func (c *rejectionCounter) add(code string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]uint64, len(rejectionCodes))
+	}
+	c.counts[code]++
+}
+
+// reject refuses input that is not sent to the provider, counting it by its error code
+func (s *AddressService) reject(code string, err error) (ports.AddressValidationResult, error) {
+	s.rejections.add(code)
+	return ports.AddressValidationResult{
+		IsValid:   false,
+		Error:     err.Error(),
+		ErrorCode: code,
+	}, err
+}
+
+// CollectMetrics reports the input refused before reaching the provider for the metrics endpoint.
+// Every reason is reported from the start, so a rate of zero is told apart from a missing series.
+func (s *AddressService) CollectMetrics() []ports.Metric {
+	s.rejections.mu.Lock()
+	defer s.rejections.mu.Unlock()
+
+	metrics := make([]ports.Metric, len(rejectionCodes))
+	for i, code := range rejectionCodes {
+		metrics[i] = ports.Metric{
+			Name:   "address_input_rejected_total",
+			Help:   "Input refused before reaching the provider, by error code.",
+			Type:   ports.METRIC_COUNTER,
+			Labels: map[string]string{"reason": code},
+			Value:  float64(s.rejections.counts[code]),
+		}
+	}
+	return metrics
+}
+
+// isTooLong reports whether the address has more characters than configured
+func (s *AddressService) isTooLong(address string) bool {
+	maxLength := s.validation.MaxAddressLength
+	return maxLength > 0 && uint(utf8.RuneCountInString(address)) > maxLength
+}
+
+// isMalformed reports whether the address is not valid UTF-8 or holds control characters
+// other than whitespace, which no typed or pasted address contains
+func isMalformed(address string) bool {
+	if !utf8.ValidString(address) {
+		return true
+	}
+	return strings.ContainsFunc(address, func(r rune) bool {
+		return unicode.IsControl(r) && !unicode.IsSpace(r)
+	})
+}
+
+// hasAddressContent reports whether the address has a letter or a digit, rather than only whitespace and punctuation
+func hasAddressContent(address string) bool {
+	return strings.ContainsFunc(address, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,167 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// rejectionCount returns the rejection counter sample of the error code
+func rejectionCount(t *testing.T, s *services.AddressService, code string) float64 {
+	t.Helper()
+	for _, metric := range s.CollectMetrics() {
+		if metric.Name == "address_input_rejected_total" && metric.Labels["reason"] == code {
+			return metric.Value
+		}
+	}
+	t.Fatalf("AddressService.CollectMetrics() has no sample for reason %q", code)
+	return 0
+}
+
+func TestAddressService_ValidateAddress_InputRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		address    string
+		regionCode string
+		wantCode   string
+		wantErr    error
+	}{
+		{
+			name:     "Test Empty Address Is Rejected",
+			address:  "  <>  ",
+			wantCode: ports.ERROR_CODE_EMPTY_ADDRESS,
+			wantErr:  services.ErrEmptyAddress,
+		},
+		{
+			name:     "Test Whitespace Only Address Is Empty",
+			address:  "     ",
+			wantCode: ports.ERROR_CODE_EMPTY_ADDRESS,
+			wantErr:  services.ErrEmptyAddress,
+		},
+		{
+			name:     "Test Tabs And Newlines Only Address Is Empty",
+			address:  "\t\n\r\n\t",
+			wantCode: ports.ERROR_CODE_EMPTY_ADDRESS,
+			wantErr:  services.ErrEmptyAddress,
+		},
+		{
+			name:     "Test Punctuation Only Address Is Empty",
+			address:  " ., #-- ,. ",
+			wantCode: ports.ERROR_CODE_EMPTY_ADDRESS,
+			wantErr:  services.ErrEmptyAddress,
+		},
+		{
+			name:     "Test Long Whitespace Is Empty Rather Than Too Long",
+			address:  strings.Repeat(" \t", 40),
+			wantCode: ports.ERROR_CODE_EMPTY_ADDRESS,
+			wantErr:  services.ErrEmptyAddress,
+		},
+		{
+			name:     "Test Long Address Is Rejected",
+			address:  strings.Repeat("1 Main St ", 10),
+			wantCode: ports.ERROR_CODE_ADDRESS_TOO_LONG,
+			wantErr:  services.ErrAddressTooLong,
+		},
+		{
+			name:     "Test Control Characters Are Rejected",
+			address:  "123 Main St\x00, Bronx, NY",
+			wantCode: ports.ERROR_CODE_MALFORMED_ADDRESS,
+			wantErr:  services.ErrMalformedAddress,
+		},
+		{
+			name:     "Test Invalid UTF-8 Is Rejected",
+			address:  "123 Main St, Bronx\xff, NY",
+			wantCode: ports.ERROR_CODE_MALFORMED_ADDRESS,
+			wantErr:  services.ErrMalformedAddress,
+		},
+		{
+			name:     "Test Disallowed Script Is Rejected",
+			address:  "Москва, ул. Тверская 1",
+			wantCode: ports.ERROR_CODE_DISALLOWED_SCRIPT,
+			wantErr:  services.ErrDisallowedScript,
+		},
+		{
+			name:       "Test Blocked Region Request Is Rejected",
+			address:    "1 Main St, Pyongyang",
+			regionCode: "kp",
+			wantCode:   ports.ERROR_CODE_BLOCKED_REGION,
+			wantErr:    services.ErrBlockedRegion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &stubValidator{result: ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St"}}
+			validationConfig := config.ValidationConfig{
+				MaxAddressLength: 50,
+				AllowedScripts:   []string{"Latin"},
+				BlockedRegions:   []string{"KP"},
+			}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, validationConfig)
+
+			ctx := context.Background()
+			if tt.regionCode != "" {
+				ctx = ports.WithRegionCode(ctx, tt.regionCode)
+			}
+			got, err := s.ValidateAddress(ctx, tt.address, services.ValidationOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddressService.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+			if validator.called {
+				t.Error("AddressService.ValidateAddress() called the validator for rejected input")
+			}
+
+			for _, metric := range s.CollectMetrics() {
+				want := 0.0
+				if metric.Labels["reason"] == tt.wantCode {
+					want = 1
+				}
+				if metric.Value != want {
+					t.Errorf("address_input_rejected_total{reason=%q} = %v, want %v", metric.Labels["reason"], metric.Value, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateCoordinates_InputRejected(t *testing.T) {
+	validator := &stubValidator{}
+	s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+	s.SetReverseGeocoder(validator)
+
+	_, err := s.ValidateCoordinates(context.Background(), 91, 0, services.ValidationOptions{})
+	if !errors.Is(err, services.ErrInvalidCoordinates) {
+		t.Fatalf("AddressService.ValidateCoordinates() error = %v, want %v", err, services.ErrInvalidCoordinates)
+	}
+	if validator.called {
+		t.Error("AddressService.ValidateCoordinates() called the reverse geocoder for rejected input")
+	}
+	if got := rejectionCount(t, s, ports.ERROR_CODE_INVALID_COORDINATES); got != 1 {
+		t.Errorf("address_input_rejected_total{reason=%q} = %v, want 1", ports.ERROR_CODE_INVALID_COORDINATES, got)
+	}
+}
+
+func TestAddressService_ValidateAddress_AcceptedNotCounted(t *testing.T) {
+	validator := &stubValidator{result: ports.AddressValidationResult{IsValid: true, FormattedAddress: "123 Main St"}}
+	s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{MaxAddressLength: 50})
+
+	if _, err := s.ValidateAddress(context.Background(), "123 Main St, Bronx, NY", services.ValidationOptions{SkipGeofence: true}); err != nil {
+		t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+	}
+	if !validator.called {
+		t.Error("AddressService.ValidateAddress() did not call the validator")
+	}
+	for _, metric := range s.CollectMetrics() {
+		if metric.Value != 0 {
+			t.Errorf("address_input_rejected_total{reason=%q} = %v, want 0", metric.Labels["reason"], metric.Value)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"address-validator/ports"
+)
+
+// LoadLocalityAliases reads a JSON object of canonical locality names to their aliases,
+// e.g. {"Bronx": ["The Bronx", "Bronx County"]}
+func LoadLocalityAliases(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locality aliases: %w", err)
+	}
+
+	var aliases map[string][]string
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse locality aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// localityAlias is an alias and the canonical locality it stands for
+type localityAlias struct {
+	alias     string
+	canonical string
+}
+
+// LocalityAliasNormalizer rewrites known locality aliases to their canonical name, then hands the
+// address to the next normalizer
+type LocalityAliasNormalizer struct {
+	aliases []localityAlias
+	next    ports.AddressNormalizer
+}
+
+// NewLocalityAliasNormalizer creates a normalizer for aliases keyed by canonical name.
+// next may be nil when no other normalization applies.
+func NewLocalityAliasNormalizer(aliases map[string][]string, next ports.AddressNormalizer) *LocalityAliasNormalizer {
+	var flattened []localityAlias
+	for canonical, names := range aliases {
+		for _, alias := range names {
+			alias = strings.TrimSpace(alias)
+			if alias != "" {
+				flattened = append(flattened, localityAlias{alias: alias, canonical: canonical})
+			}
+		}
+	}
+	// Longest first, so "Bronx County" is matched before a shorter alias it starts with
+	slices.SortFunc(flattened, func(a, b localityAlias) int {
+		return len(b.alias) - len(a.alias)
+	})
+
+	return &LocalityAliasNormalizer{
+		aliases: flattened,
+		next:    next,
+	}
+}
+
+// Normalize replaces an alias making up a comma-separated part of the address, or starting one
+// before its region and postal code as in "The Bronx NY 10456". Street names are left alone since
+// an alias only matches a whole part or its beginning.
+func (n *LocalityAliasNormalizer) Normalize(address string, regionCode string) string {
+	parts := strings.Split(address, ",")
+	for i, part := range parts {
+		trimmed := strings.TrimSpace(part)
+		for _, alias := range n.aliases {
+			if !hasAliasPrefix(trimmed, alias.alias) {
+				continue
+			}
+			// Keep the space after the comma
+			leading := part[:len(part)-len(strings.TrimLeft(part, " "))]
+			parts[i] = leading + alias.canonical + trimmed[len(alias.alias):]
+			break
+		}
+	}
+	address = strings.Join(parts, ",")
+
+	if n.next != nil {
+		return n.next.Normalize(address, regionCode)
+	}
+	return address
+}
+
+// hasAliasPrefix reports whether part is the alias, or starts with it followed by a space, in any casing
+func hasAliasPrefix(part string, alias string) bool {
+	if len(part) < len(alias) || !strings.EqualFold(part[:len(alias)], alias) {
+		return false
+	}
+	return len(part) == len(alias) || part[len(alias)] == ' '
+}
+
+// Ensure the locality alias normalizer satisfies the port
+var _ ports.AddressNormalizer = (*LocalityAliasNormalizer)(nil)
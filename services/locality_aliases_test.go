@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"address-validator/services"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalityAliasNormalizer_Normalize(t *testing.T) {
+	aliases := map[string][]string{
+		"Bronx":     {"The Bronx", "Bronx County"},
+		"Manhattan": {"New York County"},
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		next    bool
+		want    string
+	}{
+		{
+			name:    "Test Alias Part Is Canonicalized",
+			address: "123 Main St, The Bronx, NY 10456",
+			want:    "123 Main St, Bronx, NY 10456",
+		},
+		{
+			name:    "Test Longer Alias Is Canonicalized",
+			address: "123 Main St, Bronx County, NY 10456",
+			want:    "123 Main St, Bronx, NY 10456",
+		},
+		{
+			name:    "Test Alias In Any Casing Is Canonicalized",
+			address: "350 5th Ave, new york county, NY",
+			want:    "350 5th Ave, Manhattan, NY",
+		},
+		{
+			name:    "Test Alias Before Region And Postal Code Is Canonicalized",
+			address: "123 Main St, The Bronx NY 10456",
+			want:    "123 Main St, Bronx NY 10456",
+		},
+		{
+			name:    "Test Canonical Locality Is Unchanged",
+			address: "123 Main St, Bronx, NY 10456",
+			want:    "123 Main St, Bronx, NY 10456",
+		},
+		{
+			name:    "Test Alias Inside A Street Name Is Unchanged",
+			address: "1 Museum Of The Bronx Way, Bronx, NY",
+			want:    "1 Museum Of The Bronx Way, Bronx, NY",
+		},
+		{
+			name:    "Test Next Normalizer Runs After Aliasing",
+			address: "123 Main Street, The Bronx, NY 10456",
+			next:    true,
+			want:    "123 Main St, Bronx, NY 10456",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var normalizer *services.LocalityAliasNormalizer
+			if tt.next {
+				normalizer = services.NewLocalityAliasNormalizer(aliases, services.NewUSAddressNormalizer(services.USNormalizerOptions{}))
+			} else {
+				normalizer = services.NewLocalityAliasNormalizer(aliases, nil)
+			}
+
+			if got := normalizer.Normalize(tt.address, "us"); got != tt.want {
+				t.Errorf("LocalityAliasNormalizer.Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLocalityAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Test Aliases File Normalizes To Canonical Locality",
+			content: `{"Bronx": ["The Bronx", "Bronx County"]}`,
+			want:    "123 Main St, Bronx, NY 10456",
+		},
+		{
+			name:    "Test Invalid File Is An Error",
+			content: `{"Bronx": "The Bronx"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "aliases.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("os.WriteFile() error = %v", err)
+			}
+
+			aliases, err := services.LoadLocalityAliases(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLocalityAliases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := services.NewLocalityAliasNormalizer(aliases, nil).Normalize("123 Main St, Bronx County, NY 10456", "us")
+			if got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
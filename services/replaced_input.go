@@ -0,0 +1,63 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+)
+
+// isInputReplaced reports whether the formatted address drops significant parts of the input.
+// It is a fallback for providers that don't flag replaced components themselves.
+// The input counts as replaced when any number (house, unit or zip) is missing from the
+// formatted address, or when at most half of its words survive.
+func isInputReplaced(input, formatted string) bool {
+	if formatted == "" {
+		return false
+	}
+
+	found := make(map[string]bool)
+	for _, token := range addressTokens(formatted) {
+		found[token] = true
+	}
+
+	var words, matchedWords int
+	for _, token := range addressTokens(input) {
+		if isNumeric(token) {
+			if !found[token] {
+				return true
+			}
+			continue
+		}
+
+		words++
+		if found[token] {
+			matchedWords++
+		}
+	}
+
+	return words > 0 && matchedWords*2 <= words
+}
+
+// addressTokens splits an address into lowercase words with USPS abbreviations applied,
+// so "Street" and "St" compare equal
+func addressTokens(address string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(address), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, field := range fields {
+		if abbreviation, ok := usAbbreviations[field]; ok {
+			fields[i] = strings.ToLower(abbreviation)
+		}
+	}
+	return fields
+}
+
+// isNumeric reports whether a token is made of digits only
+func isNumeric(token string) bool {
+	for _, r := range token {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return token != ""
+}
@@ -0,0 +1,48 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/services"
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestAddressService_ValidateAddress_Sanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{name: "Test Trims And Collapses Whitespace", address: "  123   Main St,\tBronx  ", want: "123 Main St, Bronx"},
+		{name: "Test Keeps Period Comma And Dash", address: "12-14 W. 4th St., Bronx", want: "12-14 W. 4th St., Bronx"},
+		{name: "Test Keeps Apartment Number Sign", address: "123 Main St #4B, Bronx", want: "123 Main St #4B, Bronx"},
+		{name: "Test Strips Markup Characters", address: "123 Main St<script>alert(1)</script>", want: "123 Main Stscriptalert1script"},
+		{name: "Test Strips Quotes And Semicolons", address: `123 "Main" St; DROP TABLE`, want: "123 Main St DROP TABLE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &recordingValidator{}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+
+			if _, err := s.ValidateAddress(context.Background(), tt.address, services.ValidationOptions{SkipGeofence: true}); err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if validator.address != tt.want {
+				t.Errorf("sanitized address = %q, want %q", validator.address, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkAddressService_ValidateAddress_Sanitize(b *testing.B) {
+	s := services.NewAddressService(&stubValidator{}, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+	ctx := context.Background()
+	options := services.ValidationOptions{SkipGeofence: true}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.ValidateAddress(ctx, "  123   North Main Street,  Apartment 4, Bronx, NY 10456  ", options)
+	}
+}
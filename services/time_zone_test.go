@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// stubTimeZone answers with a fixed time zone or error, standing in for the Time Zone API
+type stubTimeZone struct {
+	called   bool
+	lat, lng float64
+	timeZone string
+	err      error
+}
+
+func (s *stubTimeZone) TimeZone(ctx context.Context, latitude float64, longitude float64) (string, error) {
+	s.called = true
+	s.lat, s.lng = latitude, longitude
+	return s.timeZone, s.err
+}
+
+func TestAddressService_ValidateAddress_TimeZone(t *testing.T) {
+	bronx := ports.AddressValidationResult{
+		IsValid:          true,
+		FormattedAddress: "1600 Grand Concourse, Bronx, NY 10457, USA",
+		Latitude:         40.8399,
+		Longitude:        -73.9115,
+	}
+	invalid := ports.AddressValidationResult{IsValid: false, Error: "Address is incomplete."}
+
+	tests := []struct {
+		name         string
+		result       ports.AddressValidationResult
+		enabled      bool
+		timeZone     *stubTimeZone
+		wantTimeZone string
+		wantCalled   bool
+	}{
+		{
+			name:         "Test Known Coordinate Returns Its Time Zone",
+			result:       bronx,
+			enabled:      true,
+			timeZone:     &stubTimeZone{timeZone: "America/New_York"},
+			wantTimeZone: "America/New_York",
+			wantCalled:   true,
+		},
+		{
+			name:     "Test Disabled Time Zone Is Not Looked Up",
+			result:   bronx,
+			timeZone: &stubTimeZone{timeZone: "America/New_York"},
+		},
+		{
+			name:     "Test Invalid Address Is Not Looked Up",
+			result:   invalid,
+			enabled:  true,
+			timeZone: &stubTimeZone{timeZone: "America/New_York"},
+		},
+		{
+			name:       "Test Failed Lookup Omits Time Zone",
+			result:     bronx,
+			enabled:    true,
+			timeZone:   &stubTimeZone{err: errors.New("time zone error: ZERO_RESULTS")},
+			wantCalled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &stubValidator{result: tt.result}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{IncludeTimeZone: tt.enabled})
+			s.SetTimeZoneProvider(tt.timeZone)
+
+			got, err := s.ValidateAddress(context.Background(), "1600 Grand Concourse, Bronx, NY", services.ValidationOptions{SkipGeofence: true})
+			if err != nil {
+				t.Fatalf("AddressService.ValidateAddress() error = %v", err)
+			}
+			if got.TimeZone != tt.wantTimeZone {
+				t.Errorf("AddressService.ValidateAddress() TimeZone = %q, want %q", got.TimeZone, tt.wantTimeZone)
+			}
+			if tt.timeZone.called != tt.wantCalled {
+				t.Errorf("time zone looked up = %v, want %v", tt.timeZone.called, tt.wantCalled)
+			}
+			if tt.wantCalled && (tt.timeZone.lat != bronx.Latitude || tt.timeZone.lng != bronx.Longitude) {
+				t.Errorf("time zone looked up at %v,%v, want %v,%v", tt.timeZone.lat, tt.timeZone.lng, bronx.Latitude, bronx.Longitude)
+			}
+		})
+	}
+}
+
+func TestAddressService_ValidateCoordinates_TimeZone(t *testing.T) {
+	timeZone := &stubTimeZone{timeZone: "America/New_York"}
+	s := services.NewAddressService(&stubValidator{}, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{IncludeTimeZone: true})
+	s.SetTimeZoneProvider(timeZone)
+
+	got, err := s.ValidateCoordinates(context.Background(), 40.8399, -73.9115, services.ValidationOptions{SkipGeofence: true})
+	if err != nil {
+		t.Fatalf("AddressService.ValidateCoordinates() error = %v", err)
+	}
+	if got.TimeZone != "America/New_York" {
+		t.Errorf("AddressService.ValidateCoordinates() TimeZone = %q, want %q", got.TimeZone, "America/New_York")
+	}
+}
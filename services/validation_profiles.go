@@ -0,0 +1,114 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"address-validator/ports"
+
+	"go.uber.org/zap"
+)
+
+// ValidationProfile is a named bundle of validation policies a request can select, e.g. a strict
+// one for checkout and a lenient one for a marketing signup
+type ValidationProfile struct {
+	// MinGranularity is the coarsest validation granularity accepted, e.g. PREMISE, empty accepts any
+	MinGranularity string `json:"minGranularity,omitempty"`
+	// RequiredComponents are the component types a valid address must have, e.g. street_number
+	RequiredComponents []string `json:"requiredComponents,omitempty"`
+	// RequireDeliverable rejects valid addresses the provider reports as not deliverable
+	RequireDeliverable bool `json:"requireDeliverable,omitempty"`
+	// CheckGeofence defaults to true when omitted
+	CheckGeofence *bool `json:"checkGeofence,omitempty"`
+}
+
+// LoadValidationProfiles reads a JSON object of profile names to their policies,
+// e.g. {"checkout": {"minGranularity": "PREMISE", "requireDeliverable": true}}
+func LoadValidationProfiles(path string) (map[string]ValidationProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validation profiles: %w", err)
+	}
+
+	var profiles map[string]ValidationProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse validation profiles: %w", err)
+	}
+
+	for name, profile := range profiles {
+		if profile.MinGranularity != "" && !slices.Contains(ports.GRANULARITIES, profile.MinGranularity) {
+			return nil, fmt.Errorf("validation profile %q has unknown minGranularity %q", name, profile.MinGranularity)
+		}
+	}
+
+	return profiles, nil
+}
+
+// SetProfiles sets the profiles requests can select by name and the one applied to requests naming
+// none. An empty default applies the policies of the validation config to those requests instead.
+func (s *AddressService) SetProfiles(profiles map[string]ValidationProfile, defaultProfile string) error {
+	if _, ok := profiles[defaultProfile]; defaultProfile != "" && !ok {
+		return fmt.Errorf("%w: default %q", ErrUnknownProfile, defaultProfile)
+	}
+	s.profiles = profiles
+	s.defaultProfile = defaultProfile
+	return nil
+}
+
+// CheckProfile returns ErrUnknownProfile when name is not a configured profile, "" is always known
+func (s *AddressService) CheckProfile(name string) error {
+	_, err := s.profile(name)
+	return err
+}
+
+// profile returns the profile a request named, the default one when it named none, or the
+// policies of the validation config when no default is configured either
+func (s *AddressService) profile(name string) (ValidationProfile, error) {
+	if name == "" {
+		name = s.defaultProfile
+	}
+	if name == "" {
+		return ValidationProfile{
+			RequiredComponents: s.validation.RequiredComponents,
+			RequireDeliverable: s.validation.RequireDeliverable,
+		}, nil
+	}
+
+	profile, ok := s.profiles[name]
+	if !ok {
+		return ValidationProfile{}, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+	}
+	return profile, nil
+}
+
+// unknownProfile refuses a request naming a profile that is not configured
+func (s *AddressService) unknownProfile(err error) (ports.AddressValidationResult, error) {
+	s.logger.Warn("unknown validation profile", zap.Error(err))
+	return ports.AddressValidationResult{
+		IsValid:   false,
+		Error:     err.Error(),
+		ErrorCode: ports.ERROR_CODE_UNKNOWN_PROFILE,
+	}, err
+}
+
+// checkGranularity rejects valid results validated to a coarser granularity than the minimum.
+// Results of an unknown granularity are accepted, only the Address Validation API reports one.
+func (s *AddressService) checkGranularity(result *ports.AddressValidationResult, minGranularity string) {
+	rank := slices.Index(ports.GRANULARITIES, result.Granularity)
+	if minGranularity == "" || !result.IsValid || rank < 0 {
+		return
+	}
+	if rank <= slices.Index(ports.GRANULARITIES, minGranularity) {
+		return
+	}
+
+	s.logger.Info("rejecting address below the minimum granularity",
+		zap.String("granularity", result.Granularity),
+		zap.String("minGranularity", minGranularity),
+	)
+	result.IsValid = false
+	result.Error = ErrInsufficientGranularity.Error()
+	result.ErrorCode = ports.ERROR_CODE_INSUFFICIENT_GRANULARITY
+}
@@ -0,0 +1,146 @@
+package services_test
+
+import (
+	"address-validator/config"
+	"address-validator/ports"
+	"address-validator/services"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+var testProfiles = map[string]services.ValidationProfile{
+	"signup": {
+		MinGranularity: ports.GRANULARITY_ROUTE,
+	},
+	"checkout": {
+		MinGranularity:     ports.GRANULARITY_PREMISE,
+		RequiredComponents: []string{"street_number"},
+		RequireDeliverable: true,
+	},
+}
+
+func TestAddressService_ValidateAddress_Profile(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultProfile string
+		profile        string
+		wantValid      bool
+		wantCode       string
+		wantErr        error
+	}{
+		{
+			name:      "Test Route Level Address Passes Lenient Profile",
+			profile:   "signup",
+			wantValid: true,
+		},
+		{
+			name:     "Test Route Level Address Fails Strict Profile",
+			profile:  "checkout",
+			wantCode: ports.ERROR_CODE_INSUFFICIENT_GRANULARITY,
+		},
+		{
+			name:           "Test Default Profile Applies When None Is Named",
+			defaultProfile: "checkout",
+			wantCode:       ports.ERROR_CODE_INSUFFICIENT_GRANULARITY,
+		},
+		{
+			name:           "Test Named Profile Overrides Default",
+			defaultProfile: "checkout",
+			profile:        "signup",
+			wantValid:      true,
+		},
+		{
+			name:      "Test Validation Config Applies Without Default Profile",
+			wantValid: true,
+		},
+		{
+			name:     "Test Unknown Profile Is Rejected",
+			profile:  "wholesale",
+			wantCode: ports.ERROR_CODE_UNKNOWN_PROFILE,
+			wantErr:  services.ErrUnknownProfile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &stubValidator{result: ports.AddressValidationResult{
+				IsValid:          true,
+				FormattedAddress: "Main St, Bronx, NY 10456, USA",
+				LocationType:     ports.LOCATION_TYPE_GEOMETRIC_CENTER,
+				Granularity:      ports.GRANULARITY_ROUTE,
+				Components:       []ports.AddressComponent{{Type: "route", Text: "Main St"}},
+			}}
+			s := services.NewAddressService(validator, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+			if err := s.SetProfiles(testProfiles, tt.defaultProfile); err != nil {
+				t.Fatalf("AddressService.SetProfiles() error = %v", err)
+			}
+
+			got, err := s.ValidateAddress(context.Background(), "Main St, Bronx, NY", services.ValidationOptions{SkipGeofence: true, Profile: tt.profile})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddressService.ValidateAddress() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.IsValid != tt.wantValid {
+				t.Errorf("AddressService.ValidateAddress() IsValid = %v, want %v", got.IsValid, tt.wantValid)
+			}
+			if got.ErrorCode != tt.wantCode {
+				t.Errorf("AddressService.ValidateAddress() ErrorCode = %q, want %q", got.ErrorCode, tt.wantCode)
+			}
+			if tt.wantErr != nil && validator.called {
+				t.Error("AddressService.ValidateAddress() called the validator for an unknown profile")
+			}
+		})
+	}
+}
+
+func TestAddressService_SetProfiles_UnknownDefault(t *testing.T) {
+	s := services.NewAddressService(&stubValidator{}, zap.NewNop(), config.MapConfig{}, config.ValidationConfig{})
+	if err := s.SetProfiles(testProfiles, "wholesale"); !errors.Is(err, services.ErrUnknownProfile) {
+		t.Errorf("AddressService.SetProfiles() error = %v, want %v", err, services.ErrUnknownProfile)
+	}
+}
+
+func TestLoadValidationProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "Test Profiles File Is Loaded",
+			content: `{"checkout": {"minGranularity": "PREMISE", "requiredComponents": ["street_number"], "requireDeliverable": true}}`,
+		},
+		{
+			name:    "Test Unknown Granularity Is An Error",
+			content: `{"checkout": {"minGranularity": "HOUSE"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Test Invalid File Is An Error",
+			content: `{"checkout": "strict"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "profiles.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("os.WriteFile() error = %v", err)
+			}
+
+			profiles, err := services.LoadValidationProfiles(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadValidationProfiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := profiles["checkout"]; got.MinGranularity != ports.GRANULARITY_PREMISE || !got.RequireDeliverable {
+				t.Errorf("LoadValidationProfiles() checkout = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package timing
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phase is the duration of one named step of a request
+type Phase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Timings collects the phase durations of a single request
+type Timings struct {
+	phases []Phase
+	mu     sync.Mutex
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying a new Timings collector
+func NewContext(ctx context.Context) (context.Context, *Timings) {
+	timings := &Timings{}
+	return context.WithValue(ctx, contextKey{}, timings), timings
+}
+
+// Detach returns ctx without its Timings collector, for work shared between requests that none of
+// them should see in its own timings
+func Detach(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, (*Timings)(nil))
+}
+
+// FromContext returns the Timings collector of the context, or nil when timing is disabled
+func FromContext(ctx context.Context) *Timings {
+	timings, _ := ctx.Value(contextKey{}).(*Timings)
+	return timings
+}
+
+// Track starts timing a phase and returns the function that stops it.
+// It is a no-op when the context carries no collector.
+//
+//	defer timing.Track(ctx, "geocode")()
+func Track(ctx context.Context, name string) func() {
+	timings := FromContext(ctx)
+	if timings == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		timings.Record(name, time.Since(start))
+	}
+}
+
+// Record adds a completed phase
+func (t *Timings) Record(name string, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.phases = append(t.phases, Phase{Name: name, Duration: duration})
+}
+
+// Phases returns the recorded phases in completion order
+func (t *Timings) Phases() []Phase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]Phase(nil), t.phases...)
+}
+
+// Header formats the phases as a Server-Timing header value with durations in milliseconds
+func (t *Timings) Header() string {
+	phases := t.Phases()
+	entries := make([]string, 0, len(phases))
+	for _, phase := range phases {
+		ms := float64(phase.Duration) / float64(time.Millisecond)
+		entries = append(entries, phase.Name+";dur="+strconv.FormatFloat(ms, 'f', 3, 64))
+	}
+	return strings.Join(entries, ", ")
+}